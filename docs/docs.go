@@ -19,6 +19,114 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/audit": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "list the audit log of mutating api calls; admins may list all entries, other users must filter by network_id and need the rights of the 'admin' operation (default 'a') on all requested networks",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "audit"
+                ],
+                "summary": "list audit entries",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "comma separated list of network ids; required for non admins",
+                        "name": "network_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated list of user ids",
+                        "name": "user_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated list of entities (e.g. deployments, process-instances, incidents)",
+                        "name": "entity",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated list of entity ids",
+                        "name": "entity_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated list of actions (e.g. 'DELETE /deployments/{networkId}/{deploymentId}')",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "filter by outcome",
+                        "name": "success",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "RFC3339 timestamp; only entries at or after this time are returned",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "RFC3339 timestamp; only entries before this time are returned",
+                        "name": "until",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "default 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "default 0",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "default time.desc; time, user_id, network_id, entity or action with optional .asc or .desc",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.AuditEntry"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
         "/deployments": {
             "get": {
                 "security": [
@@ -26,7 +134,7 @@ const docTemplate = `{
                         "Bearer": []
                     }
                 ],
-                "description": "list deployments",
+                "description": "list deployments\nrequires the rights of the 'read' operation (default 'r') on all requested networks",
                 "produces": [
                     "application/json"
                 ],
@@ -122,7 +230,7 @@ const docTemplate = `{
                         "Bearer": []
                     }
                 ],
-                "description": "deploy process; prepared process may be requested from the process-fog-deployment service",
+                "description": "deploy process; prepared process may be requested from the process-fog-deployment service\nrequires the rights of the 'deploy' operation (default 'w') on the network\nwith deployment_owner_protection, only the owner of an existing deployment with the same id and users with the rights of the 'admin' operation may replace it",
                 "produces": [
                     "application/json"
                 ],
@@ -170,7 +278,7 @@ const docTemplate = `{
                         "Bearer": []
                     }
                 ],
-                "description": "get deployment",
+                "description": "get deployment\nrequires the rights of the 'read' operation (default 'r') on the network",
                 "produces": [
                     "application/json"
                 ],
@@ -224,7 +332,7 @@ const docTemplate = `{
                         "Bearer": []
                     }
                 ],
-                "description": "delete deployment",
+                "description": "delete deployment\nrequires the rights of the 'delete' operation (default 'a') on the network\nwith deployment_owner_protection, only the owner of the deployment and users with the rights of the 'admin' operation are allowed",
                 "produces": [
                     "application/json"
                 ],
@@ -277,7 +385,7 @@ const docTemplate = `{
                         "Bearer": []
                     }
                 ],
-                "description": "get deployment metadata",
+                "description": "get deployment metadata\nrequires the rights of the 'read' operation (default 'r') on the network",
                 "produces": [
                     "application/json"
                 ],
@@ -327,21 +435,25 @@ const docTemplate = `{
                 }
             }
         },
-        "/deployments/{networkId}/{deploymentId}/start": {
-            "get": {
+        "/deployments/{networkId}/{deploymentId}/restart-policy": {
+            "put": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "start deployed process; a process may expect parameters on start. these can be passed as query parameters. swagger allows no arbitrary/dynamic parameter names, which means a query with parameters must be executed manually",
+                "description": "set the default restart policy for warden handled process instances of the deployment. the policy is copied to instances on start; already started instances keep their policy. a null body removes the default policy.\nrequires the rights of the 'deploy' operation (default 'w') on the network\nwith deployment_owner_protection, only the owner of the deployment and users with the rights of the 'admin' operation are allowed",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "deployment"
+                    "deployment",
+                    "warden"
                 ],
-                "summary": "start deployed process",
+                "summary": "set deployment restart policy",
                 "parameters": [
                     {
                         "type": "string",
@@ -358,10 +470,13 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "business_key of new process instance",
-                        "name": "business_key",
-                        "in": "query"
+                        "description": "restart policy",
+                        "name": "message",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.RestartPolicy"
+                        }
                     }
                 ],
                 "responses": {
@@ -386,87 +501,46 @@ const docTemplate = `{
                 }
             }
         },
-        "/history/process-instances": {
+        "/deployments/{networkId}/{deploymentId}/start": {
             "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "list historic process-instances",
+                "description": "start deployed process; a process may expect parameters on start. these can be passed as query parameters. swagger allows no arbitrary/dynamic parameter names, which means a query with parameters must be executed manually\nrequires the rights of the 'start' operation (default 'x') on the network\nwith deployment_owner_protection, only the owner of the deployment and users with the rights of the 'admin' operation are allowed",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "process-instance"
+                    "deployment"
                 ],
-                "summary": "list historic process-instances",
+                "summary": "start deployed process",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "search",
-                        "name": "search",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "default 100",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "default 0",
-                        "name": "offset",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "default id.asc",
-                        "name": "sort",
-                        "in": "query"
+                        "description": "network id",
+                        "name": "networkId",
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "type": "string",
-                        "description": "comma separated list of network-ids, used to filter the result",
-                        "name": "network_id",
-                        "in": "query",
+                        "description": "deployment id",
+                        "name": "deploymentId",
+                        "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "comma separated list of business-keys, used to filter the result",
+                        "description": "business_key of new process instance",
                         "name": "business_key",
                         "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "process-definition-id, used to filter the result",
-                        "name": "processDefinitionId",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "state may be 'finished' or 'unfinished', used to filter the result",
-                        "name": "state",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "if set to true, wraps the result in an objet with the result {total:0, data:[]}",
-                        "name": "with_total",
-                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.HistoricProcessInstance"
-                            }
-                        }
+                        "description": "OK"
                     },
                     "400": {
                         "description": "Bad Request"
@@ -484,23 +558,24 @@ const docTemplate = `{
                         "description": "Internal Server Error"
                     }
                 }
-            }
-        },
-        "/history/process-instances/{networkId}/{id}": {
-            "get": {
+            },
+            "post": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "get historic process-instances",
+                "description": "start deployed process; the parameters are validated and coerced against the process_parameter of the deployment metadata. unknown parameters, missing required parameters (parameters without default value) and wrong types result in a 400 response listing all problems.\nrequires the rights of the 'start' operation (default 'x') on the network\nwith deployment_owner_protection, only the owner of the deployment and users with the rights of the 'admin' operation are allowed",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "process-instance"
+                    "deployment"
                 ],
-                "summary": "get historic process-instances",
+                "summary": "start deployed process with parameters",
                 "parameters": [
                     {
                         "type": "string",
@@ -511,18 +586,24 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "instance id",
-                        "name": "id",
+                        "description": "deployment id",
+                        "name": "deploymentId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "business_key and parameter of the new process instance",
+                        "name": "message",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.StartRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/model.HistoricProcessInstance"
-                        }
+                        "description": "OK"
                     },
                     "400": {
                         "description": "Bad Request"
@@ -540,40 +621,71 @@ const docTemplate = `{
                         "description": "Internal Server Error"
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/history/analytics": {
+            "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "get historic process-instances",
+                "description": "analyse the historic process-instances started in the time window per network and process-definition (or deployment): runs, success/termination/incident rates, approximated duration percentiles of finished runs and throughput; with_incidents only counts runs with incidents which are not yet resolved\nrequires the rights of the 'read' operation (default 'r') on all requested networks",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "process-instance"
                 ],
-                "summary": "get historic process-instances",
+                "summary": "get process execution analytics",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "network id",
-                        "name": "networkId",
-                        "in": "path",
+                        "description": "comma separated list of network-ids, used to filter the result",
+                        "name": "network_id",
+                        "in": "query",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "instance id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "definition (default) or deployment",
+                        "name": "group_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "process-definition-id, used to filter the result",
+                        "name": "process_definition_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "deployment-id, used to filter the result",
+                        "name": "deployment_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "RFC3339 timestamp; default 7 days before to",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "RFC3339 timestamp; default now",
+                        "name": "to",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.HistoryAnalytics"
+                            }
+                        }
                     },
                     "400": {
                         "description": "Bad Request"
@@ -584,31 +696,34 @@ const docTemplate = `{
                     "403": {
                         "description": "Forbidden"
                     },
-                    "404": {
-                        "description": "Not Found"
-                    },
                     "500": {
                         "description": "Internal Server Error"
                     }
                 }
             }
         },
-        "/incidents": {
+        "/history/process-instances": {
             "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "list incidents",
+                "description": "list historic process-instances\nrequires the rights of the 'read' operation (default 'r') on all requested networks",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "incidents"
+                    "process-instance"
                 ],
-                "summary": "list incidents",
+                "summary": "list historic process-instances",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "search",
+                        "name": "search",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
                         "description": "default 100",
@@ -623,7 +738,7 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "default id.asc",
+                        "description": "default id.asc; id, start_time, end_time or duration with optional .asc or .desc",
                         "name": "sort",
                         "in": "query"
                     },
@@ -636,9 +751,63 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "process-instance-id, used to filter the result",
-                        "name": "process_instance_id",
-                        "in": "query"
+                        "description": "comma separated list of business-keys, used to filter the result",
+                        "name": "business_key",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "process-definition-id, used to filter the result",
+                        "name": "processDefinitionId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "state may be 'finished' or 'unfinished', used to filter the result",
+                        "name": "state",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "RFC3339 timestamp; only instances started at or after this time",
+                        "name": "started_after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "RFC3339 timestamp; only instances started before this time",
+                        "name": "started_before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "RFC3339 timestamp; only instances ended at or after this time",
+                        "name": "ended_after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "RFC3339 timestamp; only instances ended before this time",
+                        "name": "ended_before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "go duration (e.g. 1h); finished instances by duration, unfinished instances by the time since their start",
+                        "name": "min_duration",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "go duration (e.g. 1h); finished instances by duration, unfinished instances by the time since their start",
+                        "name": "max_duration",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "if set to true, wraps the result in an objet with the result {total:0, data:[]}",
+                        "name": "with_total",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -647,7 +816,7 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/model.Incident"
+                                "$ref": "#/definitions/model.HistoricProcessInstance"
                             }
                         }
                     },
@@ -669,21 +838,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/incidents/{networkId}/{id}": {
+        "/history/process-instances/{networkId}/{id}": {
             "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "get incident",
+                "description": "get historic process-instances\nrequires the rights of the 'read' operation (default 'r') on the network",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "incidents"
+                    "process-instance"
                 ],
-                "summary": "get incident",
+                "summary": "get historic process-instances",
                 "parameters": [
                     {
                         "type": "string",
@@ -694,7 +863,7 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "incident id",
+                        "description": "instance id",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -704,7 +873,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Incident"
+                            "$ref": "#/definitions/model.HistoricProcessInstance"
                         }
                     },
                     "400": {
@@ -730,14 +899,14 @@ const docTemplate = `{
                         "Bearer": []
                     }
                 ],
-                "description": "delete incident",
+                "description": "get historic process-instances\nrequires the rights of the 'delete' operation (default 'a') on the network\nwith deployment_owner_protection, only the owner of the deployment of the historic instance and users with the rights of the 'admin' operation are allowed",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "incidents"
+                    "process-instance"
                 ],
-                "summary": "delete incident",
+                "summary": "get historic process-instances",
                 "parameters": [
                     {
                         "type": "string",
@@ -748,7 +917,7 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "incident id",
+                        "description": "instance id",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -776,93 +945,61 @@ const docTemplate = `{
                 }
             }
         },
-        "/metadata/{networkId}": {
+        "/incidents": {
             "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "list deployment metadata",
+                "description": "list incidents\nrequires the rights of the 'read' operation (default 'r') on all requested networks",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "deployment",
-                    "metadata"
+                    "incidents"
                 ],
-                "summary": "list deployment metadata",
+                "summary": "list incidents",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "network id",
-                        "name": "networkId",
-                        "in": "path",
-                        "required": true
+                        "type": "integer",
+                        "description": "default 100",
+                        "name": "limit",
+                        "in": "query"
                     },
                     {
-                        "type": "string",
-                        "description": "deployment id",
-                        "name": "deployment_id",
+                        "type": "integer",
+                        "description": "default 0",
+                        "name": "offset",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "camunda deployment id",
-                        "name": "camunda_deployment_id",
+                        "description": "default id.asc",
+                        "name": "sort",
                         "in": "query"
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.DeploymentMetadata"
-                            }
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request"
-                    },
-                    "401": {
-                        "description": "Unauthorized"
-                    },
-                    "403": {
-                        "description": "Forbidden"
                     },
-                    "404": {
-                        "description": "Not Found"
+                    {
+                        "type": "string",
+                        "description": "comma separated list of network-ids, used to filter the result",
+                        "name": "network_id",
+                        "in": "query",
+                        "required": true
                     },
-                    "500": {
-                        "description": "Internal Server Error"
-                    }
-                }
-            }
-        },
-        "/networks": {
-            "get": {
-                "security": [
                     {
-                        "Bearer": []
+                        "type": "string",
+                        "description": "process-instance-id, used to filter the result",
+                        "name": "process_instance_id",
+                        "in": "query"
                     }
                 ],
-                "description": "list networks",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "networks"
-                ],
-                "summary": "list networks",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.Hub"
+                                "$ref": "#/definitions/model.Incident"
                             }
                         }
                     },
@@ -884,22 +1021,35 @@ const docTemplate = `{
                 }
             }
         },
-        "/process-definitions": {
+        "/incidents/groups": {
             "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "list process-definitions",
+                "description": "list incidents grouped by network, process definition, activity and normalized error message (ids, timestamps and numbers replaced by placeholders)\nrequires the rights of the 'read' operation (default 'r') on all requested networks",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "process-definitions"
+                    "incidents"
                 ],
-                "summary": "list process-definitions",
+                "summary": "list incident groups",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "comma separated list of network-ids, used to filter the result",
+                        "name": "network_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "process-definition-id, used to filter the result",
+                        "name": "process_definition_id",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
                         "description": "default 100",
@@ -914,16 +1064,9 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "default id.asc",
+                        "description": "default last_seen.desc; last_seen, first_seen or count with optional .asc or .desc",
                         "name": "sort",
                         "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "comma separated list of network-ids used to filter",
-                        "name": "network_id",
-                        "in": "query",
-                        "required": true
                     }
                 ],
                 "responses": {
@@ -932,7 +1075,7 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/model.ProcessDefinition"
+                                "$ref": "#/definitions/model.IncidentGroup"
                             }
                         }
                     },
@@ -945,30 +1088,27 @@ const docTemplate = `{
                     "403": {
                         "description": "Forbidden"
                     },
-                    "404": {
-                        "description": "Not Found"
-                    },
                     "500": {
                         "description": "Internal Server Error"
                     }
                 }
             }
         },
-        "/process-definitions/{networkId}/{id}": {
-            "get": {
+        "/incidents/groups/{networkId}/{groupId}/acknowledge": {
+            "post": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "get process-definition",
+                "description": "acknowledge the incident group; the group is reported as acknowledged until a new incident of the group occurs\nrequires the rights of the 'admin' operation (default 'a') on the network",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "process-definitions"
+                    "incidents"
                 ],
-                "summary": "get process-definition",
+                "summary": "acknowledge incident group",
                 "parameters": [
                     {
                         "type": "string",
@@ -979,8 +1119,8 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "process definition id",
-                        "name": "id",
+                        "description": "incident group id",
+                        "name": "groupId",
                         "in": "path",
                         "required": true
                     }
@@ -989,7 +1129,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.ProcessDefinition"
+                            "$ref": "#/definitions/model.IncidentGroupState"
                         }
                     },
                     "400": {
@@ -1001,65 +1141,54 @@ const docTemplate = `{
                     "403": {
                         "description": "Forbidden"
                     },
-                    "404": {
-                        "description": "Not Found"
-                    },
                     "500": {
                         "description": "Internal Server Error"
                     }
                 }
             }
         },
-        "/process-instances": {
-            "get": {
+        "/incidents/groups/{networkId}/{groupId}/mute": {
+            "post": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "list process-instances",
+                "description": "stop notifications for new incidents of the group\nrequires the rights of the 'admin' operation (default 'a') on the network",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "process-instance"
+                    "incidents"
                 ],
-                "summary": "list process-instances",
+                "summary": "mute incident group",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "default 100",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "default 0",
-                        "name": "offset",
-                        "in": "query"
+                        "type": "string",
+                        "description": "network id",
+                        "name": "networkId",
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "type": "string",
-                        "description": "default id.asc",
-                        "name": "sort",
-                        "in": "query"
+                        "description": "incident group id",
+                        "name": "groupId",
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "type": "string",
-                        "description": "comma separated list of network-ids used to filter",
-                        "name": "network_id",
-                        "in": "query",
-                        "required": true
+                        "description": "go duration (e.g. 24h); without duration, the group is muted until it is unmuted",
+                        "name": "duration",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.ProcessInstance"
-                            }
+                            "$ref": "#/definitions/model.IncidentGroupState"
                         }
                     },
                     "400": {
@@ -1071,27 +1200,25 @@ const docTemplate = `{
                     "403": {
                         "description": "Forbidden"
                     },
-                    "404": {
-                        "description": "Not Found"
-                    },
                     "500": {
                         "description": "Internal Server Error"
                     }
                 }
-            }
-        },
-        "/process-instances-by-business-key/{networkId}/{business_key}": {
+            },
             "delete": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "stop process-instances identified by business-key",
+                "description": "resume notifications for new incidents of the group\nrequires the rights of the 'admin' operation (default 'a') on the network",
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "process-instance"
+                    "incidents"
                 ],
-                "summary": "delete process-instances by business-key",
+                "summary": "unmute incident group",
                 "parameters": [
                     {
                         "type": "string",
@@ -1102,61 +1229,1708 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "business-key of process-instances",
-                        "name": "business_key",
+                        "description": "incident group id",
+                        "name": "groupId",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.IncidentGroupState"
+                        }
                     },
                     "400": {
                         "description": "Bad Request"
                     },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
                     "500": {
                         "description": "Internal Server Error"
                     }
                 }
             }
         },
-        "/process-instances/{networkId}/{id}": {
+        "/incidents/stats": {
             "get": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "get process-instances",
+                "description": "count the stored incidents (resolved and deleted incidents are not included) per time bucket\nrequires the rights of the 'read' operation (default 'r') on all requested networks",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "process-instance"
+                    "incidents"
                 ],
-                "summary": "get process-instances",
+                "summary": "get incident stats",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "network id",
-                        "name": "networkId",
-                        "in": "path",
+                        "description": "comma separated list of network-ids, used to filter the result",
+                        "name": "network_id",
+                        "in": "query",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "instance id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                        "description": "incident group id, used to filter the result",
+                        "name": "group_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "RFC3339 timestamp; default 24h before to; buckets are aligned to from",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "RFC3339 timestamp; default now",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "bucket size as go duration (e.g. 15m); default 1h; at most 1000 buckets",
+                        "name": "interval",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.IncidentStats"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/incidents/{networkId}/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "get incident\nrequires the rights of the 'read' operation (default 'r') on the network",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "incidents"
+                ],
+                "summary": "get incident",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "network id",
+                        "name": "networkId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "incident id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Incident"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "delete incident\nrequires the rights of the 'delete' operation (default 'a') on the network\nwith deployment_owner_protection, only the owner of the deployment of the incident and users with the rights of the 'admin' operation are allowed",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "incidents"
+                ],
+                "summary": "delete incident",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "network id",
+                        "name": "networkId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "incident id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/incidents/{networkId}/{id}/{command}": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "sends a command for the incident to the mgw: retry (increments the retries of the failed external task), resolve (resolves the incident without retry) or restart (stops the process instance and restarts it; warden handled instances are stopped and restarted by the warden).\nthe incident is marked with a pending_command until the mgw removes the incident; further commands are rejected with 409 until the incident_command_timeout is reached.\nrequires the rights of the 'start' operation (default 'x') on the network\nwith deployment_owner_protection, only the owner of the deployment of the process instance and users with the rights of the 'admin' operation are allowed",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "incidents"
+                ],
+                "summary": "send incident command",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "network id",
+                        "name": "networkId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "incident id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "retry",
+                            "resolve",
+                            "restart"
+                        ],
+                        "type": "string",
+                        "description": "command",
+                        "name": "command",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "retries to add for the retry command; default 1",
+                        "name": "retries",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Incident"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "409": {
+                        "description": "Conflict"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/leases": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "list the currently held leases (e.g. of the warden loops, backfills and retention) and their holders; only for admins. expired leases may be listed until they are removed by the database.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "lease"
+                ],
+                "summary": "list leases",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Lease"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/metadata/{networkId}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "list deployment metadata\nrequires the rights of the 'read' operation (default 'r') on the network",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "deployment",
+                    "metadata"
+                ],
+                "summary": "list deployment metadata",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "network id",
+                        "name": "networkId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "deployment id",
+                        "name": "deployment_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "camunda deployment id",
+                        "name": "camunda_deployment_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.DeploymentMetadata"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/networks": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "list networks",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "networks"
+                ],
+                "summary": "list networks",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Hub"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/networks/status": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "list the sync status of multiple networks (e.g. for dashboards); see /networks/{networkId}/status\nrequires the rights of the 'read' operation (default 'r') on all requested networks",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "networks"
+                ],
+                "summary": "list network status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "comma separated list of network-ids",
+                        "name": "network_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "placeholders waiting longer than this number of minutes are counted as waiting; default network_placeholder_wait config",
+                        "name": "placeholder_wait_minutes",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.NetworkStatus"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/networks/{networkId}": {
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "removes all data of a retired network: warden infos, optionally the deployments on the mgw, history and incidents (archived first if an archive store is configured), all other elements and the last contact; streams one model.NetworkDecommissionProgress per finished step as NDJSON; the last line has the step 'done' or an error\nrequires the rights of the 'delete' operation (default 'a') on the network",
+                "produces": [
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "networks"
+                ],
+                "summary": "decommission network",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "network id",
+                        "name": "networkId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "sends delete commands for all deployments to the mgw",
+                        "name": "delete_on_edge",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.NetworkDecommissionProgress"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/networks/{networkId}/export": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "streams the complete sync state of the network (deployments, metadata, definitions, instances, history, incidents and warden infos) as NDJSON: the first line is a model.NetworkExportHeader, every following line a model.NetworkExportRecord; the last record has the type 'end' and is missing if the export failed while streaming\nrequires the rights of the 'read' operation (default 'r') on the network",
+                "produces": [
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "networks"
+                ],
+                "summary": "export network",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "network id",
+                        "name": "networkId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/networks/{networkId}/import": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "imports an export of /networks/{networkId}/export into the network; the export is validated completely before any document is saved or removed; without replace, the imported documents are merged into the current sync state\nrequires the rights of the 'admin' operation (default 'a') on the network",
+                "consumes": [
+                    "application/x-ndjson"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "networks"
+                ],
+                "summary": "import network",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "target network id",
+                        "name": "networkId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "allows the import of an export of another network; all documents are moved to the target network",
+                        "name": "rewrite_network_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "skips placeholder documents",
+                        "name": "skip_placeholders",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "removes the current sync state of the target network before the import",
+                        "name": "replace",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.NetworkImportResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/networks/{networkId}/status": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "get the sync status of a network: last contact (total and per entity topic), placeholders waiting for the mgw, deployments marked as missing or for delete, open incidents and the derived health (online, degraded, offline)\nrequires the rights of the 'read' operation (default 'r') on the network",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "networks"
+                ],
+                "summary": "get network status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "network id",
+                        "name": "networkId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "placeholders waiting longer than this number of minutes are counted as waiting; default network_placeholder_wait config",
+                        "name": "placeholder_wait_minutes",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.NetworkStatus"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/process-definitions": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "list process-definitions\nrequires the rights of the 'read' operation (default 'r') on all requested networks",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "process-definitions"
+                ],
+                "summary": "list process-definitions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "default 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "default 0",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "default id.asc",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated list of network-ids used to filter",
+                        "name": "network_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.ProcessDefinition"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/process-definitions/{networkId}/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "get process-definition\nrequires the rights of the 'read' operation (default 'r') on the network",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "process-definitions"
+                ],
+                "summary": "get process-definition",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "network id",
+                        "name": "networkId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "process definition id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ProcessDefinition"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/process-instances": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "list process-instances\nrequires the rights of the 'read' operation (default 'r') on all requested networks",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "process-instance"
+                ],
+                "summary": "list process-instances",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "default 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "default 0",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "default id.asc",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated list of network-ids used to filter",
+                        "name": "network_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.ProcessInstance"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/process-instances-by-business-key/{networkId}/{business_key}": {
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "stop process-instances identified by business-key\nrequires the rights of the 'stop' operation (default 'x') on the network\nwith deployment_owner_protection, only the owner of the deployments of the instances and users with the rights of the 'admin' operation are allowed",
+                "tags": [
+                    "process-instance"
+                ],
+                "summary": "delete process-instances by business-key",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "network id",
+                        "name": "networkId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "business-key of process-instances",
+                        "name": "business_key",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/process-instances/{networkId}/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "get process-instances\nrequires the rights of the 'read' operation (default 'r') on the network",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "process-instance"
+                ],
+                "summary": "get process-instances",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "network id",
+                        "name": "networkId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "instance id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.HistoricProcessInstance"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "get process-instances\nrequires the rights of the 'stop' operation (default 'x') on the network\nwith deployment_owner_protection, only the owner of the deployment of the instance and users with the rights of the 'admin' operation are allowed",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "process-instance"
+                ],
+                "summary": "get process-instances",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "network id",
+                        "name": "networkId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "instance id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/schedules": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "list schedules including their next planned run and the status of the last run\nrequires the rights of the 'read' operation (default 'r') on all requested networks",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "schedule"
+                ],
+                "summary": "list schedules",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "comma separated list of network-ids used to filter the schedules",
+                        "name": "network_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated list of deployment-ids used to filter the schedules",
+                        "name": "deployment_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "default 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "default 0",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "default id.asc; allowed fields: id, deployment_id, next_run",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Schedule"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/schedules/{networkId}": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "create a schedule which starts the referenced deployment according to its cron expression. the fields id, network_id, next_run and last_run are set by the service. the parameter are validated against the deployment metadata on each run.\nrequires the rights of the 'start' operation (default 'x') on the network\nwith deployment_owner_protection, only the owner of the scheduled deployment and users with the rights of the 'admin' operation are allowed",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "schedule"
+                ],
+                "summary": "create schedule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "network id",
+                        "name": "networkId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "schedule",
+                        "name": "message",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.Schedule"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Schedule"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/schedules/{networkId}/{scheduleId}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "get schedule\nrequires the rights of the 'read' operation (default 'r') on the network",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "schedule"
+                ],
+                "summary": "get schedule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "network id",
+                        "name": "networkId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "schedule id",
+                        "name": "scheduleId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Schedule"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "update schedule; the next run is recomputed, the last run status is kept\nrequires the rights of the 'start' operation (default 'x') on the network\nwith deployment_owner_protection, only the owner of the stored and the new scheduled deployment and users with the rights of the 'admin' operation are allowed",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "schedule"
+                ],
+                "summary": "update schedule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "network id",
+                        "name": "networkId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "schedule id",
+                        "name": "scheduleId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "schedule",
+                        "name": "message",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.Schedule"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Schedule"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "delete schedule\nrequires the rights of the 'start' operation (default 'x') on the network\nwith deployment_owner_protection, only the owner of the scheduled deployment and users with the rights of the 'admin' operation are allowed",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "schedule"
+                ],
+                "summary": "delete schedule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "network id",
+                        "name": "networkId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "schedule id",
+                        "name": "scheduleId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/sync/deployments/{networkId}": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "resync deployments that are registered as lost on the mgw side. can only be tried once.\nrequires the rights of the 'deploy' operation (default 'w') on the network",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "deployment"
+                ],
+                "summary": "resync deployments",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "network id",
+                        "name": "networkId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/warden/{networkId}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "list the process instances handled by the warden in the network with their computed status (healthy, missing_instance, duplicate, restarting, waiting_for_age_gate, finished, gave_up, paused)\nrequires the rights of the 'read' operation (default 'r') on the network",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "warden"
+                ],
+                "summary": "list warden infos",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "network id",
+                        "name": "networkId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated list of deployment-ids used to filter the warden infos",
+                        "name": "deployment_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "default 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "default 0",
+                        "name": "offset",
+                        "in": "query"
+                    }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.HistoricProcessInstance"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.WardenInfoWithStatus"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/warden/{networkId}/decisions": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "list the decisions of the warden (start, restart, stop, redeploy, replace_placeholder, remove_warden, give_up) in the network; decisions with dry_run=true have been logged but not executed\nrequires the rights of the 'read' operation (default 'r') on the network",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "warden"
+                ],
+                "summary": "list warden decisions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "network id",
+                        "name": "networkId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated list of entity ids (business key, deployment id or process instance id)",
+                        "name": "entity_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated list of business keys; may be passed with or without the 'wardened:' prefix",
+                        "name": "business_key",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated list of decisions",
+                        "name": "decision",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "RFC3339 timestamp; only decisions made at or after this time are returned",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "default 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "default 0",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "default time.desc; time, entity_id or decision with optional .asc or .desc",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.WardenDecision"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/warden/{networkId}/deployments/{deploymentId}/pause": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "pause the warden handling of the deployment and its process instances; paused instances are neither restarted nor stopped and the deployment is not redeployed. instances started while the deployment is paused are paused too.\nrequires the rights of the 'admin' operation (default 'a') on the network\nwith deployment_owner_protection, only the owner of the deployment and users with the rights of the 'admin' operation are allowed",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "warden"
+                ],
+                "summary": "pause deployment warden",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "network id",
+                        "name": "networkId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "deployment id",
+                        "name": "deploymentId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/warden/{networkId}/deployments/{deploymentId}/resume": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "resume the warden handling of the deployment and its process instances\nrequires the rights of the 'admin' operation (default 'a') on the network\nwith deployment_owner_protection, only the owner of the deployment and users with the rights of the 'admin' operation are allowed",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "warden"
+                ],
+                "summary": "resume deployment warden",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "network id",
+                        "name": "networkId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "deployment id",
+                        "name": "deploymentId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/warden/{networkId}/{businessKey}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "get the warden info of a process instance with its computed status; the business key may be passed with or without the 'wardened:' prefix\nrequires the rights of the 'read' operation (default 'r') on the network",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "warden"
+                ],
+                "summary": "get warden info",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "network id",
+                        "name": "networkId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "business key",
+                        "name": "businessKey",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.WardenInfoWithStatus"
                         }
                     },
                     "400": {
@@ -1182,14 +2956,14 @@ const docTemplate = `{
                         "Bearer": []
                     }
                 ],
-                "description": "get process-instances",
+                "description": "stop wardening the process instance; the process instance itself is not stopped, but it may be stopped by the warden as instance without warden info\nrequires the rights of the 'admin' operation (default 'a') on the network",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "process-instance"
+                    "warden"
                 ],
-                "summary": "get process-instances",
+                "summary": "remove warden info",
                 "parameters": [
                     {
                         "type": "string",
@@ -1200,8 +2974,8 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "instance id",
-                        "name": "id",
+                        "description": "business key",
+                        "name": "businessKey",
                         "in": "path",
                         "required": true
                     }
@@ -1228,21 +3002,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/sync/deployments/{networkId}": {
+        "/warden/{networkId}/{businessKey}/check": {
             "post": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
-                "description": "resync deployments that are registered as lost on the mgw side. can only be tried once.",
+                "description": "run the warden check for the process instance now (also if it is paused); the check may start or stop process instances. returns the resulting state; status 'removed' signals that the check removed the warden info.\nwith warden_leader_election, the check runs only on the replica holding the warden db loop lease, never concurrently with the db loop; other replicas queue the check for the lease holder (with warden_event_checks; paused entries are skipped by queued checks) and return the current state with 202, or respond with 503 without warden_event_checks\nrequires the rights of the 'start' operation (default 'x') on the network\nwith deployment_owner_protection, only the owner of the deployment and users with the rights of the 'admin' operation are allowed",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "deployment"
+                    "warden"
                 ],
-                "summary": "resync deployments",
+                "summary": "check warden info",
                 "parameters": [
                     {
                         "type": "string",
@@ -1250,11 +3024,27 @@ const docTemplate = `{
                         "name": "networkId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "business key",
+                        "name": "businessKey",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.WardenInfoWithStatus"
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/model.WardenInfoWithStatus"
+                        }
                     },
                     "400": {
                         "description": "Bad Request"
@@ -1270,6 +3060,9 @@ const docTemplate = `{
                     },
                     "500": {
                         "description": "Internal Server Error"
+                    },
+                    "503": {
+                        "description": "Service Unavailable"
                     }
                 }
             }
@@ -1324,6 +3117,49 @@ const docTemplate = `{
                 "valueInfo": {}
             }
         },
+        "model.AuditEntry": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "description": "method and pattern of the endpoint (e.g. \"DELETE /deployments/{networkId}/{deploymentId}\")",
+                    "type": "string"
+                },
+                "entity": {
+                    "description": "first path segment of the endpoint (e.g. deployments, process-instances, incidents)",
+                    "type": "string"
+                },
+                "entity_id": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "network_id": {
+                    "type": "string"
+                },
+                "parameters": {
+                    "description": "path values, query parameters and request body",
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "status_code": {
+                    "type": "integer"
+                },
+                "success": {
+                    "type": "boolean"
+                },
+                "time": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "description": "subject of the jwt; empty if the token is missing or invalid",
+                    "type": "string"
+                }
+            }
+        },
         "model.Deployment": {
             "type": "object",
             "properties": {
@@ -1518,119 +3354,458 @@ const docTemplate = `{
                 "caseInstanceId": {
                     "type": "string"
                 },
-                "deleteReason": {
+                "deleteReason": {
+                    "type": "string"
+                },
+                "durationInMillis": {
+                    "type": "number"
+                },
+                "endTime": {
+                    "type": "string"
+                },
+                "end_date": {
+                    "description": "parsed EndTime; nil while unfinished",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_placeholder": {
+                    "type": "boolean"
+                },
+                "marked_as_missing": {
+                    "type": "boolean"
+                },
+                "marked_for_delete": {
+                    "type": "boolean"
+                },
+                "network_id": {
+                    "type": "string"
+                },
+                "processDefinitionId": {
+                    "type": "string"
+                },
+                "processDefinitionKey": {
+                    "type": "string"
+                },
+                "processDefinitionName": {
+                    "type": "string"
+                },
+                "processDefinitionVersion": {
+                    "type": "number"
+                },
+                "startActivityId": {
+                    "type": "string"
+                },
+                "startTime": {
+                    "type": "string"
+                },
+                "startUserId": {
+                    "type": "string"
+                },
+                "start_date": {
+                    "description": "parsed StartTime",
+                    "type": "string"
+                },
+                "state": {
+                    "type": "string"
+                },
+                "superCaseInstanceId": {
+                    "type": "string"
+                },
+                "superProcessInstanceId": {
+                    "type": "string"
+                },
+                "sync_date": {
+                    "type": "string"
+                },
+                "tenantId": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.HistoryAnalytics": {
+            "type": "object",
+            "properties": {
+                "completed": {
+                    "type": "integer"
+                },
+                "deployment_id": {
+                    "description": "only if grouped by deployment",
+                    "type": "string"
+                },
+                "duration_millis": {
+                    "description": "of finished runs; percentiles are approximated with a deviation below 2%",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.HistoryDurationAnalytics"
+                        }
+                    ]
+                },
+                "finished": {
+                    "type": "integer"
+                },
+                "from": {
+                    "type": "string"
+                },
+                "incident_rate": {
+                    "description": "with_incidents / runs",
+                    "type": "number"
+                },
+                "network_id": {
+                    "type": "string"
+                },
+                "process_definition_id": {
+                    "description": "only if grouped by definition",
+                    "type": "string"
+                },
+                "process_definition_name": {
+                    "type": "string"
+                },
+                "running": {
+                    "type": "integer"
+                },
+                "runs": {
+                    "type": "integer"
+                },
+                "success_rate": {
+                    "description": "completed / finished",
+                    "type": "number"
+                },
+                "terminated": {
+                    "description": "externally or internally terminated",
+                    "type": "integer"
+                },
+                "termination_rate": {
+                    "description": "terminated / finished",
+                    "type": "number"
+                },
+                "throughput_per_hour": {
+                    "description": "finished runs per hour of the time window",
+                    "type": "number"
+                },
+                "to": {
+                    "type": "string"
+                },
+                "with_incidents": {
+                    "description": "runs with incidents which are still stored; incidents are removed when they are resolved, so runs with only resolved incidents are not counted",
+                    "type": "integer"
+                }
+            }
+        },
+        "model.HistoryDurationAnalytics": {
+            "type": "object",
+            "properties": {
+                "avg": {
+                    "type": "number"
+                },
+                "max": {
+                    "type": "number"
+                },
+                "min": {
+                    "type": "number"
+                },
+                "p50": {
+                    "type": "number"
+                },
+                "p90": {
+                    "type": "number"
+                },
+                "p95": {
+                    "type": "number"
+                },
+                "p99": {
+                    "type": "number"
+                }
+            }
+        },
+        "model.Incident": {
+            "type": "object",
+            "properties": {
+                "activity_id": {
+                    "description": "optional; sent by mgw versions with incident grouping support",
+                    "type": "string"
+                },
+                "business_key": {
+                    "type": "string"
+                },
+                "deployment_name": {
+                    "type": "string"
+                },
+                "error_message": {
+                    "type": "string"
+                },
+                "external_task_id": {
+                    "type": "string"
+                },
+                "group_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_placeholder": {
+                    "type": "boolean"
+                },
+                "marked_as_missing": {
+                    "type": "boolean"
+                },
+                "marked_for_delete": {
+                    "type": "boolean"
+                },
+                "msg_version": {
+                    "description": "from version 3 onward will be set in KafkaIncidentsCommand and be copied to this field",
+                    "type": "integer"
+                },
+                "network_id": {
+                    "type": "string"
+                },
+                "normalized_error_message": {
+                    "type": "string"
+                },
+                "pending_command": {
+                    "description": "command sent to the mgw; pending until the mgw removes the incident",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.IncidentCommand"
+                        }
+                    ]
+                },
+                "process_definition_id": {
+                    "type": "string"
+                },
+                "process_instance_id": {
+                    "type": "string"
+                },
+                "sync_date": {
+                    "type": "string"
+                },
+                "tenant_id": {
+                    "type": "string"
+                },
+                "time": {
+                    "type": "string"
+                },
+                "worker_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.IncidentCommand": {
+            "type": "object",
+            "properties": {
+                "command": {
+                    "type": "string"
+                },
+                "time": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.IncidentGroup": {
+            "type": "object",
+            "properties": {
+                "acknowledged": {
+                    "description": "true if no incident of the group occurred after the acknowledgement",
+                    "type": "boolean"
+                },
+                "activity_id": {
+                    "type": "string"
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "deployment_name": {
                     "type": "string"
                 },
-                "durationInMillis": {
-                    "type": "number"
+                "first_seen": {
+                    "type": "string"
                 },
-                "endTime": {
+                "group_id": {
                     "type": "string"
                 },
-                "id": {
+                "last_seen": {
                     "type": "string"
                 },
-                "is_placeholder": {
-                    "type": "boolean"
+                "latest_error_message": {
+                    "type": "string"
                 },
-                "marked_as_missing": {
-                    "type": "boolean"
+                "latest_incident_id": {
+                    "type": "string"
                 },
-                "marked_for_delete": {
+                "muted": {
+                    "description": "muted groups send no notifications",
                     "type": "boolean"
                 },
                 "network_id": {
                     "type": "string"
                 },
-                "processDefinitionId": {
+                "normalized_error_message": {
                     "type": "string"
                 },
-                "processDefinitionKey": {
+                "process_definition_id": {
                     "type": "string"
                 },
-                "processDefinitionName": {
+                "state": {
+                    "$ref": "#/definitions/model.IncidentGroupState"
+                },
+                "tenant_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.IncidentGroupState": {
+            "type": "object",
+            "properties": {
+                "acknowledged_at": {
                     "type": "string"
                 },
-                "processDefinitionVersion": {
-                    "type": "number"
+                "acknowledged_by": {
+                    "type": "string"
                 },
-                "startActivityId": {
+                "group_id": {
                     "type": "string"
                 },
-                "startTime": {
+                "muted": {
+                    "type": "boolean"
+                },
+                "muted_by": {
                     "type": "string"
                 },
-                "startUserId": {
+                "muted_until": {
+                    "description": "nil mutes until unmuted",
                     "type": "string"
                 },
-                "state": {
+                "network_id": {
                     "type": "string"
+                }
+            }
+        },
+        "model.IncidentStats": {
+            "type": "object",
+            "properties": {
+                "buckets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.IncidentStatsBucket"
+                    }
                 },
-                "superCaseInstanceId": {
+                "from": {
                     "type": "string"
                 },
-                "superProcessInstanceId": {
+                "interval": {
                     "type": "string"
                 },
-                "sync_date": {
+                "to": {
                     "type": "string"
                 },
-                "tenantId": {
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.IncidentStatsBucket": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "time": {
                     "type": "string"
                 }
             }
         },
-        "model.Incident": {
+        "model.Lease": {
             "type": "object",
             "properties": {
-                "business_key": {
+                "expires_at": {
                     "type": "string"
                 },
-                "deployment_name": {
+                "holder": {
                     "type": "string"
                 },
-                "error_message": {
+                "name": {
                     "type": "string"
                 },
-                "external_task_id": {
+                "renewed_at": {
                     "type": "string"
+                }
+            }
+        },
+        "model.NetworkDecommissionProgress": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "description": "affected elements, if known",
+                    "type": "integer"
                 },
-                "id": {
+                "error": {
                     "type": "string"
                 },
-                "is_placeholder": {
-                    "type": "boolean"
-                },
-                "marked_as_missing": {
-                    "type": "boolean"
+                "network_id": {
+                    "type": "string"
                 },
-                "marked_for_delete": {
-                    "type": "boolean"
+                "step": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.NetworkImportResult": {
+            "type": "object",
+            "properties": {
+                "imported": {
+                    "description": "documents per type",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
                 },
-                "msg_version": {
-                    "description": "from version 3 onward will be set in KafkaIncidentsCommand and be copied to this field",
+                "skipped": {
+                    "description": "placeholders",
                     "type": "integer"
                 },
-                "network_id": {
+                "source_network_id": {
                     "type": "string"
+                }
+            }
+        },
+        "model.NetworkStatus": {
+            "type": "object",
+            "properties": {
+                "deployments_marked_for_delete": {
+                    "type": "integer"
                 },
-                "process_definition_id": {
+                "health": {
+                    "description": "online, degraded or offline",
                     "type": "string"
                 },
-                "process_instance_id": {
+                "last_contact": {
                     "type": "string"
                 },
-                "sync_date": {
-                    "type": "string"
+                "last_topic_contacts": {
+                    "description": "last contact per entity topic (deployment, incident, process-definition, process-instance, process-instance-history)",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
                 },
-                "tenant_id": {
-                    "type": "string"
+                "missing_deployments": {
+                    "description": "deployments marked as missing",
+                    "type": "integer"
                 },
-                "time": {
+                "network_id": {
                     "type": "string"
                 },
-                "worker_id": {
-                    "type": "string"
+                "open_incidents": {
+                    "type": "integer"
+                },
+                "waiting_placeholder_deployments": {
+                    "description": "placeholder deployments, waiting longer than the placeholder wait time for the mgw",
+                    "type": "integer"
+                },
+                "waiting_placeholder_process_instances": {
+                    "description": "placeholder process instances, waiting longer than the placeholder wait time for the mgw",
+                    "type": "integer"
                 }
             }
         },
@@ -1952,6 +4127,211 @@ const docTemplate = `{
                 }
             }
         },
+        "model.RestartPolicy": {
+            "type": "object",
+            "properties": {
+                "backoff": {
+                    "description": "duration (e.g. \"1m\") to wait after a failed instance ended, before it is restarted; doubled with every consecutive restart; empty = no backoff",
+                    "type": "string"
+                },
+                "give_up_action": {
+                    "description": "action if max_restarts is reached: \"notify\" (default) or \"stop_wardening\"",
+                    "type": "string"
+                },
+                "max_backoff": {
+                    "description": "upper limit for the backoff; empty = unlimited",
+                    "type": "string"
+                },
+                "max_restarts": {
+                    "description": "maximum number of consecutive restarts after incidents; 0 = unlimited",
+                    "type": "integer"
+                },
+                "restart_on_success": {
+                    "description": "restart instances which finished without incident (for always running processes)",
+                    "type": "boolean"
+                }
+            }
+        },
+        "model.Schedule": {
+            "type": "object",
+            "properties": {
+                "business_key_template": {
+                    "description": "BusinessKeyTemplate is a text/template with the fields .ScheduleId, .NetworkId, .DeploymentId and .Time (the planned run time);\nan empty template results in a random business key",
+                    "type": "string"
+                },
+                "cron": {
+                    "description": "Cron expects the standard 5 fields (minute hour day-of-month month day-of-week)\nor one of the descriptors @yearly, @monthly, @weekly, @daily, @hourly and @every \u003cduration\u003e",
+                    "type": "string"
+                },
+                "deployment_id": {
+                    "type": "string"
+                },
+                "disabled": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "last_run": {
+                    "$ref": "#/definitions/model.ScheduleRun"
+                },
+                "missed_run_policy": {
+                    "description": "MissedRunPolicy is ScheduleMissedRunSkip (default) or ScheduleMissedRunOnce",
+                    "type": "string"
+                },
+                "network_id": {
+                    "type": "string"
+                },
+                "next_run": {
+                    "type": "string"
+                },
+                "parameter": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "time_zone": {
+                    "description": "TimeZone is an IANA time zone name (e.g. Europe/Berlin); defaults to UTC",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ScheduleRun": {
+            "type": "object",
+            "properties": {
+                "business_key": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "planned_for": {
+                    "description": "time the run was planned for by the cron expression",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "one of ScheduleRunStatusStarted, ScheduleRunStatusSkipped, ScheduleRunStatusFailed",
+                    "type": "string"
+                },
+                "time": {
+                    "description": "time of the trigger",
+                    "type": "string"
+                }
+            }
+        },
+        "model.StartRequest": {
+            "type": "object",
+            "properties": {
+                "business_key": {
+                    "type": "string"
+                },
+                "parameter": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "restart_policy": {
+                    "description": "optional; defaults to the restart policy of the deployment",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.RestartPolicy"
+                        }
+                    ]
+                }
+            }
+        },
+        "model.WardenDecision": {
+            "type": "object",
+            "properties": {
+                "business_key": {
+                    "type": "string"
+                },
+                "decision": {
+                    "type": "string"
+                },
+                "deployment_id": {
+                    "type": "string"
+                },
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "entity": {
+                    "description": "warden_info, deployment_warden_info or process_instance",
+                    "type": "string"
+                },
+                "entity_id": {
+                    "description": "business key, deployment id or process instance id",
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "network_id": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "time": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.WardenInfoWithStatus": {
+            "type": "object",
+            "properties": {
+                "business_key": {
+                    "description": "must be the same as the process-instance business-key and start with WardenBusinessKeyPrefix; the prefix may be set by Warden.MarkInstanceBusinessKeyAsWardenHandled",
+                    "type": "string"
+                },
+                "consecutive_failures": {
+                    "description": "restarts after incidents since the last successful finished instance",
+                    "type": "integer"
+                },
+                "creation_time": {
+                    "description": "unix timestamp",
+                    "type": "integer"
+                },
+                "gave_up": {
+                    "description": "restart policy allows no further restarts",
+                    "type": "boolean"
+                },
+                "last_restart": {
+                    "description": "unix timestamp",
+                    "type": "integer"
+                },
+                "last_restart_reason": {
+                    "type": "string"
+                },
+                "network_id": {
+                    "description": "must be the same as the process-instance network-id",
+                    "type": "string"
+                },
+                "paused": {
+                    "description": "paused infos are ignored by the warden loops",
+                    "type": "boolean"
+                },
+                "process_deployment_id": {
+                    "description": "must be the same as the process-instance process-deployment-id",
+                    "type": "string"
+                },
+                "restart_count": {
+                    "type": "integer"
+                },
+                "restart_policy": {
+                    "$ref": "#/definitions/model.RestartPolicy"
+                },
+                "start_parameters": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "status": {
+                    "description": "computed status; one of healthy, missing_instance, duplicate, restarting, waiting_for_age_gate, finished, gave_up, paused",
+                    "type": "string"
+                }
+            }
+        },
         "models.Hub": {
             "type": "object",
             "properties": {
@@ -2427,7 +4807,7 @@ var SwaggerInfo = &swag.Spec{
 	BasePath:         "/",
 	Schemes:          []string{},
 	Title:            "Process-Sync-Api",
-	Description:      "",
+	Description:      "endpoints check the permissions-v2 rights of the user on the network (kind 'hubs'); the rights per operation (read, start, stop, deploy, delete, admin) are configured in operation_rights",
 	InfoInstanceName: "swagger",
 	SwaggerTemplate:  docTemplate,
 	LeftDelim:        "{{",
@@ -18,6 +18,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"log"
 	"net/http"
@@ -30,14 +31,18 @@ import (
 	"github.com/SENERGY-Platform/api-docs-provider/lib/client"
 	"github.com/SENERGY-Platform/process-sync/docs"
 	"github.com/SENERGY-Platform/process-sync/pkg/api"
+	"github.com/SENERGY-Platform/process-sync/pkg/archive"
 	"github.com/SENERGY-Platform/process-sync/pkg/configuration"
 	"github.com/SENERGY-Platform/process-sync/pkg/controller"
+	"github.com/SENERGY-Platform/process-sync/pkg/database/mongo"
+	"github.com/SENERGY-Platform/process-sync/pkg/tracing"
 )
 
 func main() {
 	time.Sleep(5 * time.Second) //wait for routing tables in cluster
 
 	confLocation := flag.String("config", "config.json", "configuration file")
+	restorePrefix := flag.String("restore", "", "restores the archive files with this key prefix (e.g. history/{network_id}/2026-01/) into the database and exits")
 	flag.Parse()
 
 	config, err := configuration.Load(*confLocation)
@@ -47,6 +52,20 @@ func main() {
 
 	ctx, cancel := context.WithCancel(context.Background())
 
+	if *restorePrefix != "" {
+		err = restore(ctx, config, *restorePrefix)
+		cancel()
+		if err != nil {
+			log.Fatal("ERROR: unable to restore archive ", err)
+		}
+		return
+	}
+
+	err = tracing.Init(ctx, config)
+	if err != nil {
+		log.Fatal("ERROR: unable to init tracing ", err)
+	}
+
 	ctrl, err := controller.NewDefault(config, ctx)
 	if err != nil {
 		config.GetLogger().Error("FATAL", "error", err, "stack", debug.Stack())
@@ -65,6 +84,10 @@ func main() {
 		go cleanup(ctx, ctrl, config)
 	}
 
+	if len(config.Retention) > 0 && config.RetentionInterval != "" && config.RetentionInterval != "-" {
+		go retention(ctx, ctrl, config)
+	}
+
 	if config.ApiDocsProviderBaseUrl != "" && config.ApiDocsProviderBaseUrl != "-" {
 		err = PublishAsyncApiDoc(config)
 		if err != nil {
@@ -92,7 +115,7 @@ func cleanup(ctx context.Context, ctrl *controller.Controller, config configurat
 		return
 	}
 
-	err = ctrl.RemoveOldEntities(maxAge)
+	err = ctrl.RemoveOldEntities(ctx, maxAge)
 	if err != nil {
 		config.GetLogger().Warn("error in RemoveOldEntities", "error", err)
 	}
@@ -102,7 +125,7 @@ func cleanup(ctx context.Context, ctrl *controller.Controller, config configurat
 		case <-ctx.Done():
 			return
 		case <-t.C:
-			err = ctrl.RemoveOldEntities(maxAge)
+			err = ctrl.RemoveOldEntities(ctx, maxAge)
 			if err != nil {
 				config.GetLogger().Warn("error in RemoveOldEntities", "error", err)
 			}
@@ -110,6 +133,48 @@ func cleanup(ctx context.Context, ctrl *controller.Controller, config configurat
 	}
 }
 
+func retention(ctx context.Context, ctrl *controller.Controller, config configuration.Config) {
+	retentionInterval, err := time.ParseDuration(config.RetentionInterval)
+	if err != nil {
+		config.GetLogger().Warn("invalid RetentionInterval", "error", err, "retention_interval", config.RetentionInterval)
+		return
+	}
+
+	err = ctrl.ApplyRetentionPolicies(ctx)
+	if err != nil {
+		config.GetLogger().Warn("error in ApplyRetentionPolicies", "error", err)
+	}
+	t := time.NewTicker(retentionInterval)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			err = ctrl.ApplyRetentionPolicies(ctx)
+			if err != nil {
+				config.GetLogger().Warn("error in ApplyRetentionPolicies", "error", err)
+			}
+		}
+	}
+}
+
+func restore(ctx context.Context, config configuration.Config, prefix string) error {
+	store, err := archive.NewStore(config)
+	if err != nil {
+		return err
+	}
+	if store == nil {
+		return errors.New("missing archive_store")
+	}
+	db, err := mongo.New(config)
+	if err != nil {
+		return err
+	}
+	count, err := archive.Restore(ctx, store, db, prefix)
+	config.GetLogger().Info("restored archive", "prefix", prefix, "count", count)
+	return err
+}
+
 func PublishAsyncApiDoc(conf configuration.Config) error {
 	ctx, _ := context.WithTimeout(context.Background(), 30*time.Second)
 	return client.New(http.DefaultClient, conf.ApiDocsProviderBaseUrl).AsyncapiPutDoc(ctx, "github_com_SENERGY-Platform_process-sync", docs.AsyncApiDoc)
@@ -27,6 +27,8 @@ import (
 	"github.com/SENERGY-Platform/process-sync/pkg/api/util"
 	"github.com/SENERGY-Platform/process-sync/pkg/configuration"
 	"github.com/SENERGY-Platform/process-sync/pkg/controller"
+	"github.com/SENERGY-Platform/process-sync/pkg/metrics"
+	"github.com/SENERGY-Platform/process-sync/pkg/tracing"
 	"github.com/SENERGY-Platform/service-commons/pkg/accesslog"
 )
 
@@ -36,11 +38,17 @@ type EndpointMethod = func(config configuration.Config, ctrl *controller.Control
 
 var endpoints = []interface{}{} //list of objects with EndpointMethod
 
+const readTimeout = 2 * time.Second
+const writeTimeout = 10 * time.Second
+
+// longRunningRoutes are served without read and write timeouts and without access log, which reads the complete request body into memory and hides the flusher of the response writer
+var longRunningRoutes = []string{exportNetworkRoute, importNetworkRoute, decommissionNetworkRoute}
+
 func Start(config configuration.Config, ctx context.Context, ctrl *controller.Controller) (err error) {
 	config.GetLogger().Info("start api", "port", config.ApiPort)
-	router := Router(config, ctrl)
-	handler := accesslog.New(util.NewCors(router))
-	server := &http.Server{Addr: ":" + config.ApiPort, Handler: handler, WriteTimeout: 10 * time.Second, ReadTimeout: 2 * time.Second, ReadHeaderTimeout: 2 * time.Second}
+	handler := Handler(Router(config, ctrl), ctrl, readTimeout, writeTimeout)
+	//read and write timeouts are set per route by the handler; without server ReadTimeout, the IdleTimeout must be set explicitly
+	server := &http.Server{Addr: ":" + config.ApiPort, Handler: handler, ReadHeaderTimeout: 2 * time.Second, IdleTimeout: readTimeout}
 	go func() {
 		config.GetLogger().Info("listening on " + server.Addr)
 		if err := server.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
@@ -56,9 +64,23 @@ func Start(config configuration.Config, ctx context.Context, ctrl *controller.Co
 	return nil
 }
 
+// HandlerController is the part of the controller used by the middlewares
+type HandlerController interface {
+	util.TokenValidator
+	util.AuditLogger
+	Metrics() *metrics.Metrics
+}
+
+// Handler wraps the router with all middlewares; the read and write timeouts apply to all routes except longRunningRoutes
+func Handler(router *http.ServeMux, ctrl HandlerController, read time.Duration, write time.Duration) http.Handler {
+	handler := util.NewCors(tracing.Middleware(util.NewAudit(util.NewAuth(util.NewMetrics(router, ctrl.Metrics()), ctrl), ctrl, router)))
+	return util.NewTimeouts(accesslog.New(handler), handler, router, longRunningRoutes, read, write)
+}
+
 // Router doc
 // @title         Process-Sync-Api
 // @version       0.1
+// @description   endpoints check the permissions-v2 rights of the user on the network (kind 'hubs'); the rights per operation (read, start, stop, deploy, delete, admin) are configured in operation_rights
 // @license.name  Apache 2.0
 // @license.url   http://www.apache.org/licenses/LICENSE-2.0.html
 // @BasePath  /
@@ -66,7 +88,7 @@ func Start(config configuration.Config, ctx context.Context, ctrl *controller.Co
 // @in header
 // @name Authorization
 // @description Type "Bearer" followed by a space and JWT token.
-func Router(config configuration.Config, ctrl *controller.Controller) http.Handler {
+func Router(config configuration.Config, ctrl *controller.Controller) *http.ServeMux {
 	router := http.NewServeMux()
 	config.GetLogger().Info("add heart beat endpoint")
 	router.HandleFunc("GET /", func(writer http.ResponseWriter, request *http.Request) {
@@ -0,0 +1,123 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package api
+
+import (
+	"bufio"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/SENERGY-Platform/process-sync/pkg/metrics"
+	"github.com/SENERGY-Platform/process-sync/pkg/model"
+	"github.com/SENERGY-Platform/process-sync/pkg/security"
+)
+
+type handlerCtrl struct{}
+
+func (this handlerCtrl) ApiVerifyToken(token string) (security.Claims, error) {
+	return security.Claims{Token: token}, nil
+}
+
+func (this handlerCtrl) LogAudit(entry model.AuditEntry) {}
+
+func (this handlerCtrl) Metrics() *metrics.Metrics {
+	return nil
+}
+
+func TestHandlerTimeouts(t *testing.T) {
+	const write = 200 * time.Millisecond
+	const streamed = 5
+	router := http.NewServeMux()
+	stream := func(writer http.ResponseWriter, request *http.Request) {
+		for i := 0; i < streamed; i++ {
+			_, err := writer.Write([]byte("line\n"))
+			if err != nil {
+				return
+			}
+			err = http.NewResponseController(writer).Flush()
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			time.Sleep(write / 2)
+		}
+	}
+	router.HandleFunc(exportNetworkRoute, stream)
+	router.HandleFunc(decommissionNetworkRoute, stream)
+	router.HandleFunc("GET /slow", func(writer http.ResponseWriter, request *http.Request) {
+		time.Sleep(2 * write)
+		_, _ = writer.Write([]byte("too late"))
+	})
+	router.HandleFunc("GET /fast", func(writer http.ResponseWriter, request *http.Request) {
+		_, _ = writer.Write([]byte("ok"))
+	})
+	server := httptest.NewServer(Handler(router, handlerCtrl{}, time.Second, write))
+	defer server.Close()
+	client := server.Client()
+
+	//the fast request leaves deadlines on the keep-alive connection, which must not apply to the following stream
+	resp, err := client.Get(server.URL + "/fast")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatal(resp.StatusCode)
+	}
+
+	for _, method := range []string{http.MethodGet, http.MethodDelete} {
+		path := "/networks/n1"
+		if method == http.MethodGet {
+			path += "/export"
+		}
+		req, err := http.NewRequest(method, server.URL+path, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		start := time.Now()
+		resp, err = client.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		scanner := bufio.NewScanner(resp.Body)
+		lines := 0
+		for scanner.Scan() {
+			if lines == 0 && time.Since(start) > write {
+				t.Error(method, "first line should be flushed before the stream ends")
+			}
+			lines++
+		}
+		resp.Body.Close()
+		if err = scanner.Err(); err != nil {
+			t.Error(method, err)
+		}
+		if lines != streamed {
+			t.Error(method, "stream longer than the write timeout should be complete", lines)
+		}
+		if duration := time.Since(start); duration < 2*write {
+			t.Error(method, "stream should be longer than the write timeout", duration)
+		}
+	}
+
+	resp, err = client.Get(server.URL + "/slow")
+	if err == nil {
+		resp.Body.Close()
+		t.Error("write timeout should apply to other routes")
+	}
+}
@@ -0,0 +1,144 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/SENERGY-Platform/process-sync/pkg/configuration"
+	"github.com/SENERGY-Platform/process-sync/pkg/controller"
+	"github.com/SENERGY-Platform/process-sync/pkg/model"
+)
+
+func init() {
+	endpoints = append(endpoints, &AuditEndpoints{})
+}
+
+type AuditEndpoints struct{}
+
+// ListAuditEntries godoc
+// @Summary      list audit entries
+// @Description  list the audit log of mutating api calls; admins may list all entries, other users must filter by network_id and need the rights of the 'admin' operation (default 'a') on all requested networks
+// @Tags         audit
+// @Produce      json
+// @Security Bearer
+// @Param        network_id query string false "comma separated list of network ids; required for non admins"
+// @Param        user_id query string false "comma separated list of user ids"
+// @Param        entity query string false "comma separated list of entities (e.g. deployments, process-instances, incidents)"
+// @Param        entity_id query string false "comma separated list of entity ids"
+// @Param        action query string false "comma separated list of actions (e.g. 'DELETE /deployments/{networkId}/{deploymentId}')"
+// @Param        success query boolean false "filter by outcome"
+// @Param        since query string false "RFC3339 timestamp; only entries at or after this time are returned"
+// @Param        until query string false "RFC3339 timestamp; only entries before this time are returned"
+// @Param        limit query integer false "default 100"
+// @Param        offset query integer false "default 0"
+// @Param        sort query string false "default time.desc; time, user_id, network_id, entity or action with optional .asc or .desc"
+// @Success      200 {array}  model.AuditEntry
+// @Failure      400
+// @Failure      401
+// @Failure      403
+// @Failure      500
+// @Router       /audit [GET]
+func (this *AuditEndpoints) ListAuditEntries(config configuration.Config, ctrl *controller.Controller, router *http.ServeMux) {
+	router.HandleFunc("GET /audit", func(writer http.ResponseWriter, request *http.Request) {
+		query, err := parseAuditQuery(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		err, errCode := ctrl.ApiCheckAdmin(request)
+		if err != nil {
+			if len(query.NetworkIds) == 0 {
+				http.Error(writer, "expect network_id query parameter for non admin users", http.StatusForbidden)
+				return
+			}
+			err, errCode = ctrl.ApiCheckAccessMultiple(request, query.NetworkIds, configuration.OperationAdmin)
+			if err != nil {
+				http.Error(writer, err.Error(), errCode)
+				return
+			}
+		}
+		result, err, errCode := ctrl.ApiListAuditEntries(query)
+		if err != nil {
+			http.Error(writer, err.Error(), errCode)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		err = json.NewEncoder(writer).Encode(result)
+		if err != nil {
+			config.GetLogger().Error("unable to encode response", "error", err)
+		}
+		return
+	})
+}
+
+func parseAuditQuery(request *http.Request) (query model.AuditQuery, err error) {
+	values := request.URL.Query()
+	query.Sort = values.Get("sort")
+	limitStr := values.Get("limit")
+	if limitStr == "" {
+		limitStr = "100"
+	}
+	query.Limit, err = strconv.ParseInt(limitStr, 10, 64)
+	if err != nil {
+		return query, err
+	}
+	offsetStr := values.Get("offset")
+	if offsetStr == "" {
+		offsetStr = "0"
+	}
+	query.Offset, err = strconv.ParseInt(offsetStr, 10, 64)
+	if err != nil {
+		return query, err
+	}
+	list := func(name string) []string {
+		if value := values.Get(name); value != "" {
+			return strings.Split(value, ",")
+		}
+		return nil
+	}
+	query.NetworkIds = list("network_id")
+	query.UserIds = list("user_id")
+	query.Entities = list("entity")
+	query.EntityIds = list("entity_id")
+	query.Actions = list("action")
+	if successStr := values.Get("success"); successStr != "" {
+		success, err := strconv.ParseBool(successStr)
+		if err != nil {
+			return query, errors.New("invalid success parameter: " + err.Error())
+		}
+		query.Success = &success
+	}
+	if sinceStr := values.Get("since"); sinceStr != "" {
+		query.Since, err = time.Parse(time.RFC3339, sinceStr)
+		if err != nil {
+			return query, err
+		}
+	}
+	if untilStr := values.Get("until"); untilStr != "" {
+		query.Until, err = time.Parse(time.RFC3339, untilStr)
+		if err != nil {
+			return query, err
+		}
+	}
+	return query, nil
+}
@@ -38,6 +38,7 @@ type DeploymentEndpoints struct{}
 // GetDeployment godoc
 // @Summary      get deployment
 // @Description  get deployment
+// @Description  requires the rights of the 'read' operation (default 'r') on the network
 // @Tags         deployment
 // @Produce      json
 // @Security Bearer
@@ -54,7 +55,7 @@ func (this *DeploymentEndpoints) GetDeployment(config configuration.Config, ctrl
 	router.HandleFunc("GET /deployments/{networkId}/{deploymentId}", func(writer http.ResponseWriter, request *http.Request) {
 		networkId := request.PathValue("networkId")
 		deploymentId := request.PathValue("deploymentId")
-		err, errCode := ctrl.ApiCheckAccess(request, networkId, "rx")
+		err, errCode := ctrl.ApiCheckAccess(request, networkId, configuration.OperationRead)
 		if err != nil {
 			http.Error(writer, err.Error(), errCode)
 			return
@@ -76,6 +77,7 @@ func (this *DeploymentEndpoints) GetDeployment(config configuration.Config, ctrl
 // GetDeploymentMetadata godoc
 // @Summary      get deployment metadata
 // @Description  get deployment metadata
+// @Description  requires the rights of the 'read' operation (default 'r') on the network
 // @Tags         deployment, metadata
 // @Produce      json
 // @Security Bearer
@@ -92,7 +94,7 @@ func (this *DeploymentEndpoints) GetDeploymentMetadata(config configuration.Conf
 	router.HandleFunc("GET /deployments/{networkId}/{deploymentId}/metadata", func(writer http.ResponseWriter, request *http.Request) {
 		networkId := request.PathValue("networkId")
 		deploymentId := request.PathValue("deploymentId")
-		err, errCode := ctrl.ApiCheckAccess(request, networkId, "rx")
+		err, errCode := ctrl.ApiCheckAccess(request, networkId, configuration.OperationRead)
 		if err != nil {
 			http.Error(writer, err.Error(), errCode)
 			return
@@ -114,6 +116,8 @@ func (this *DeploymentEndpoints) GetDeploymentMetadata(config configuration.Conf
 // StartDeployment godoc
 // @Summary      start deployed process
 // @Description  start deployed process; a process may expect parameters on start. these can be passed as query parameters. swagger allows no arbitrary/dynamic parameter names, which means a query with parameters must be executed manually
+// @Description  requires the rights of the 'start' operation (default 'x') on the network
+// @Description  with deployment_owner_protection, only the owner of the deployment and users with the rights of the 'admin' operation are allowed
 // @Tags         deployment
 // @Produce      json
 // @Security Bearer
@@ -131,7 +135,12 @@ func (this *DeploymentEndpoints) StartDeployment(config configuration.Config, ct
 	router.HandleFunc("GET /deployments/{networkId}/{deploymentId}/start", func(writer http.ResponseWriter, request *http.Request) {
 		networkId := request.PathValue("networkId")
 		deploymentId := request.PathValue("deploymentId")
-		err, errCode := ctrl.ApiCheckAccess(request, networkId, "rx")
+		err, errCode := ctrl.ApiCheckAccess(request, networkId, configuration.OperationStart)
+		if err != nil {
+			http.Error(writer, err.Error(), errCode)
+			return
+		}
+		err, errCode = ctrl.ApiCheckDeploymentOwner(request, networkId, deploymentId)
 		if err != nil {
 			http.Error(writer, err.Error(), errCode)
 			return
@@ -142,7 +151,60 @@ func (this *DeploymentEndpoints) StartDeployment(config configuration.Config, ct
 
 		inputs := parseQueryParameter(request.URL.Query())
 
-		err, errCode = ctrl.ApiStartDeployment(networkId, deploymentId, businessKey, inputs)
+		err, errCode = ctrl.ApiStartDeployment(request.Context(), networkId, deploymentId, businessKey, inputs)
+		if err != nil {
+			http.Error(writer, err.Error(), errCode)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		err = json.NewEncoder(writer).Encode(true)
+		if err != nil {
+			config.GetLogger().Error("unable to encode response", "error", err)
+		}
+		return
+	})
+}
+
+// StartDeploymentWithParameter godoc
+// @Summary      start deployed process with parameters
+// @Description  start deployed process; the parameters are validated and coerced against the process_parameter of the deployment metadata. unknown parameters, missing required parameters (parameters without default value) and wrong types result in a 400 response listing all problems.
+// @Description  requires the rights of the 'start' operation (default 'x') on the network
+// @Description  with deployment_owner_protection, only the owner of the deployment and users with the rights of the 'admin' operation are allowed
+// @Tags         deployment
+// @Accept       json
+// @Produce      json
+// @Security Bearer
+// @Param        networkId path string true "network id"
+// @Param        deploymentId path string true "deployment id"
+// @Param        message body model.StartRequest true "business_key and parameter of the new process instance"
+// @Success      200
+// @Failure      400
+// @Failure      401
+// @Failure      403
+// @Failure      404
+// @Failure      500
+// @Router       /deployments/{networkId}/{deploymentId}/start [POST]
+func (this *DeploymentEndpoints) StartDeploymentWithParameter(config configuration.Config, ctrl *controller.Controller, router *http.ServeMux) {
+	router.HandleFunc("POST /deployments/{networkId}/{deploymentId}/start", func(writer http.ResponseWriter, request *http.Request) {
+		networkId := request.PathValue("networkId")
+		deploymentId := request.PathValue("deploymentId")
+		startRequest := model.StartRequest{}
+		err := json.NewDecoder(request.Body).Decode(&startRequest)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		err, errCode := ctrl.ApiCheckAccess(request, networkId, configuration.OperationStart)
+		if err != nil {
+			http.Error(writer, err.Error(), errCode)
+			return
+		}
+		err, errCode = ctrl.ApiCheckDeploymentOwner(request, networkId, deploymentId)
+		if err != nil {
+			http.Error(writer, err.Error(), errCode)
+			return
+		}
+		err, errCode = ctrl.ApiStartDeploymentWithValidatedParameter(request.Context(), networkId, deploymentId, startRequest)
 		if err != nil {
 			http.Error(writer, err.Error(), errCode)
 			return
@@ -159,6 +221,8 @@ func (this *DeploymentEndpoints) StartDeployment(config configuration.Config, ct
 // CreateDeployment godoc
 // @Summary      deploy process
 // @Description  deploy process; prepared process may be requested from the process-fog-deployment service
+// @Description  requires the rights of the 'deploy' operation (default 'w') on the network
+// @Description  with deployment_owner_protection, only the owner of an existing deployment with the same id and users with the rights of the 'admin' operation may replace it
 // @Tags         deployment
 // @Produce      json
 // @Security Bearer
@@ -179,12 +243,70 @@ func (this *DeploymentEndpoints) CreateDeployment(config configuration.Config, c
 			http.Error(writer, err.Error(), http.StatusBadRequest)
 			return
 		}
-		token, err, errCode := ctrl.ApiCheckAccessReturnToken(request, networkId, "a")
+		token, err, errCode := ctrl.ApiCheckAccessReturnToken(request, networkId, configuration.OperationDeploy)
 		if err != nil {
 			http.Error(writer, err.Error(), errCode)
 			return
 		}
-		err, errCode = ctrl.ApiCreateDeployment(token, networkId, deployment)
+		err, errCode = ctrl.ApiCheckDeploymentOwner(request, networkId, deployment.Id)
+		if err != nil {
+			http.Error(writer, err.Error(), errCode)
+			return
+		}
+		err, errCode = ctrl.ApiCreateDeployment(request.Context(), token, networkId, deployment)
+		if err != nil {
+			http.Error(writer, err.Error(), errCode)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		err = json.NewEncoder(writer).Encode(true)
+		if err != nil {
+			config.GetLogger().Error("unable to encode response", "error", err)
+		}
+		return
+	})
+}
+
+// SetDeploymentRestartPolicy godoc
+// @Summary      set deployment restart policy
+// @Description  set the default restart policy for warden handled process instances of the deployment. the policy is copied to instances on start; already started instances keep their policy. a null body removes the default policy.
+// @Description  requires the rights of the 'deploy' operation (default 'w') on the network
+// @Description  with deployment_owner_protection, only the owner of the deployment and users with the rights of the 'admin' operation are allowed
+// @Tags         deployment, warden
+// @Accept       json
+// @Produce      json
+// @Security Bearer
+// @Param        networkId path string true "network id"
+// @Param        deploymentId path string true "deployment id"
+// @Param        message body model.RestartPolicy true "restart policy"
+// @Success      200
+// @Failure      400
+// @Failure      401
+// @Failure      403
+// @Failure      404
+// @Failure      500
+// @Router       /deployments/{networkId}/{deploymentId}/restart-policy [PUT]
+func (this *DeploymentEndpoints) SetDeploymentRestartPolicy(config configuration.Config, ctrl *controller.Controller, router *http.ServeMux) {
+	router.HandleFunc("PUT /deployments/{networkId}/{deploymentId}/restart-policy", func(writer http.ResponseWriter, request *http.Request) {
+		networkId := request.PathValue("networkId")
+		deploymentId := request.PathValue("deploymentId")
+		var policy *model.RestartPolicy
+		err := json.NewDecoder(request.Body).Decode(&policy)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		err, errCode := ctrl.ApiCheckAccess(request, networkId, configuration.OperationDeploy)
+		if err != nil {
+			http.Error(writer, err.Error(), errCode)
+			return
+		}
+		err, errCode = ctrl.ApiCheckDeploymentOwner(request, networkId, deploymentId)
+		if err != nil {
+			http.Error(writer, err.Error(), errCode)
+			return
+		}
+		err, errCode = ctrl.ApiSetDeploymentRestartPolicy(networkId, deploymentId, policy)
 		if err != nil {
 			http.Error(writer, err.Error(), errCode)
 			return
@@ -201,6 +323,8 @@ func (this *DeploymentEndpoints) CreateDeployment(config configuration.Config, c
 // DeleteDeployment godoc
 // @Summary      delete deployment
 // @Description  delete deployment
+// @Description  requires the rights of the 'delete' operation (default 'a') on the network
+// @Description  with deployment_owner_protection, only the owner of the deployment and users with the rights of the 'admin' operation are allowed
 // @Tags         deployment
 // @Produce      json
 // @Security Bearer
@@ -217,12 +341,17 @@ func (this *DeploymentEndpoints) DeleteDeployment(config configuration.Config, c
 	router.HandleFunc("DELETE /deployments/{networkId}/{deploymentId}", func(writer http.ResponseWriter, request *http.Request) {
 		networkId := request.PathValue("networkId")
 		deploymentId := request.PathValue("deploymentId")
-		err, errCode := ctrl.ApiCheckAccess(request, networkId, "a")
+		err, errCode := ctrl.ApiCheckAccess(request, networkId, configuration.OperationDelete)
+		if err != nil {
+			http.Error(writer, err.Error(), errCode)
+			return
+		}
+		err, errCode = ctrl.ApiCheckDeploymentOwner(request, networkId, deploymentId)
 		if err != nil {
 			http.Error(writer, err.Error(), errCode)
 			return
 		}
-		err, errCode = ctrl.ApiDeleteDeployment(networkId, deploymentId)
+		err, errCode = ctrl.ApiDeleteDeployment(request.Context(), networkId, deploymentId)
 		if err != nil {
 			http.Error(writer, err.Error(), errCode)
 			return
@@ -239,6 +368,7 @@ func (this *DeploymentEndpoints) DeleteDeployment(config configuration.Config, c
 // ListDeployments godoc
 // @Summary      list deployments
 // @Description  list deployments
+// @Description  requires the rights of the 'read' operation (default 'r') on all requested networks
 // @Tags         deployment
 // @Produce      json
 // @Security Bearer
@@ -299,7 +429,7 @@ func (this *DeploymentEndpoints) ListDeployments(config configuration.Config, ct
 			return
 		}
 		networkIds := strings.Split(networkIdsStr, ",")
-		err, errCode := ctrl.ApiCheckAccessMultiple(request, networkIds, "rx")
+		err, errCode := ctrl.ApiCheckAccessMultiple(request, networkIds, configuration.OperationRead)
 		if err != nil {
 			http.Error(writer, err.Error(), errCode)
 			return
@@ -18,9 +18,11 @@ package api
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/SENERGY-Platform/process-sync/pkg/configuration"
 	"github.com/SENERGY-Platform/process-sync/pkg/controller"
@@ -36,6 +38,7 @@ type HistoryEndpoints struct{}
 // GetHistoricProcessInstance godoc
 // @Summary      get historic process-instances
 // @Description  get historic process-instances
+// @Description  requires the rights of the 'read' operation (default 'r') on the network
 // @Tags         process-instance
 // @Produce      json
 // @Security Bearer
@@ -52,7 +55,7 @@ func (this *HistoryEndpoints) GetHistoricProcessInstance(config configuration.Co
 	router.HandleFunc("GET /history/process-instances/{networkId}/{id}", func(writer http.ResponseWriter, request *http.Request) {
 		networkId := request.PathValue("networkId")
 		id := request.PathValue("id")
-		err, errCode := ctrl.ApiCheckAccess(request, networkId, "rx")
+		err, errCode := ctrl.ApiCheckAccess(request, networkId, configuration.OperationRead)
 		if err != nil {
 			http.Error(writer, err.Error(), errCode)
 			return
@@ -74,6 +77,8 @@ func (this *HistoryEndpoints) GetHistoricProcessInstance(config configuration.Co
 // DeleteHistoricProcessInstance godoc
 // @Summary      get historic process-instances
 // @Description  get historic process-instances
+// @Description  requires the rights of the 'delete' operation (default 'a') on the network
+// @Description  with deployment_owner_protection, only the owner of the deployment of the historic instance and users with the rights of the 'admin' operation are allowed
 // @Tags         process-instance
 // @Produce      json
 // @Security Bearer
@@ -90,12 +95,17 @@ func (this *HistoryEndpoints) DeleteHistoricProcessInstance(config configuration
 	router.HandleFunc("DELETE /history/process-instances/{networkId}/{id}", func(writer http.ResponseWriter, request *http.Request) {
 		networkId := request.PathValue("networkId")
 		id := request.PathValue("id")
-		err, errCode := ctrl.ApiCheckAccess(request, networkId, "a")
+		err, errCode := ctrl.ApiCheckAccess(request, networkId, configuration.OperationDelete)
 		if err != nil {
 			http.Error(writer, err.Error(), errCode)
 			return
 		}
-		err, errCode = ctrl.ApiDeleteHistoricProcessInstance(networkId, id)
+		err, errCode = ctrl.ApiCheckHistoricProcessInstanceOwner(request, networkId, id)
+		if err != nil {
+			http.Error(writer, err.Error(), errCode)
+			return
+		}
+		err, errCode = ctrl.ApiDeleteHistoricProcessInstance(request.Context(), networkId, id)
 		if err != nil {
 			http.Error(writer, err.Error(), errCode)
 			return
@@ -112,17 +122,24 @@ func (this *HistoryEndpoints) DeleteHistoricProcessInstance(config configuration
 // ListHistoricProcessInstances godoc
 // @Summary      list historic process-instances
 // @Description  list historic process-instances
+// @Description  requires the rights of the 'read' operation (default 'r') on all requested networks
 // @Tags         process-instance
 // @Produce      json
 // @Security Bearer
 // @Param        search query string false "search"
 // @Param        limit query integer false "default 100"
 // @Param        offset query integer false "default 0"
-// @Param        sort query string false "default id.asc"
+// @Param        sort query string false "default id.asc; id, start_time, end_time or duration with optional .asc or .desc"
 // @Param        network_id query string true "comma separated list of network-ids, used to filter the result"
 // @Param        business_key query string false "comma separated list of business-keys, used to filter the result"
 // @Param        processDefinitionId query string false "process-definition-id, used to filter the result"
 // @Param        state query string false "state may be 'finished' or 'unfinished', used to filter the result"
+// @Param        started_after query string false "RFC3339 timestamp; only instances started at or after this time"
+// @Param        started_before query string false "RFC3339 timestamp; only instances started before this time"
+// @Param        ended_after query string false "RFC3339 timestamp; only instances ended at or after this time"
+// @Param        ended_before query string false "RFC3339 timestamp; only instances ended before this time"
+// @Param        min_duration query string false "go duration (e.g. 1h); finished instances by duration, unfinished instances by the time since their start"
+// @Param        max_duration query string false "go duration (e.g. 1h); finished instances by duration, unfinished instances by the time since their start"
 // @Param        with_total query bool false "if set to true, wraps the result in an objet with the result {total:0, data:[]}"
 // @Success      200 {array}  model.HistoricProcessInstance
 // @Failure      400
@@ -177,6 +194,11 @@ func (this *HistoryEndpoints) ListHistoricProcessInstances(config configuration.
 			Search:              request.URL.Query().Get("search"),
 			BusinessKeys:        businessKeys,
 		}
+		err = parseHistoryTimeFilter(request, &query)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
 
 		networkIdsStr := request.URL.Query().Get("network_id")
 		if networkIdsStr == "" {
@@ -184,7 +206,7 @@ func (this *HistoryEndpoints) ListHistoricProcessInstances(config configuration.
 			return
 		}
 		networkIds := strings.Split(networkIdsStr, ",")
-		err, errCode := ctrl.ApiCheckAccessMultiple(request, networkIds, "rx")
+		err, errCode := ctrl.ApiCheckAccessMultiple(request, networkIds, configuration.OperationRead)
 		if err != nil {
 			http.Error(writer, err.Error(), errCode)
 			return
@@ -212,3 +234,106 @@ func (this *HistoryEndpoints) ListHistoricProcessInstances(config configuration.
 		return
 	})
 }
+
+func parseHistoryTimeFilter(request *http.Request, query *model.HistoryQuery) (err error) {
+	values := request.URL.Query()
+	times := []struct {
+		name   string
+		target *time.Time
+	}{
+		{name: "started_after", target: &query.StartedAfter},
+		{name: "started_before", target: &query.StartedBefore},
+		{name: "ended_after", target: &query.EndedAfter},
+		{name: "ended_before", target: &query.EndedBefore},
+	}
+	for _, t := range times {
+		if value := values.Get(t.name); value != "" {
+			*t.target, err = time.Parse(time.RFC3339, value)
+			if err != nil {
+				return fmt.Errorf("invalid %v: %w", t.name, err)
+			}
+		}
+	}
+	durations := []struct {
+		name   string
+		target *time.Duration
+	}{
+		{name: "min_duration", target: &query.MinDuration},
+		{name: "max_duration", target: &query.MaxDuration},
+	}
+	for _, d := range durations {
+		if value := values.Get(d.name); value != "" {
+			*d.target, err = time.ParseDuration(value)
+			if err != nil {
+				return fmt.Errorf("invalid %v: %w", d.name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// GetHistoryAnalytics godoc
+// @Summary      get process execution analytics
+// @Description  analyse the historic process-instances started in the time window per network and process-definition (or deployment): runs, success/termination/incident rates, approximated duration percentiles of finished runs and throughput; with_incidents only counts runs with incidents which are not yet resolved
+// @Description  requires the rights of the 'read' operation (default 'r') on all requested networks
+// @Tags         process-instance
+// @Produce      json
+// @Security Bearer
+// @Param        network_id query string true "comma separated list of network-ids, used to filter the result"
+// @Param        group_by query string false "definition (default) or deployment"
+// @Param        process_definition_id query string false "process-definition-id, used to filter the result"
+// @Param        deployment_id query string false "deployment-id, used to filter the result"
+// @Param        from query string false "RFC3339 timestamp; default 7 days before to"
+// @Param        to query string false "RFC3339 timestamp; default now"
+// @Success      200 {array}  model.HistoryAnalytics
+// @Failure      400
+// @Failure      401
+// @Failure      403
+// @Failure      500
+// @Router       /history/analytics [GET]
+func (this *HistoryEndpoints) GetHistoryAnalytics(config configuration.Config, ctrl *controller.Controller, router *http.ServeMux) {
+	router.HandleFunc("GET /history/analytics", func(writer http.ResponseWriter, request *http.Request) {
+		query := model.HistoryAnalyticsQuery{
+			GroupBy:             request.URL.Query().Get("group_by"),
+			ProcessDefinitionId: request.URL.Query().Get("process_definition_id"),
+			DeploymentId:        request.URL.Query().Get("deployment_id"),
+		}
+		var err error
+		if fromStr := request.URL.Query().Get("from"); fromStr != "" {
+			query.From, err = time.Parse(time.RFC3339, fromStr)
+			if err != nil {
+				http.Error(writer, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+		if toStr := request.URL.Query().Get("to"); toStr != "" {
+			query.To, err = time.Parse(time.RFC3339, toStr)
+			if err != nil {
+				http.Error(writer, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+		networkIdsStr := request.URL.Query().Get("network_id")
+		if networkIdsStr == "" {
+			http.Error(writer, "expect network_id query parameter", http.StatusBadRequest)
+			return
+		}
+		query.NetworkIds = strings.Split(networkIdsStr, ",")
+		err, errCode := ctrl.ApiCheckAccessMultiple(request, query.NetworkIds, configuration.OperationRead)
+		if err != nil {
+			http.Error(writer, err.Error(), errCode)
+			return
+		}
+		result, err, errCode := ctrl.ApiGetHistoryAnalytics(query)
+		if err != nil {
+			http.Error(writer, err.Error(), errCode)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		err = json.NewEncoder(writer).Encode(result)
+		if err != nil {
+			config.GetLogger().Error("unable to encode response", "error", err)
+		}
+		return
+	})
+}
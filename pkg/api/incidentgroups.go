@@ -0,0 +1,300 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/SENERGY-Platform/process-sync/pkg/configuration"
+	"github.com/SENERGY-Platform/process-sync/pkg/controller"
+	"github.com/SENERGY-Platform/process-sync/pkg/model"
+	"github.com/SENERGY-Platform/process-sync/pkg/security"
+)
+
+func init() {
+	endpoints = append(endpoints, &IncidentGroupEndpoints{})
+}
+
+type IncidentGroupEndpoints struct{}
+
+// ListIncidentGroups godoc
+// @Summary      list incident groups
+// @Description  list incidents grouped by network, process definition, activity and normalized error message (ids, timestamps and numbers replaced by placeholders)
+// @Description  requires the rights of the 'read' operation (default 'r') on all requested networks
+// @Tags         incidents
+// @Produce      json
+// @Security Bearer
+// @Param        network_id query string true "comma separated list of network-ids, used to filter the result"
+// @Param        process_definition_id query string false "process-definition-id, used to filter the result"
+// @Param        limit query integer false "default 100"
+// @Param        offset query integer false "default 0"
+// @Param        sort query string false "default last_seen.desc; last_seen, first_seen or count with optional .asc or .desc"
+// @Success      200 {array}  model.IncidentGroup
+// @Failure      400
+// @Failure      401
+// @Failure      403
+// @Failure      500
+// @Router       /incidents/groups [GET]
+func (this *IncidentGroupEndpoints) ListIncidentGroups(config configuration.Config, ctrl *controller.Controller, router *http.ServeMux) {
+	router.HandleFunc("GET /incidents/groups", func(writer http.ResponseWriter, request *http.Request) {
+		query := model.IncidentGroupQuery{
+			Sort:                request.URL.Query().Get("sort"),
+			ProcessDefinitionId: request.URL.Query().Get("process_definition_id"),
+		}
+		limitStr := request.URL.Query().Get("limit")
+		if limitStr == "" {
+			limitStr = "100"
+		}
+		var err error
+		query.Limit, err = strconv.ParseInt(limitStr, 10, 64)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		offsetStr := request.URL.Query().Get("offset")
+		if offsetStr == "" {
+			offsetStr = "0"
+		}
+		query.Offset, err = strconv.ParseInt(offsetStr, 10, 64)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		networkIdsStr := request.URL.Query().Get("network_id")
+		if networkIdsStr == "" {
+			http.Error(writer, "expect network_id query parameter", http.StatusBadRequest)
+			return
+		}
+		query.NetworkIds = strings.Split(networkIdsStr, ",")
+		err, errCode := ctrl.ApiCheckAccessMultiple(request, query.NetworkIds, configuration.OperationRead)
+		if err != nil {
+			http.Error(writer, err.Error(), errCode)
+			return
+		}
+		result, err, errCode := ctrl.ApiListIncidentGroups(query)
+		if err != nil {
+			http.Error(writer, err.Error(), errCode)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		err = json.NewEncoder(writer).Encode(result)
+		if err != nil {
+			config.GetLogger().Error("unable to encode response", "error", err)
+		}
+		return
+	})
+}
+
+// GetIncidentStats godoc
+// @Summary      get incident stats
+// @Description  count the stored incidents (resolved and deleted incidents are not included) per time bucket
+// @Description  requires the rights of the 'read' operation (default 'r') on all requested networks
+// @Tags         incidents
+// @Produce      json
+// @Security Bearer
+// @Param        network_id query string true "comma separated list of network-ids, used to filter the result"
+// @Param        group_id query string false "incident group id, used to filter the result"
+// @Param        from query string false "RFC3339 timestamp; default 24h before to; buckets are aligned to from"
+// @Param        to query string false "RFC3339 timestamp; default now"
+// @Param        interval query string false "bucket size as go duration (e.g. 15m); default 1h; at most 1000 buckets"
+// @Success      200 {object}  model.IncidentStats
+// @Failure      400
+// @Failure      401
+// @Failure      403
+// @Failure      500
+// @Router       /incidents/stats [GET]
+func (this *IncidentGroupEndpoints) GetIncidentStats(config configuration.Config, ctrl *controller.Controller, router *http.ServeMux) {
+	router.HandleFunc("GET /incidents/stats", func(writer http.ResponseWriter, request *http.Request) {
+		query := model.IncidentStatsQuery{
+			GroupId: request.URL.Query().Get("group_id"),
+		}
+		var err error
+		if fromStr := request.URL.Query().Get("from"); fromStr != "" {
+			query.From, err = time.Parse(time.RFC3339, fromStr)
+			if err != nil {
+				http.Error(writer, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+		if toStr := request.URL.Query().Get("to"); toStr != "" {
+			query.To, err = time.Parse(time.RFC3339, toStr)
+			if err != nil {
+				http.Error(writer, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+		if intervalStr := request.URL.Query().Get("interval"); intervalStr != "" {
+			query.Interval, err = time.ParseDuration(intervalStr)
+			if err != nil {
+				http.Error(writer, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+		networkIdsStr := request.URL.Query().Get("network_id")
+		if networkIdsStr == "" {
+			http.Error(writer, "expect network_id query parameter", http.StatusBadRequest)
+			return
+		}
+		query.NetworkIds = strings.Split(networkIdsStr, ",")
+		err, errCode := ctrl.ApiCheckAccessMultiple(request, query.NetworkIds, configuration.OperationRead)
+		if err != nil {
+			http.Error(writer, err.Error(), errCode)
+			return
+		}
+		result, err, errCode := ctrl.ApiGetIncidentStats(query)
+		if err != nil {
+			http.Error(writer, err.Error(), errCode)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		err = json.NewEncoder(writer).Encode(result)
+		if err != nil {
+			config.GetLogger().Error("unable to encode response", "error", err)
+		}
+		return
+	})
+}
+
+// AcknowledgeIncidentGroup godoc
+// @Summary      acknowledge incident group
+// @Description  acknowledge the incident group; the group is reported as acknowledged until a new incident of the group occurs
+// @Description  requires the rights of the 'admin' operation (default 'a') on the network
+// @Tags         incidents
+// @Produce      json
+// @Security Bearer
+// @Param        networkId path string true "network id"
+// @Param        groupId path string true "incident group id"
+// @Success      200 {object}  model.IncidentGroupState
+// @Failure      400
+// @Failure      401
+// @Failure      403
+// @Failure      500
+// @Router       /incidents/groups/{networkId}/{groupId}/acknowledge [POST]
+func (this *IncidentGroupEndpoints) AcknowledgeIncidentGroup(config configuration.Config, ctrl *controller.Controller, router *http.ServeMux) {
+	router.HandleFunc("POST /incidents/groups/{networkId}/{groupId}/acknowledge", func(writer http.ResponseWriter, request *http.Request) {
+		networkId := request.PathValue("networkId")
+		groupId := request.PathValue("groupId")
+		err, errCode := ctrl.ApiCheckAccess(request, networkId, configuration.OperationAdmin)
+		if err != nil {
+			http.Error(writer, err.Error(), errCode)
+			return
+		}
+		userId := security.ContextUserId(request.Context(), request.Header.Get("Authorization"))
+		result, err, errCode := ctrl.ApiAcknowledgeIncidentGroup(networkId, groupId, userId)
+		if err != nil {
+			http.Error(writer, err.Error(), errCode)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		err = json.NewEncoder(writer).Encode(result)
+		if err != nil {
+			config.GetLogger().Error("unable to encode response", "error", err)
+		}
+		return
+	})
+}
+
+// MuteIncidentGroup godoc
+// @Summary      mute incident group
+// @Description  stop notifications for new incidents of the group
+// @Description  requires the rights of the 'admin' operation (default 'a') on the network
+// @Tags         incidents
+// @Produce      json
+// @Security Bearer
+// @Param        networkId path string true "network id"
+// @Param        groupId path string true "incident group id"
+// @Param        duration query string false "go duration (e.g. 24h); without duration, the group is muted until it is unmuted"
+// @Success      200 {object}  model.IncidentGroupState
+// @Failure      400
+// @Failure      401
+// @Failure      403
+// @Failure      500
+// @Router       /incidents/groups/{networkId}/{groupId}/mute [POST]
+func (this *IncidentGroupEndpoints) MuteIncidentGroup(config configuration.Config, ctrl *controller.Controller, router *http.ServeMux) {
+	router.HandleFunc("POST /incidents/groups/{networkId}/{groupId}/mute", func(writer http.ResponseWriter, request *http.Request) {
+		networkId := request.PathValue("networkId")
+		groupId := request.PathValue("groupId")
+		var duration time.Duration
+		if durationStr := request.URL.Query().Get("duration"); durationStr != "" {
+			var err error
+			duration, err = time.ParseDuration(durationStr)
+			if err != nil {
+				http.Error(writer, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+		err, errCode := ctrl.ApiCheckAccess(request, networkId, configuration.OperationAdmin)
+		if err != nil {
+			http.Error(writer, err.Error(), errCode)
+			return
+		}
+		userId := security.ContextUserId(request.Context(), request.Header.Get("Authorization"))
+		result, err, errCode := ctrl.ApiMuteIncidentGroup(networkId, groupId, userId, duration)
+		if err != nil {
+			http.Error(writer, err.Error(), errCode)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		err = json.NewEncoder(writer).Encode(result)
+		if err != nil {
+			config.GetLogger().Error("unable to encode response", "error", err)
+		}
+		return
+	})
+}
+
+// UnmuteIncidentGroup godoc
+// @Summary      unmute incident group
+// @Description  resume notifications for new incidents of the group
+// @Description  requires the rights of the 'admin' operation (default 'a') on the network
+// @Tags         incidents
+// @Produce      json
+// @Security Bearer
+// @Param        networkId path string true "network id"
+// @Param        groupId path string true "incident group id"
+// @Success      200 {object}  model.IncidentGroupState
+// @Failure      400
+// @Failure      401
+// @Failure      403
+// @Failure      500
+// @Router       /incidents/groups/{networkId}/{groupId}/mute [DELETE]
+func (this *IncidentGroupEndpoints) UnmuteIncidentGroup(config configuration.Config, ctrl *controller.Controller, router *http.ServeMux) {
+	router.HandleFunc("DELETE /incidents/groups/{networkId}/{groupId}/mute", func(writer http.ResponseWriter, request *http.Request) {
+		networkId := request.PathValue("networkId")
+		groupId := request.PathValue("groupId")
+		err, errCode := ctrl.ApiCheckAccess(request, networkId, configuration.OperationAdmin)
+		if err != nil {
+			http.Error(writer, err.Error(), errCode)
+			return
+		}
+		result, err, errCode := ctrl.ApiUnmuteIncidentGroup(networkId, groupId)
+		if err != nil {
+			http.Error(writer, err.Error(), errCode)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		err = json.NewEncoder(writer).Encode(result)
+		if err != nil {
+			config.GetLogger().Error("unable to encode response", "error", err)
+		}
+		return
+	})
+}
@@ -35,6 +35,7 @@ type IncidentEndpoints struct{}
 // GetIncident godoc
 // @Summary      get incident
 // @Description  get incident
+// @Description  requires the rights of the 'read' operation (default 'r') on the network
 // @Tags         incidents
 // @Produce      json
 // @Security Bearer
@@ -51,7 +52,7 @@ func (this *IncidentEndpoints) GetIncident(config configuration.Config, ctrl *co
 	router.HandleFunc("GET /incidents/{networkId}/{id}", func(writer http.ResponseWriter, request *http.Request) {
 		networkId := request.PathValue("networkId")
 		id := request.PathValue("id")
-		err, errCode := ctrl.ApiCheckAccess(request, networkId, "rx")
+		err, errCode := ctrl.ApiCheckAccess(request, networkId, configuration.OperationRead)
 		if err != nil {
 			http.Error(writer, err.Error(), errCode)
 			return
@@ -73,6 +74,8 @@ func (this *IncidentEndpoints) GetIncident(config configuration.Config, ctrl *co
 // DeleteIncident godoc
 // @Summary      delete incident
 // @Description  delete incident
+// @Description  requires the rights of the 'delete' operation (default 'a') on the network
+// @Description  with deployment_owner_protection, only the owner of the deployment of the incident and users with the rights of the 'admin' operation are allowed
 // @Tags         incidents
 // @Produce      json
 // @Security Bearer
@@ -89,7 +92,12 @@ func (this *IncidentEndpoints) DeleteIncident(config configuration.Config, ctrl
 	router.HandleFunc("DELETE /incidents/{networkId}/{id}", func(writer http.ResponseWriter, request *http.Request) {
 		networkId := request.PathValue("networkId")
 		id := request.PathValue("id")
-		err, errCode := ctrl.ApiCheckAccess(request, networkId, "a")
+		err, errCode := ctrl.ApiCheckAccess(request, networkId, configuration.OperationDelete)
+		if err != nil {
+			http.Error(writer, err.Error(), errCode)
+			return
+		}
+		err, errCode = ctrl.ApiCheckIncidentOwner(request, networkId, id)
 		if err != nil {
 			http.Error(writer, err.Error(), errCode)
 			return
@@ -108,9 +116,69 @@ func (this *IncidentEndpoints) DeleteIncident(config configuration.Config, ctrl
 	})
 }
 
+// SendIncidentCommand godoc
+// @Summary      send incident command
+// @Description  sends a command for the incident to the mgw: retry (increments the retries of the failed external task), resolve (resolves the incident without retry) or restart (stops the process instance and restarts it; warden handled instances are stopped and restarted by the warden).
+// @Description  the incident is marked with a pending_command until the mgw removes the incident; further commands are rejected with 409 until the incident_command_timeout is reached.
+// @Description  requires the rights of the 'start' operation (default 'x') on the network
+// @Description  with deployment_owner_protection, only the owner of the deployment of the process instance and users with the rights of the 'admin' operation are allowed
+// @Tags         incidents
+// @Produce      json
+// @Security Bearer
+// @Param        networkId path string true "network id"
+// @Param        id path string true "incident id"
+// @Param        command path string true "command" Enums(retry, resolve, restart)
+// @Param        retries query integer false "retries to add for the retry command; default 1"
+// @Success      200 {object}  model.Incident
+// @Failure      400
+// @Failure      401
+// @Failure      403
+// @Failure      404
+// @Failure      409
+// @Failure      500
+// @Router       /incidents/{networkId}/{id}/{command} [POST]
+func (this *IncidentEndpoints) SendIncidentCommand(config configuration.Config, ctrl *controller.Controller, router *http.ServeMux) {
+	router.HandleFunc("POST /incidents/{networkId}/{id}/{command}", func(writer http.ResponseWriter, request *http.Request) {
+		networkId := request.PathValue("networkId")
+		id := request.PathValue("id")
+		command := request.PathValue("command")
+		retries := 0
+		if retriesStr := request.URL.Query().Get("retries"); retriesStr != "" {
+			var err error
+			retries, err = strconv.Atoi(retriesStr)
+			if err != nil {
+				http.Error(writer, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+		err, errCode := ctrl.ApiCheckAccess(request, networkId, configuration.OperationStart)
+		if err != nil {
+			http.Error(writer, err.Error(), errCode)
+			return
+		}
+		err, errCode = ctrl.ApiCheckIncidentOwner(request, networkId, id)
+		if err != nil {
+			http.Error(writer, err.Error(), errCode)
+			return
+		}
+		result, err, errCode := ctrl.ApiSendIncidentCommand(request.Context(), networkId, id, command, retries)
+		if err != nil {
+			http.Error(writer, err.Error(), errCode)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		err = json.NewEncoder(writer).Encode(result)
+		if err != nil {
+			config.GetLogger().Error("unable to encode response", "error", err)
+		}
+		return
+	})
+}
+
 // ListIncidents godoc
 // @Summary      list incidents
 // @Description  list incidents
+// @Description  requires the rights of the 'read' operation (default 'r') on all requested networks
 // @Tags         incidents
 // @Produce      json
 // @Security Bearer
@@ -159,7 +227,7 @@ func (this *IncidentEndpoints) ListIncidents(config configuration.Config, ctrl *
 			return
 		}
 		networkIds := strings.Split(networkIdsStr, ",")
-		err, errCode := ctrl.ApiCheckAccessMultiple(request, networkIds, "rx")
+		err, errCode := ctrl.ApiCheckAccessMultiple(request, networkIds, configuration.OperationRead)
 		if err != nil {
 			http.Error(writer, err.Error(), errCode)
 			return
@@ -0,0 +1,63 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/SENERGY-Platform/process-sync/pkg/configuration"
+	"github.com/SENERGY-Platform/process-sync/pkg/controller"
+)
+
+func init() {
+	endpoints = append(endpoints, &LeaseEndpoints{})
+}
+
+type LeaseEndpoints struct{}
+
+// ListLeases godoc
+// @Summary      list leases
+// @Description  list the currently held leases (e.g. of the warden loops, backfills and retention) and their holders; only for admins. expired leases may be listed until they are removed by the database.
+// @Tags         lease
+// @Produce      json
+// @Security Bearer
+// @Success      200 {array}  model.Lease
+// @Failure      401
+// @Failure      403
+// @Failure      500
+// @Router       /leases [GET]
+func (this *LeaseEndpoints) ListLeases(config configuration.Config, ctrl *controller.Controller, router *http.ServeMux) {
+	router.HandleFunc("GET /leases", func(writer http.ResponseWriter, request *http.Request) {
+		err, errCode := ctrl.ApiCheckAdmin(request)
+		if err != nil {
+			http.Error(writer, err.Error(), errCode)
+			return
+		}
+		result, err, errCode := ctrl.ApiListLeases()
+		if err != nil {
+			http.Error(writer, err.Error(), errCode)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		err = json.NewEncoder(writer).Encode(result)
+		if err != nil {
+			config.GetLogger().Error("unable to encode response", "error", err)
+		}
+		return
+	})
+}
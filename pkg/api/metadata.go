@@ -33,6 +33,7 @@ type MetadataEndpoints struct{}
 // ListMetadata godoc
 // @Summary      list deployment metadata
 // @Description  list deployment metadata
+// @Description  requires the rights of the 'read' operation (default 'r') on the network
 // @Tags         deployment, metadata
 // @Produce      json
 // @Security Bearer
@@ -49,7 +50,7 @@ type MetadataEndpoints struct{}
 func (this *MetadataEndpoints) ListMetadata(config configuration.Config, ctrl *controller.Controller, router *http.ServeMux) {
 	router.HandleFunc("GET /metadata/{networkId}", func(writer http.ResponseWriter, request *http.Request) {
 		networkId := request.PathValue("networkId")
-		err, errCode := ctrl.ApiCheckAccess(request, networkId, "rx")
+		err, errCode := ctrl.ApiCheckAccess(request, networkId, configuration.OperationRead)
 		if err != nil {
 			http.Error(writer, err.Error(), errCode)
 			return
@@ -19,9 +19,14 @@ package api
 import (
 	"encoding/json"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/SENERGY-Platform/process-sync/pkg/configuration"
 	"github.com/SENERGY-Platform/process-sync/pkg/controller"
+	"github.com/SENERGY-Platform/process-sync/pkg/model"
 )
 
 func init() {
@@ -58,3 +63,252 @@ func (this *NetworksEndpoints) ListNetworks(config configuration.Config, ctrl *c
 		return
 	})
 }
+
+// GetNetworkStatus godoc
+// @Summary      get network status
+// @Description  get the sync status of a network: last contact (total and per entity topic), placeholders waiting for the mgw, deployments marked as missing or for delete, open incidents and the derived health (online, degraded, offline)
+// @Description  requires the rights of the 'read' operation (default 'r') on the network
+// @Tags         networks
+// @Produce      json
+// @Security Bearer
+// @Param        networkId path string true "network id"
+// @Param        placeholder_wait_minutes query integer false "placeholders waiting longer than this number of minutes are counted as waiting; default network_placeholder_wait config"
+// @Success      200 {object}  model.NetworkStatus
+// @Failure      400
+// @Failure      401
+// @Failure      403
+// @Failure      500
+// @Router       /networks/{networkId}/status [GET]
+func (this *NetworksEndpoints) GetNetworkStatus(config configuration.Config, ctrl *controller.Controller, router *http.ServeMux) {
+	router.HandleFunc("GET /networks/{networkId}/status", func(writer http.ResponseWriter, request *http.Request) {
+		networkId := request.PathValue("networkId")
+		placeholderWait, err := getPlaceholderWait(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		err, errCode := ctrl.ApiCheckAccess(request, networkId, configuration.OperationRead)
+		if err != nil {
+			http.Error(writer, err.Error(), errCode)
+			return
+		}
+		result, err, errCode := ctrl.ApiGetNetworkStatus(networkId, placeholderWait)
+		if err != nil {
+			http.Error(writer, err.Error(), errCode)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		err = json.NewEncoder(writer).Encode(result)
+		if err != nil {
+			config.GetLogger().Error("unable to encode response", "error", err)
+		}
+		return
+	})
+}
+
+// ListNetworkStatus godoc
+// @Summary      list network status
+// @Description  list the sync status of multiple networks (e.g. for dashboards); see /networks/{networkId}/status
+// @Description  requires the rights of the 'read' operation (default 'r') on all requested networks
+// @Tags         networks
+// @Produce      json
+// @Security Bearer
+// @Param        network_id query string true "comma separated list of network-ids"
+// @Param        placeholder_wait_minutes query integer false "placeholders waiting longer than this number of minutes are counted as waiting; default network_placeholder_wait config"
+// @Success      200 {array}  model.NetworkStatus
+// @Failure      400
+// @Failure      401
+// @Failure      403
+// @Failure      500
+// @Router       /networks/status [GET]
+func (this *NetworksEndpoints) ListNetworkStatus(config configuration.Config, ctrl *controller.Controller, router *http.ServeMux) {
+	router.HandleFunc("GET /networks/status", func(writer http.ResponseWriter, request *http.Request) {
+		networkIdsStr := request.URL.Query().Get("network_id")
+		if networkIdsStr == "" {
+			http.Error(writer, "expect network_id query parameter", http.StatusBadRequest)
+			return
+		}
+		networkIds := strings.Split(networkIdsStr, ",")
+		placeholderWait, err := getPlaceholderWait(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		err, errCode := ctrl.ApiCheckAccessMultiple(request, networkIds, configuration.OperationRead)
+		if err != nil {
+			http.Error(writer, err.Error(), errCode)
+			return
+		}
+		result, err, errCode := ctrl.ApiListNetworkStatus(networkIds, placeholderWait)
+		if err != nil {
+			http.Error(writer, err.Error(), errCode)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		err = json.NewEncoder(writer).Encode(result)
+		if err != nil {
+			config.GetLogger().Error("unable to encode response", "error", err)
+		}
+		return
+	})
+}
+
+const exportNetworkRoute = "GET /networks/{networkId}/export"
+const importNetworkRoute = "POST /networks/{networkId}/import"
+const decommissionNetworkRoute = "DELETE /networks/{networkId}"
+
+// ExportNetwork godoc
+// @Summary      export network
+// @Description  streams the complete sync state of the network (deployments, metadata, definitions, instances, history, incidents and warden infos) as NDJSON: the first line is a model.NetworkExportHeader, every following line a model.NetworkExportRecord; the last record has the type 'end' and is missing if the export failed while streaming
+// @Description  requires the rights of the 'read' operation (default 'r') on the network
+// @Tags         networks
+// @Produce      application/x-ndjson
+// @Security Bearer
+// @Param        networkId path string true "network id"
+// @Success      200
+// @Failure      400
+// @Failure      401
+// @Failure      403
+// @Failure      500
+// @Router       /networks/{networkId}/export [GET]
+func (this *NetworksEndpoints) ExportNetwork(config configuration.Config, ctrl *controller.Controller, router *http.ServeMux) {
+	router.HandleFunc(exportNetworkRoute, func(writer http.ResponseWriter, request *http.Request) {
+		networkId := request.PathValue("networkId")
+		err, errCode := ctrl.ApiCheckAccess(request, networkId, configuration.OperationRead)
+		if err != nil {
+			http.Error(writer, err.Error(), errCode)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/x-ndjson; charset=utf-8")
+		writer.Header().Set("Content-Disposition", "attachment; filename=\"network-"+url.PathEscape(networkId)+".ndjson\"")
+		err, _ = ctrl.ApiExportNetwork(networkId, writer)
+		if err != nil {
+			config.GetLogger().Error("unable to export network", "error", err, "network_id", networkId)
+		}
+		return
+	})
+}
+
+// ImportNetwork godoc
+// @Summary      import network
+// @Description  imports an export of /networks/{networkId}/export into the network; the export is validated completely before any document is saved or removed; without replace, the imported documents are merged into the current sync state
+// @Description  requires the rights of the 'admin' operation (default 'a') on the network
+// @Tags         networks
+// @Accept       application/x-ndjson
+// @Produce      json
+// @Security Bearer
+// @Param        networkId path string true "target network id"
+// @Param        rewrite_network_id query bool false "allows the import of an export of another network; all documents are moved to the target network"
+// @Param        skip_placeholders query bool false "skips placeholder documents"
+// @Param        replace query bool false "removes the current sync state of the target network before the import"
+// @Success      200 {object}  model.NetworkImportResult
+// @Failure      400
+// @Failure      401
+// @Failure      403
+// @Failure      500
+// @Router       /networks/{networkId}/import [POST]
+func (this *NetworksEndpoints) ImportNetwork(config configuration.Config, ctrl *controller.Controller, router *http.ServeMux) {
+	router.HandleFunc(importNetworkRoute, func(writer http.ResponseWriter, request *http.Request) {
+		networkId := request.PathValue("networkId")
+		options := model.NetworkImportOptions{}
+		for _, option := range []struct {
+			name   string
+			target *bool
+		}{
+			{name: "rewrite_network_id", target: &options.RewriteNetworkId},
+			{name: "skip_placeholders", target: &options.SkipPlaceholders},
+			{name: "replace", target: &options.Replace},
+		} {
+			value := request.URL.Query().Get(option.name)
+			if value == "" {
+				continue
+			}
+			var err error
+			*option.target, err = strconv.ParseBool(value)
+			if err != nil {
+				http.Error(writer, option.name+": "+err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+		err, errCode := ctrl.ApiCheckAccess(request, networkId, configuration.OperationAdmin)
+		if err != nil {
+			http.Error(writer, err.Error(), errCode)
+			return
+		}
+		result, err, errCode := ctrl.ApiImportNetwork(networkId, request.Body, options)
+		if err != nil {
+			http.Error(writer, err.Error(), errCode)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		err = json.NewEncoder(writer).Encode(result)
+		if err != nil {
+			config.GetLogger().Error("unable to encode response", "error", err)
+		}
+		return
+	})
+}
+
+// DecommissionNetwork godoc
+// @Summary      decommission network
+// @Description  removes all data of a retired network: warden infos, optionally the deployments on the mgw, history and incidents (archived first if an archive store is configured), all other elements and the last contact; streams one model.NetworkDecommissionProgress per finished step as NDJSON; the last line has the step 'done' or an error
+// @Description  requires the rights of the 'delete' operation (default 'a') on the network
+// @Tags         networks
+// @Produce      application/x-ndjson
+// @Security Bearer
+// @Param        networkId path string true "network id"
+// @Param        delete_on_edge query bool false "sends delete commands for all deployments to the mgw"
+// @Success      200 {array}  model.NetworkDecommissionProgress
+// @Failure      400
+// @Failure      401
+// @Failure      403
+// @Failure      500
+// @Router       /networks/{networkId} [DELETE]
+func (this *NetworksEndpoints) DecommissionNetwork(config configuration.Config, ctrl *controller.Controller, router *http.ServeMux) {
+	router.HandleFunc(decommissionNetworkRoute, func(writer http.ResponseWriter, request *http.Request) {
+		networkId := request.PathValue("networkId")
+		options := model.NetworkDecommissionOptions{}
+		if deleteOnEdge := request.URL.Query().Get("delete_on_edge"); deleteOnEdge != "" {
+			var err error
+			options.DeleteOnEdge, err = strconv.ParseBool(deleteOnEdge)
+			if err != nil {
+				http.Error(writer, "delete_on_edge: "+err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+		err, errCode := ctrl.ApiCheckAccess(request, networkId, configuration.OperationDelete)
+		if err != nil {
+			http.Error(writer, err.Error(), errCode)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/x-ndjson; charset=utf-8")
+		encoder := json.NewEncoder(writer)
+		err, _ = ctrl.ApiDecommissionNetwork(request.Context(), networkId, options, func(progress model.NetworkDecommissionProgress) {
+			err := encoder.Encode(progress)
+			if err != nil {
+				config.GetLogger().Error("unable to encode response", "error", err)
+				return
+			}
+			err = http.NewResponseController(writer).Flush()
+			if err != nil {
+				config.GetLogger().Warn("unable to flush decommission progress", "error", err, "network_id", networkId)
+			}
+		})
+		if err != nil {
+			config.GetLogger().Error("unable to decommission network", "error", err, "network_id", networkId)
+		}
+		return
+	})
+}
+
+func getPlaceholderWait(request *http.Request) (time.Duration, error) {
+	minutesStr := request.URL.Query().Get("placeholder_wait_minutes")
+	if minutesStr == "" {
+		return 0, nil
+	}
+	minutes, err := strconv.ParseInt(minutesStr, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(minutes) * time.Minute, nil
+}
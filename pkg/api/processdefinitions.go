@@ -35,6 +35,7 @@ type ProcessDefinitionEndpoints struct{}
 // GetProcessDefinition godoc
 // @Summary      get process-definition
 // @Description  get process-definition
+// @Description  requires the rights of the 'read' operation (default 'r') on the network
 // @Tags         process-definitions
 // @Produce      json
 // @Security Bearer
@@ -51,7 +52,7 @@ func (this *ProcessDefinitionEndpoints) GetProcessDefinition(config configuratio
 	router.HandleFunc("GET /process-definitions/{networkId}/{id}", func(writer http.ResponseWriter, request *http.Request) {
 		networkId := request.PathValue("networkId")
 		id := request.PathValue("id")
-		err, errCode := ctrl.ApiCheckAccess(request, networkId, "rx")
+		err, errCode := ctrl.ApiCheckAccess(request, networkId, configuration.OperationRead)
 		if err != nil {
 			http.Error(writer, err.Error(), errCode)
 			return
@@ -73,6 +74,7 @@ func (this *ProcessDefinitionEndpoints) GetProcessDefinition(config configuratio
 // ListProcessDefinitions godoc
 // @Summary      list process-definitions
 // @Description  list process-definitions
+// @Description  requires the rights of the 'read' operation (default 'r') on all requested networks
 // @Tags         process-definitions
 // @Produce      json
 // @Security Bearer
@@ -118,7 +120,7 @@ func (this *ProcessDefinitionEndpoints) ListProcessDefinitions(config configurat
 			return
 		}
 		networkIds := strings.Split(networkIdsStr, ",")
-		err, errCode := ctrl.ApiCheckAccessMultiple(request, networkIds, "rx")
+		err, errCode := ctrl.ApiCheckAccessMultiple(request, networkIds, configuration.OperationRead)
 		if err != nil {
 			http.Error(writer, err.Error(), errCode)
 			return
@@ -35,6 +35,7 @@ type ProcessInstanceEndpoints struct{}
 // GetProcessInstance godoc
 // @Summary      get process-instances
 // @Description  get process-instances
+// @Description  requires the rights of the 'read' operation (default 'r') on the network
 // @Tags         process-instance
 // @Produce      json
 // @Security Bearer
@@ -51,7 +52,7 @@ func (this *ProcessInstanceEndpoints) GetProcessInstance(config configuration.Co
 	router.HandleFunc("GET /process-instances/{networkId}/{id}", func(writer http.ResponseWriter, request *http.Request) {
 		networkId := request.PathValue("networkId")
 		id := request.PathValue("id")
-		err, errCode := ctrl.ApiCheckAccess(request, networkId, "rx")
+		err, errCode := ctrl.ApiCheckAccess(request, networkId, configuration.OperationRead)
 		if err != nil {
 			http.Error(writer, err.Error(), errCode)
 			return
@@ -73,6 +74,8 @@ func (this *ProcessInstanceEndpoints) GetProcessInstance(config configuration.Co
 // DeleteProcessInstance godoc
 // @Summary      get process-instances
 // @Description  get process-instances
+// @Description  requires the rights of the 'stop' operation (default 'x') on the network
+// @Description  with deployment_owner_protection, only the owner of the deployment of the instance and users with the rights of the 'admin' operation are allowed
 // @Tags         process-instance
 // @Produce      json
 // @Security Bearer
@@ -89,12 +92,17 @@ func (this *ProcessInstanceEndpoints) DeleteProcessInstance(config configuration
 	router.HandleFunc("DELETE /process-instances/{networkId}/{id}", func(writer http.ResponseWriter, request *http.Request) {
 		networkId := request.PathValue("networkId")
 		id := request.PathValue("id")
-		err, errCode := ctrl.ApiCheckAccess(request, networkId, "a")
+		err, errCode := ctrl.ApiCheckAccess(request, networkId, configuration.OperationStop)
 		if err != nil {
 			http.Error(writer, err.Error(), errCode)
 			return
 		}
-		err, errCode = ctrl.ApiDeleteProcessInstance(networkId, id)
+		err, errCode = ctrl.ApiCheckProcessInstanceOwner(request, networkId, id)
+		if err != nil {
+			http.Error(writer, err.Error(), errCode)
+			return
+		}
+		err, errCode = ctrl.ApiDeleteProcessInstance(request.Context(), networkId, id)
 		if err != nil {
 			http.Error(writer, err.Error(), errCode)
 			return
@@ -111,6 +119,7 @@ func (this *ProcessInstanceEndpoints) DeleteProcessInstance(config configuration
 // ListProcessInstances godoc
 // @Summary      list process-instances
 // @Description  list process-instances
+// @Description  requires the rights of the 'read' operation (default 'r') on all requested networks
 // @Tags         process-instance
 // @Produce      json
 // @Security Bearer
@@ -156,7 +165,7 @@ func (this *ProcessInstanceEndpoints) ListProcessInstances(config configuration.
 			return
 		}
 		networkIds := strings.Split(networkIdsStr, ",")
-		err, errCode := ctrl.ApiCheckAccessMultiple(request, networkIds, "rx")
+		err, errCode := ctrl.ApiCheckAccessMultiple(request, networkIds, configuration.OperationRead)
 		if err != nil {
 			http.Error(writer, err.Error(), errCode)
 			return
@@ -178,6 +187,8 @@ func (this *ProcessInstanceEndpoints) ListProcessInstances(config configuration.
 // DeleteProcessInstancesByBusinessKey godoc
 // @Summary      delete process-instances by business-key
 // @Description  stop process-instances identified by business-key
+// @Description  requires the rights of the 'stop' operation (default 'x') on the network
+// @Description  with deployment_owner_protection, only the owner of the deployments of the instances and users with the rights of the 'admin' operation are allowed
 // @Tags         process-instance
 // @Security Bearer
 // @Param        networkId path string true "network id"
@@ -190,13 +201,18 @@ func (this *ProcessInstanceEndpoints) DeleteProcessInstancesByBusinessKey(config
 	router.HandleFunc("DELETE /process-instances-by-business-key/{networkId}/{business_key}", func(writer http.ResponseWriter, request *http.Request) {
 		networkId := request.PathValue("networkId")
 		businessKey := request.PathValue("business_key")
-		err, errCode := ctrl.ApiCheckAccess(request, networkId, "a")
+		err, errCode := ctrl.ApiCheckAccess(request, networkId, configuration.OperationStop)
+		if err != nil {
+			http.Error(writer, err.Error(), errCode)
+			return
+		}
+		err, errCode = ctrl.ApiCheckBusinessKeyOwner(request, networkId, businessKey)
 		if err != nil {
 			http.Error(writer, err.Error(), errCode)
 			return
 		}
 
-		err, errCode = ctrl.DeleteProcessInstanceByBusinessKey(networkId, businessKey)
+		err, errCode = ctrl.DeleteProcessInstanceByBusinessKey(request.Context(), networkId, businessKey)
 		if err != nil {
 			http.Error(writer, err.Error(), errCode)
 			return
@@ -0,0 +1,301 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/SENERGY-Platform/process-sync/pkg/configuration"
+	"github.com/SENERGY-Platform/process-sync/pkg/controller"
+	"github.com/SENERGY-Platform/process-sync/pkg/model"
+)
+
+func init() {
+	endpoints = append(endpoints, &ScheduleEndpoints{})
+}
+
+type ScheduleEndpoints struct{}
+
+// GetSchedule godoc
+// @Summary      get schedule
+// @Description  get schedule
+// @Description  requires the rights of the 'read' operation (default 'r') on the network
+// @Tags         schedule
+// @Produce      json
+// @Security Bearer
+// @Param        networkId path string true "network id"
+// @Param        scheduleId path string true "schedule id"
+// @Success      200 {object}  model.Schedule
+// @Failure      400
+// @Failure      401
+// @Failure      403
+// @Failure      404
+// @Failure      500
+// @Router       /schedules/{networkId}/{scheduleId} [GET]
+func (this *ScheduleEndpoints) GetSchedule(config configuration.Config, ctrl *controller.Controller, router *http.ServeMux) {
+	router.HandleFunc("GET /schedules/{networkId}/{scheduleId}", func(writer http.ResponseWriter, request *http.Request) {
+		networkId := request.PathValue("networkId")
+		scheduleId := request.PathValue("scheduleId")
+		err, errCode := ctrl.ApiCheckAccess(request, networkId, configuration.OperationRead)
+		if err != nil {
+			http.Error(writer, err.Error(), errCode)
+			return
+		}
+		result, err, errCode := ctrl.ApiReadSchedule(networkId, scheduleId)
+		if err != nil {
+			http.Error(writer, err.Error(), errCode)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		err = json.NewEncoder(writer).Encode(result)
+		if err != nil {
+			config.GetLogger().Error("unable to encode response", "error", err)
+		}
+		return
+	})
+}
+
+// ListSchedules godoc
+// @Summary      list schedules
+// @Description  list schedules including their next planned run and the status of the last run
+// @Description  requires the rights of the 'read' operation (default 'r') on all requested networks
+// @Tags         schedule
+// @Produce      json
+// @Security Bearer
+// @Param        network_id query string true "comma separated list of network-ids used to filter the schedules"
+// @Param        deployment_id query string false "comma separated list of deployment-ids used to filter the schedules"
+// @Param        limit query integer false "default 100"
+// @Param        offset query integer false "default 0"
+// @Param        sort query string false "default id.asc; allowed fields: id, deployment_id, next_run"
+// @Success      200 {array}  model.Schedule
+// @Failure      400
+// @Failure      401
+// @Failure      403
+// @Failure      404
+// @Failure      500
+// @Router       /schedules [GET]
+func (this *ScheduleEndpoints) ListSchedules(config configuration.Config, ctrl *controller.Controller, router *http.ServeMux) {
+	router.HandleFunc("GET /schedules", func(writer http.ResponseWriter, request *http.Request) {
+		query := model.ScheduleQuery{
+			Sort: request.URL.Query().Get("sort"),
+		}
+		if query.Sort == "" {
+			query.Sort = "id.asc"
+		}
+		limitStr := request.URL.Query().Get("limit")
+		if limitStr == "" {
+			limitStr = "100"
+		}
+		var err error
+		query.Limit, err = strconv.ParseInt(limitStr, 10, 64)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		offsetStr := request.URL.Query().Get("offset")
+		if offsetStr == "" {
+			offsetStr = "0"
+		}
+		query.Offset, err = strconv.ParseInt(offsetStr, 10, 64)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		networkIdsStr := request.URL.Query().Get("network_id")
+		if networkIdsStr == "" {
+			http.Error(writer, "expect network_id query parameter", http.StatusBadRequest)
+			return
+		}
+		query.NetworkIds = strings.Split(networkIdsStr, ",")
+		if deploymentIdsStr := request.URL.Query().Get("deployment_id"); deploymentIdsStr != "" {
+			query.DeploymentIds = strings.Split(deploymentIdsStr, ",")
+		}
+		err, errCode := ctrl.ApiCheckAccessMultiple(request, query.NetworkIds, configuration.OperationRead)
+		if err != nil {
+			http.Error(writer, err.Error(), errCode)
+			return
+		}
+		result, err, errCode := ctrl.ApiListSchedules(query)
+		if err != nil {
+			http.Error(writer, err.Error(), errCode)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		err = json.NewEncoder(writer).Encode(result)
+		if err != nil {
+			config.GetLogger().Error("unable to encode response", "error", err)
+		}
+		return
+	})
+}
+
+// CreateSchedule godoc
+// @Summary      create schedule
+// @Description  create a schedule which starts the referenced deployment according to its cron expression. the fields id, network_id, next_run and last_run are set by the service. the parameter are validated against the deployment metadata on each run.
+// @Description  requires the rights of the 'start' operation (default 'x') on the network
+// @Description  with deployment_owner_protection, only the owner of the scheduled deployment and users with the rights of the 'admin' operation are allowed
+// @Tags         schedule
+// @Accept       json
+// @Produce      json
+// @Security Bearer
+// @Param        networkId path string true "network id"
+// @Param        message body model.Schedule true "schedule"
+// @Success      200 {object}  model.Schedule
+// @Failure      400
+// @Failure      401
+// @Failure      403
+// @Failure      404
+// @Failure      500
+// @Router       /schedules/{networkId} [POST]
+func (this *ScheduleEndpoints) CreateSchedule(config configuration.Config, ctrl *controller.Controller, router *http.ServeMux) {
+	router.HandleFunc("POST /schedules/{networkId}", func(writer http.ResponseWriter, request *http.Request) {
+		networkId := request.PathValue("networkId")
+		schedule := model.Schedule{}
+		err := json.NewDecoder(request.Body).Decode(&schedule)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		err, errCode := ctrl.ApiCheckAccess(request, networkId, configuration.OperationStart)
+		if err != nil {
+			http.Error(writer, err.Error(), errCode)
+			return
+		}
+		err, errCode = ctrl.ApiCheckDeploymentOwner(request, networkId, schedule.DeploymentId)
+		if err != nil {
+			http.Error(writer, err.Error(), errCode)
+			return
+		}
+		result, err, errCode := ctrl.ApiCreateSchedule(networkId, schedule)
+		if err != nil {
+			http.Error(writer, err.Error(), errCode)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		err = json.NewEncoder(writer).Encode(result)
+		if err != nil {
+			config.GetLogger().Error("unable to encode response", "error", err)
+		}
+		return
+	})
+}
+
+// UpdateSchedule godoc
+// @Summary      update schedule
+// @Description  update schedule; the next run is recomputed, the last run status is kept
+// @Description  requires the rights of the 'start' operation (default 'x') on the network
+// @Description  with deployment_owner_protection, only the owner of the stored and the new scheduled deployment and users with the rights of the 'admin' operation are allowed
+// @Tags         schedule
+// @Accept       json
+// @Produce      json
+// @Security Bearer
+// @Param        networkId path string true "network id"
+// @Param        scheduleId path string true "schedule id"
+// @Param        message body model.Schedule true "schedule"
+// @Success      200 {object}  model.Schedule
+// @Failure      400
+// @Failure      401
+// @Failure      403
+// @Failure      404
+// @Failure      500
+// @Router       /schedules/{networkId}/{scheduleId} [PUT]
+func (this *ScheduleEndpoints) UpdateSchedule(config configuration.Config, ctrl *controller.Controller, router *http.ServeMux) {
+	router.HandleFunc("PUT /schedules/{networkId}/{scheduleId}", func(writer http.ResponseWriter, request *http.Request) {
+		networkId := request.PathValue("networkId")
+		scheduleId := request.PathValue("scheduleId")
+		schedule := model.Schedule{}
+		err := json.NewDecoder(request.Body).Decode(&schedule)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		err, errCode := ctrl.ApiCheckAccess(request, networkId, configuration.OperationStart)
+		if err != nil {
+			http.Error(writer, err.Error(), errCode)
+			return
+		}
+		err, errCode = ctrl.ApiCheckScheduleOwner(request, networkId, scheduleId)
+		if err != nil {
+			http.Error(writer, err.Error(), errCode)
+			return
+		}
+		err, errCode = ctrl.ApiCheckDeploymentOwner(request, networkId, schedule.DeploymentId)
+		if err != nil {
+			http.Error(writer, err.Error(), errCode)
+			return
+		}
+		result, err, errCode := ctrl.ApiUpdateSchedule(networkId, scheduleId, schedule)
+		if err != nil {
+			http.Error(writer, err.Error(), errCode)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		err = json.NewEncoder(writer).Encode(result)
+		if err != nil {
+			config.GetLogger().Error("unable to encode response", "error", err)
+		}
+		return
+	})
+}
+
+// DeleteSchedule godoc
+// @Summary      delete schedule
+// @Description  delete schedule
+// @Description  requires the rights of the 'start' operation (default 'x') on the network
+// @Description  with deployment_owner_protection, only the owner of the scheduled deployment and users with the rights of the 'admin' operation are allowed
+// @Tags         schedule
+// @Produce      json
+// @Security Bearer
+// @Param        networkId path string true "network id"
+// @Param        scheduleId path string true "schedule id"
+// @Success      200
+// @Failure      400
+// @Failure      401
+// @Failure      403
+// @Failure      404
+// @Failure      500
+// @Router       /schedules/{networkId}/{scheduleId} [DELETE]
+func (this *ScheduleEndpoints) DeleteSchedule(config configuration.Config, ctrl *controller.Controller, router *http.ServeMux) {
+	router.HandleFunc("DELETE /schedules/{networkId}/{scheduleId}", func(writer http.ResponseWriter, request *http.Request) {
+		networkId := request.PathValue("networkId")
+		scheduleId := request.PathValue("scheduleId")
+		err, errCode := ctrl.ApiCheckAccess(request, networkId, configuration.OperationStart)
+		if err != nil {
+			http.Error(writer, err.Error(), errCode)
+			return
+		}
+		err, errCode = ctrl.ApiCheckScheduleOwner(request, networkId, scheduleId)
+		if err != nil {
+			http.Error(writer, err.Error(), errCode)
+			return
+		}
+		err, errCode = ctrl.ApiDeleteSchedule(networkId, scheduleId)
+		if err != nil {
+			http.Error(writer, err.Error(), errCode)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		err = json.NewEncoder(writer).Encode(true)
+		if err != nil {
+			config.GetLogger().Error("unable to encode response", "error", err)
+		}
+		return
+	})
+}
@@ -33,6 +33,7 @@ type SyncEndpoints struct{}
 // ReSyncDeployments godoc
 // @Summary      resync deployments
 // @Description  resync deployments that are registered as lost on the mgw side. can only be tried once.
+// @Description  requires the rights of the 'deploy' operation (default 'w') on the network
 // @Tags         deployment
 // @Produce      json
 // @Security Bearer
@@ -47,12 +48,12 @@ type SyncEndpoints struct{}
 func (this *SyncEndpoints) ReSyncDeployments(config configuration.Config, ctrl *controller.Controller, router *http.ServeMux) {
 	router.HandleFunc("POST /sync/deployments/{networkId}", func(writer http.ResponseWriter, request *http.Request) {
 		networkId := request.PathValue("networkId")
-		_, err, errCode := ctrl.ApiCheckAccessReturnToken(request, networkId, "a")
+		_, err, errCode := ctrl.ApiCheckAccessReturnToken(request, networkId, configuration.OperationDeploy)
 		if err != nil {
 			http.Error(writer, err.Error(), errCode)
 			return
 		}
-		err, errCode = ctrl.ApiSyncDeployments(networkId)
+		err, errCode = ctrl.ApiSyncDeployments(request.Context(), networkId)
 		if err != nil {
 			http.Error(writer, err.Error(), errCode)
 			return
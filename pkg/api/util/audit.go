@@ -0,0 +1,214 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/SENERGY-Platform/process-sync/pkg/model"
+	"github.com/SENERGY-Platform/process-sync/pkg/security"
+)
+
+const auditMaxBodySize = 4096
+const auditMaxErrorSize = 1024
+
+// mutatingGetPatterns lists GET endpoints with side effects, which are audited like POST, PUT and DELETE calls
+var mutatingGetPatterns = map[string]bool{
+	"GET /deployments/{networkId}/{deploymentId}/start": true,
+}
+
+// entityIdPathValues are the path values used as audit entity id; the first existing one is used
+var entityIdPathValues = []string{"id", "deploymentId", "scheduleId", "businessKey", "business_key"}
+
+type AuditLogger interface {
+	LogAudit(entry model.AuditEntry)
+}
+
+// RouteResolver finds the route pattern of a request (e.g. *http.ServeMux)
+type RouteResolver interface {
+	Handler(r *http.Request) (h http.Handler, pattern string)
+}
+
+// NewAudit logs the requests handled by handler; routes may be nil, if all requests reach the router
+func NewAudit(handler http.Handler, logger AuditLogger, routes RouteResolver) *AuditMiddleware {
+	return &AuditMiddleware{handler: handler, logger: logger, routes: routes}
+}
+
+// AuditMiddleware logs mutating requests by the pattern of the matched route; it must wrap the router (or a middleware passing the request unchanged) to see the pattern and path values.
+// requests rejected before the router (e.g. by the auth middleware) are matched with routes
+type AuditMiddleware struct {
+	handler http.Handler
+	logger  AuditLogger
+	routes  RouteResolver
+}
+
+func (this *AuditMiddleware) ServeHTTP(res http.ResponseWriter, req *http.Request) {
+	req = req.WithContext(security.ContextWithClaimsHolder(req.Context())) //filled by the wrapped auth middleware
+	if req.Method == http.MethodGet || req.Method == http.MethodHead || req.Method == http.MethodOptions {
+		writer := newAuditStatusWriter(res)
+		this.handler.ServeHTTP(writer, req)
+		if mutatingGetPatterns[req.Pattern] {
+			this.logger.LogAudit(newAuditEntry(req, nil, writer.status, writer.errBody.String()))
+		}
+		return
+	}
+	body := &limitedBuffer{limit: auditMaxBodySize}
+	if req.Body != nil {
+		req.Body = readCloser{Reader: io.TeeReader(req.Body, body), Closer: req.Body}
+	}
+	writer := newAuditStatusWriter(res)
+	this.handler.ServeHTTP(writer, req)
+	if req.Pattern == "" {
+		this.resolveRoute(req)
+	}
+	if req.Pattern == "" {
+		return //unknown endpoint
+	}
+	this.logger.LogAudit(newAuditEntry(req, body, writer.status, writer.errBody.String()))
+}
+
+// resolveRoute sets pattern and path values of requests which did not reach the router
+func (this *AuditMiddleware) resolveRoute(req *http.Request) {
+	if this.routes == nil {
+		return
+	}
+	_, pattern := this.routes.Handler(req)
+	if pattern == "" {
+		return
+	}
+	req.Pattern = pattern
+	_, path, _ := strings.Cut(pattern, " ")
+	patternSegments := strings.Split(strings.Trim(path, "/"), "/")
+	pathSegments := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+	for i, segment := range patternSegments {
+		if i >= len(pathSegments) || !strings.HasPrefix(segment, "{") || !strings.HasSuffix(segment, "}") {
+			continue
+		}
+		name := strings.Trim(segment, "{}")
+		if strings.HasSuffix(name, "...") {
+			req.SetPathValue(strings.TrimSuffix(name, "..."), strings.Join(pathSegments[i:], "/"))
+			return
+		}
+		req.SetPathValue(name, pathSegments[i])
+	}
+}
+
+// newAuditEntry describes a handled request; req.Pattern must be set by the router.
+// the user id is taken from the claims verified by the auth middleware; requests which failed the auth are logged without user
+func newAuditEntry(req *http.Request, body *limitedBuffer, status int, errMsg string) model.AuditEntry {
+	entry := model.AuditEntry{
+		Action:     req.Pattern,
+		Parameters: map[string]interface{}{},
+		StatusCode: status,
+		Success:    status < 300,
+		Error:      strings.TrimSpace(errMsg),
+	}
+	if claims, ok := security.ContextClaims(req.Context(), req.Header.Get("Authorization")); ok {
+		entry.UserId = claims.UserId
+	}
+	path := req.Pattern
+	if _, p, found := strings.Cut(path, " "); found {
+		path = p
+	}
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(segments) > 0 {
+		entry.Entity = segments[0]
+	}
+	for _, segment := range segments {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			name := strings.TrimSuffix(strings.Trim(segment, "{}"), "...")
+			entry.Parameters[name] = req.PathValue(name)
+		}
+	}
+	if networkId, ok := entry.Parameters["networkId"].(string); ok {
+		entry.NetworkId = networkId
+	}
+	for _, name := range entityIdPathValues {
+		if value, ok := entry.Parameters[name].(string); ok && value != "" {
+			entry.EntityId = value
+			break
+		}
+	}
+	if len(req.URL.Query()) > 0 {
+		entry.Parameters["query"] = req.URL.Query()
+	}
+	if body != nil && body.Len() > 0 {
+		var parsed interface{}
+		if !body.truncated && json.Unmarshal(body.Bytes(), &parsed) == nil {
+			entry.Parameters["body"] = parsed
+		} else {
+			entry.Parameters["body"] = body.String()
+			entry.Parameters["body_truncated"] = body.truncated
+		}
+	}
+	return entry
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// limitedBuffer stores the first limit bytes written to it and discards the rest
+type limitedBuffer struct {
+	bytes.Buffer
+	limit     int
+	truncated bool
+}
+
+func (this *limitedBuffer) Write(p []byte) (n int, err error) {
+	remaining := this.limit - this.Len()
+	if remaining < len(p) {
+		this.truncated = true
+		if remaining > 0 {
+			this.Buffer.Write(p[:remaining])
+		}
+		return len(p), nil
+	}
+	return this.Buffer.Write(p)
+}
+
+// auditStatusWriter records the status and the start of error responses
+type auditStatusWriter struct {
+	http.ResponseWriter
+	status  int
+	errBody limitedBuffer
+}
+
+func newAuditStatusWriter(res http.ResponseWriter) *auditStatusWriter {
+	return &auditStatusWriter{ResponseWriter: res, status: http.StatusOK, errBody: limitedBuffer{limit: auditMaxErrorSize}}
+}
+
+func (this *auditStatusWriter) WriteHeader(status int) {
+	this.status = status
+	this.ResponseWriter.WriteHeader(status)
+}
+
+func (this *auditStatusWriter) Write(p []byte) (int, error) {
+	if this.status >= 400 {
+		this.errBody.Write(p)
+	}
+	return this.ResponseWriter.Write(p)
+}
+
+func (this *auditStatusWriter) Unwrap() http.ResponseWriter {
+	return this.ResponseWriter
+}
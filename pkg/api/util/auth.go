@@ -0,0 +1,56 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	"net/http"
+
+	"github.com/SENERGY-Platform/process-sync/pkg/security"
+)
+
+type TokenValidator interface {
+	ApiVerifyToken(token string) (security.Claims, error)
+}
+
+func NewAuth(handler http.Handler, validator TokenValidator) *AuthMiddleware {
+	return &AuthMiddleware{handler: handler, validator: validator}
+}
+
+// AuthMiddleware rejects requests with an invalid Authorization header before any handler (and permission check) runs;
+// requests without Authorization header are passed to the handlers, which reject them if the endpoint is not public.
+// the verified claims are stored in the claims holder of the request context (see security.ContextWithClaimsHolder), so that permission checks do not validate the token again;
+// the holder should be added by an outer middleware (e.g. audit), because a request with new context hides the matched route pattern from outer middlewares
+type AuthMiddleware struct {
+	handler   http.Handler
+	validator TokenValidator
+}
+
+func (this *AuthMiddleware) ServeHTTP(res http.ResponseWriter, req *http.Request) {
+	token := req.Header.Get("Authorization")
+	if token != "" && req.Method != http.MethodOptions {
+		claims, err := this.validator.ApiVerifyToken(token)
+		if err != nil {
+			http.Error(res, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		if !security.SetContextClaims(req.Context(), claims) {
+			req = req.WithContext(security.ContextWithClaimsHolder(req.Context()))
+			security.SetContextClaims(req.Context(), claims)
+		}
+	}
+	this.handler.ServeHTTP(res, req)
+}
@@ -0,0 +1,69 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/SENERGY-Platform/process-sync/pkg/security"
+)
+
+type tokenValidatorFunc func(token string) (security.Claims, error)
+
+func (this tokenValidatorFunc) ApiVerifyToken(token string) (security.Claims, error) {
+	return this(token)
+}
+
+func TestAuthMiddleware(t *testing.T) {
+	handled := 0
+	handler := NewAuth(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		handled++
+		token := request.Header.Get("Authorization")
+		if claims, ok := security.ContextClaims(request.Context(), token); token != "" && (!ok || claims.UserId != "user") {
+			t.Error("missing verified claims", claims, ok)
+		}
+	}), tokenValidatorFunc(func(token string) (security.Claims, error) {
+		if token != "Bearer valid" {
+			return security.Claims{}, errors.New("invalid token")
+		}
+		return security.Claims{Token: token, UserId: "user"}, nil
+	}))
+	for _, test := range []struct {
+		token  string
+		status int
+	}{
+		{token: "Bearer valid", status: http.StatusOK},
+		{token: "Bearer invalid", status: http.StatusUnauthorized},
+		{token: "", status: http.StatusOK},
+	} {
+		request := httptest.NewRequest(http.MethodGet, "/deployments", nil)
+		if test.token != "" {
+			request.Header.Set("Authorization", test.token)
+		}
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, request)
+		if recorder.Code != test.status {
+			t.Error(test.token, recorder.Code)
+		}
+	}
+	if handled != 2 {
+		t.Error(handled)
+	}
+}
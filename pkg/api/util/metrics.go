@@ -0,0 +1,59 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/SENERGY-Platform/process-sync/pkg/metrics"
+)
+
+func NewMetrics(handler http.Handler, m *metrics.Metrics) *MetricsMiddleware {
+	return &MetricsMiddleware{handler: handler, metrics: m}
+}
+
+// MetricsMiddleware records requests by the pattern of the matched route; it must wrap the router directly to see the pattern
+type MetricsMiddleware struct {
+	handler http.Handler
+	metrics *metrics.Metrics
+}
+
+func (this *MetricsMiddleware) ServeHTTP(res http.ResponseWriter, req *http.Request) {
+	start := time.Now()
+	writer := &statusWriter{ResponseWriter: res, status: http.StatusOK}
+	this.handler.ServeHTTP(writer, req)
+	endpoint := req.Pattern
+	if endpoint == "" {
+		endpoint = "unmatched"
+	}
+	this.metrics.ApiRequest(endpoint, writer.status, time.Since(start))
+}
+
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (this *statusWriter) WriteHeader(status int) {
+	this.status = status
+	this.ResponseWriter.WriteHeader(status)
+}
+
+func (this *statusWriter) Unwrap() http.ResponseWriter {
+	return this.ResponseWriter
+}
@@ -0,0 +1,67 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	"net/http"
+	"time"
+)
+
+// NewTimeouts passes requests of the long running routes to longRunning, all other requests to handler
+func NewTimeouts(handler http.Handler, longRunning http.Handler, routes RouteResolver, longRunningPatterns []string, read time.Duration, write time.Duration) *TimeoutMiddleware {
+	patterns := map[string]bool{}
+	for _, pattern := range longRunningPatterns {
+		patterns[pattern] = true
+	}
+	return &TimeoutMiddleware{handler: handler, longRunning: longRunning, routes: routes, longRunningPatterns: patterns, read: read, write: write}
+}
+
+// TimeoutMiddleware replaces the read and write timeouts of the server, which would apply to every route:
+// requests to handler get read and write deadlines, requests of long running routes (e.g. streams or imports) none.
+// it must be the outermost handler, because response writers of other middlewares may not support deadlines
+type TimeoutMiddleware struct {
+	handler             http.Handler
+	longRunning         http.Handler
+	routes              RouteResolver
+	longRunningPatterns map[string]bool
+	read                time.Duration
+	write               time.Duration
+}
+
+func (this *TimeoutMiddleware) ServeHTTP(res http.ResponseWriter, req *http.Request) {
+	_, pattern := this.routes.Handler(req)
+	longRunning := this.longRunningPatterns[pattern]
+	var readDeadline, writeDeadline time.Time //deadlines of previous requests on the same connection are reset for long running routes
+	if !longRunning {
+		now := time.Now()
+		readDeadline, writeDeadline = now.Add(this.read), now.Add(this.write)
+	}
+	controller := http.NewResponseController(res)
+	err := controller.SetReadDeadline(readDeadline)
+	if err == nil {
+		err = controller.SetWriteDeadline(writeDeadline)
+	}
+	if err != nil {
+		http.Error(res, "unable to set request timeouts: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if longRunning {
+		this.longRunning.ServeHTTP(res, req)
+	} else {
+		this.handler.ServeHTTP(res, req)
+	}
+}
@@ -0,0 +1,395 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/SENERGY-Platform/process-sync/pkg/configuration"
+	"github.com/SENERGY-Platform/process-sync/pkg/controller"
+	"github.com/SENERGY-Platform/process-sync/pkg/model"
+)
+
+func init() {
+	endpoints = append(endpoints, &WardenEndpoints{})
+}
+
+type WardenEndpoints struct{}
+
+// ListWardenInfo godoc
+// @Summary      list warden infos
+// @Description  list the process instances handled by the warden in the network with their computed status (healthy, missing_instance, duplicate, restarting, waiting_for_age_gate, finished, gave_up, paused)
+// @Description  requires the rights of the 'read' operation (default 'r') on the network
+// @Tags         warden
+// @Produce      json
+// @Security Bearer
+// @Param        networkId path string true "network id"
+// @Param        deployment_id query string false "comma separated list of deployment-ids used to filter the warden infos"
+// @Param        limit query integer false "default 100"
+// @Param        offset query integer false "default 0"
+// @Success      200 {array}  model.WardenInfoWithStatus
+// @Failure      400
+// @Failure      401
+// @Failure      403
+// @Failure      404
+// @Failure      500
+// @Router       /warden/{networkId} [GET]
+func (this *WardenEndpoints) ListWardenInfo(config configuration.Config, ctrl *controller.Controller, router *http.ServeMux) {
+	router.HandleFunc("GET /warden/{networkId}", func(writer http.ResponseWriter, request *http.Request) {
+		networkId := request.PathValue("networkId")
+		limitStr := request.URL.Query().Get("limit")
+		if limitStr == "" {
+			limitStr = "100"
+		}
+		limit, err := strconv.ParseInt(limitStr, 10, 64)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		offsetStr := request.URL.Query().Get("offset")
+		if offsetStr == "" {
+			offsetStr = "0"
+		}
+		offset, err := strconv.ParseInt(offsetStr, 10, 64)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		deploymentIds := []string{}
+		if deploymentIdsStr := request.URL.Query().Get("deployment_id"); deploymentIdsStr != "" {
+			deploymentIds = strings.Split(deploymentIdsStr, ",")
+		}
+		err, errCode := ctrl.ApiCheckAccess(request, networkId, configuration.OperationRead)
+		if err != nil {
+			http.Error(writer, err.Error(), errCode)
+			return
+		}
+		result, err, errCode := ctrl.ApiListWardenInfo(networkId, deploymentIds, limit, offset)
+		if err != nil {
+			http.Error(writer, err.Error(), errCode)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		err = json.NewEncoder(writer).Encode(result)
+		if err != nil {
+			config.GetLogger().Error("unable to encode response", "error", err)
+		}
+		return
+	})
+}
+
+// GetWardenInfo godoc
+// @Summary      get warden info
+// @Description  get the warden info of a process instance with its computed status; the business key may be passed with or without the 'wardened:' prefix
+// @Description  requires the rights of the 'read' operation (default 'r') on the network
+// @Tags         warden
+// @Produce      json
+// @Security Bearer
+// @Param        networkId path string true "network id"
+// @Param        businessKey path string true "business key"
+// @Success      200 {object}  model.WardenInfoWithStatus
+// @Failure      400
+// @Failure      401
+// @Failure      403
+// @Failure      404
+// @Failure      500
+// @Router       /warden/{networkId}/{businessKey} [GET]
+func (this *WardenEndpoints) GetWardenInfo(config configuration.Config, ctrl *controller.Controller, router *http.ServeMux) {
+	router.HandleFunc("GET /warden/{networkId}/{businessKey}", func(writer http.ResponseWriter, request *http.Request) {
+		networkId := request.PathValue("networkId")
+		businessKey := request.PathValue("businessKey")
+		err, errCode := ctrl.ApiCheckAccess(request, networkId, configuration.OperationRead)
+		if err != nil {
+			http.Error(writer, err.Error(), errCode)
+			return
+		}
+		result, err, errCode := ctrl.ApiReadWardenInfo(networkId, businessKey)
+		if err != nil {
+			http.Error(writer, err.Error(), errCode)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		err = json.NewEncoder(writer).Encode(result)
+		if err != nil {
+			config.GetLogger().Error("unable to encode response", "error", err)
+		}
+		return
+	})
+}
+
+// CheckWardenInfo godoc
+// @Summary      check warden info
+// @Description  run the warden check for the process instance now (also if it is paused); the check may start or stop process instances. returns the resulting state; status 'removed' signals that the check removed the warden info.
+// @Description  with warden_leader_election, the check runs only on the replica holding the warden db loop lease, never concurrently with the db loop; other replicas queue the check for the lease holder (with warden_event_checks; paused entries are skipped by queued checks) and return the current state with 202, or respond with 503 without warden_event_checks
+// @Description  requires the rights of the 'start' operation (default 'x') on the network
+// @Description  with deployment_owner_protection, only the owner of the deployment and users with the rights of the 'admin' operation are allowed
+// @Tags         warden
+// @Produce      json
+// @Security Bearer
+// @Param        networkId path string true "network id"
+// @Param        businessKey path string true "business key"
+// @Success      200 {object}  model.WardenInfoWithStatus
+// @Success      202 {object}  model.WardenInfoWithStatus
+// @Failure      400
+// @Failure      401
+// @Failure      403
+// @Failure      404
+// @Failure      500
+// @Failure      503
+// @Router       /warden/{networkId}/{businessKey}/check [POST]
+func (this *WardenEndpoints) CheckWardenInfo(config configuration.Config, ctrl *controller.Controller, router *http.ServeMux) {
+	router.HandleFunc("POST /warden/{networkId}/{businessKey}/check", func(writer http.ResponseWriter, request *http.Request) {
+		networkId := request.PathValue("networkId")
+		businessKey := request.PathValue("businessKey")
+		err, errCode := ctrl.ApiCheckAccess(request, networkId, configuration.OperationStart)
+		if err != nil {
+			http.Error(writer, err.Error(), errCode)
+			return
+		}
+		err, errCode = ctrl.ApiCheckWardenInfoOwner(request, networkId, businessKey)
+		if err != nil {
+			http.Error(writer, err.Error(), errCode)
+			return
+		}
+		result, err, errCode := ctrl.ApiCheckWardenInfo(request.Context(), networkId, businessKey)
+		if err != nil {
+			http.Error(writer, err.Error(), errCode)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		writer.WriteHeader(errCode)
+		err = json.NewEncoder(writer).Encode(result)
+		if err != nil {
+			config.GetLogger().Error("unable to encode response", "error", err)
+		}
+		return
+	})
+}
+
+// RemoveWardenInfo godoc
+// @Summary      remove warden info
+// @Description  stop wardening the process instance; the process instance itself is not stopped, but it may be stopped by the warden as instance without warden info
+// @Description  requires the rights of the 'admin' operation (default 'a') on the network
+// @Tags         warden
+// @Produce      json
+// @Security Bearer
+// @Param        networkId path string true "network id"
+// @Param        businessKey path string true "business key"
+// @Success      200
+// @Failure      400
+// @Failure      401
+// @Failure      403
+// @Failure      404
+// @Failure      500
+// @Router       /warden/{networkId}/{businessKey} [DELETE]
+func (this *WardenEndpoints) RemoveWardenInfo(config configuration.Config, ctrl *controller.Controller, router *http.ServeMux) {
+	router.HandleFunc("DELETE /warden/{networkId}/{businessKey}", func(writer http.ResponseWriter, request *http.Request) {
+		networkId := request.PathValue("networkId")
+		businessKey := request.PathValue("businessKey")
+		err, errCode := ctrl.ApiCheckAccess(request, networkId, configuration.OperationAdmin)
+		if err != nil {
+			http.Error(writer, err.Error(), errCode)
+			return
+		}
+		err, errCode = ctrl.ApiRemoveWardenInfo(networkId, businessKey)
+		if err != nil {
+			http.Error(writer, err.Error(), errCode)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		err = json.NewEncoder(writer).Encode(true)
+		if err != nil {
+			config.GetLogger().Error("unable to encode response", "error", err)
+		}
+		return
+	})
+}
+
+// PauseDeploymentWarden godoc
+// @Summary      pause deployment warden
+// @Description  pause the warden handling of the deployment and its process instances; paused instances are neither restarted nor stopped and the deployment is not redeployed. instances started while the deployment is paused are paused too.
+// @Description  requires the rights of the 'admin' operation (default 'a') on the network
+// @Description  with deployment_owner_protection, only the owner of the deployment and users with the rights of the 'admin' operation are allowed
+// @Tags         warden
+// @Produce      json
+// @Security Bearer
+// @Param        networkId path string true "network id"
+// @Param        deploymentId path string true "deployment id"
+// @Success      200
+// @Failure      400
+// @Failure      401
+// @Failure      403
+// @Failure      404
+// @Failure      500
+// @Router       /warden/{networkId}/deployments/{deploymentId}/pause [POST]
+func (this *WardenEndpoints) PauseDeploymentWarden(config configuration.Config, ctrl *controller.Controller, router *http.ServeMux) {
+	router.HandleFunc("POST /warden/{networkId}/deployments/{deploymentId}/pause", func(writer http.ResponseWriter, request *http.Request) {
+		networkId := request.PathValue("networkId")
+		deploymentId := request.PathValue("deploymentId")
+		err, errCode := ctrl.ApiCheckAccess(request, networkId, configuration.OperationAdmin)
+		if err != nil {
+			http.Error(writer, err.Error(), errCode)
+			return
+		}
+		err, errCode = ctrl.ApiCheckDeploymentOwner(request, networkId, deploymentId)
+		if err != nil {
+			http.Error(writer, err.Error(), errCode)
+			return
+		}
+		err, errCode = ctrl.ApiSetDeploymentWardenPaused(networkId, deploymentId, true)
+		if err != nil {
+			http.Error(writer, err.Error(), errCode)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		err = json.NewEncoder(writer).Encode(true)
+		if err != nil {
+			config.GetLogger().Error("unable to encode response", "error", err)
+		}
+		return
+	})
+}
+
+// ResumeDeploymentWarden godoc
+// @Summary      resume deployment warden
+// @Description  resume the warden handling of the deployment and its process instances
+// @Description  requires the rights of the 'admin' operation (default 'a') on the network
+// @Description  with deployment_owner_protection, only the owner of the deployment and users with the rights of the 'admin' operation are allowed
+// @Tags         warden
+// @Produce      json
+// @Security Bearer
+// @Param        networkId path string true "network id"
+// @Param        deploymentId path string true "deployment id"
+// @Success      200
+// @Failure      400
+// @Failure      401
+// @Failure      403
+// @Failure      404
+// @Failure      500
+// @Router       /warden/{networkId}/deployments/{deploymentId}/resume [POST]
+func (this *WardenEndpoints) ResumeDeploymentWarden(config configuration.Config, ctrl *controller.Controller, router *http.ServeMux) {
+	router.HandleFunc("POST /warden/{networkId}/deployments/{deploymentId}/resume", func(writer http.ResponseWriter, request *http.Request) {
+		networkId := request.PathValue("networkId")
+		deploymentId := request.PathValue("deploymentId")
+		err, errCode := ctrl.ApiCheckAccess(request, networkId, configuration.OperationAdmin)
+		if err != nil {
+			http.Error(writer, err.Error(), errCode)
+			return
+		}
+		err, errCode = ctrl.ApiCheckDeploymentOwner(request, networkId, deploymentId)
+		if err != nil {
+			http.Error(writer, err.Error(), errCode)
+			return
+		}
+		err, errCode = ctrl.ApiSetDeploymentWardenPaused(networkId, deploymentId, false)
+		if err != nil {
+			http.Error(writer, err.Error(), errCode)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		err = json.NewEncoder(writer).Encode(true)
+		if err != nil {
+			config.GetLogger().Error("unable to encode response", "error", err)
+		}
+		return
+	})
+}
+
+// ListWardenDecisions godoc
+// @Summary      list warden decisions
+// @Description  list the decisions of the warden (start, restart, stop, redeploy, replace_placeholder, remove_warden, give_up) in the network; decisions with dry_run=true have been logged but not executed
+// @Description  requires the rights of the 'read' operation (default 'r') on the network
+// @Tags         warden
+// @Produce      json
+// @Security Bearer
+// @Param        networkId path string true "network id"
+// @Param        entity_id query string false "comma separated list of entity ids (business key, deployment id or process instance id)"
+// @Param        business_key query string false "comma separated list of business keys; may be passed with or without the 'wardened:' prefix"
+// @Param        decision query string false "comma separated list of decisions"
+// @Param        since query string false "RFC3339 timestamp; only decisions made at or after this time are returned"
+// @Param        limit query integer false "default 100"
+// @Param        offset query integer false "default 0"
+// @Param        sort query string false "default time.desc; time, entity_id or decision with optional .asc or .desc"
+// @Success      200 {array}  model.WardenDecision
+// @Failure      400
+// @Failure      401
+// @Failure      403
+// @Failure      500
+// @Router       /warden/{networkId}/decisions [GET]
+func (this *WardenEndpoints) ListWardenDecisions(config configuration.Config, ctrl *controller.Controller, router *http.ServeMux) {
+	router.HandleFunc("GET /warden/{networkId}/decisions", func(writer http.ResponseWriter, request *http.Request) {
+		networkId := request.PathValue("networkId")
+		query := model.WardenDecisionQuery{
+			Sort: request.URL.Query().Get("sort"),
+		}
+		limitStr := request.URL.Query().Get("limit")
+		if limitStr == "" {
+			limitStr = "100"
+		}
+		var err error
+		query.Limit, err = strconv.ParseInt(limitStr, 10, 64)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		offsetStr := request.URL.Query().Get("offset")
+		if offsetStr == "" {
+			offsetStr = "0"
+		}
+		query.Offset, err = strconv.ParseInt(offsetStr, 10, 64)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if sinceStr := request.URL.Query().Get("since"); sinceStr != "" {
+			query.Since, err = time.Parse(time.RFC3339, sinceStr)
+			if err != nil {
+				http.Error(writer, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+		if entityIdsStr := request.URL.Query().Get("entity_id"); entityIdsStr != "" {
+			query.EntityIds = strings.Split(entityIdsStr, ",")
+		}
+		if businessKeysStr := request.URL.Query().Get("business_key"); businessKeysStr != "" {
+			query.BusinessKeys = strings.Split(businessKeysStr, ",")
+		}
+		if decisionsStr := request.URL.Query().Get("decision"); decisionsStr != "" {
+			query.Decisions = strings.Split(decisionsStr, ",")
+		}
+		err, errCode := ctrl.ApiCheckAccess(request, networkId, configuration.OperationRead)
+		if err != nil {
+			http.Error(writer, err.Error(), errCode)
+			return
+		}
+		result, err, errCode := ctrl.ApiListWardenDecisions(networkId, query)
+		if err != nil {
+			http.Error(writer, err.Error(), errCode)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+		err = json.NewEncoder(writer).Encode(result)
+		if err != nil {
+			config.GetLogger().Error("unable to encode response", "error", err)
+		}
+		return
+	})
+}
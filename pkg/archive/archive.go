@@ -0,0 +1,187 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package archive
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/SENERGY-Platform/process-sync/pkg/configuration"
+	"github.com/SENERGY-Platform/process-sync/pkg/model"
+)
+
+const (
+	EntityHistory   = "history"
+	EntityIncidents = "incidents"
+)
+
+const fileSuffix = ".ndjson.gz"
+
+// Archiver writes records as gzip compressed NDJSON files, partitioned by entity, network and month:
+// {entity}/{network_id}/{yyyy-mm}/{yyyymmddThhmmss.nnnnnnnnn}-{n}.ndjson.gz
+type Archiver struct {
+	store Store
+}
+
+func New(store Store) *Archiver {
+	return &Archiver{store: store}
+}
+
+func (this *Archiver) ArchiveHistory(ctx context.Context, instances []model.HistoricProcessInstance) (err error) {
+	records := make([]record, 0, len(instances))
+	for _, instance := range instances {
+		t := time.Time{}
+		switch {
+		case instance.EndDate != nil:
+			t = *instance.EndDate
+		case instance.StartDate != nil:
+			t = *instance.StartDate
+		}
+		records = append(records, record{networkId: instance.NetworkId, time: t, document: instance})
+	}
+	return this.write(ctx, EntityHistory, records)
+}
+
+func (this *Archiver) ArchiveIncidents(ctx context.Context, incidents []model.Incident) (err error) {
+	records := make([]record, 0, len(incidents))
+	for _, incident := range incidents {
+		records = append(records, record{networkId: incident.NetworkId, time: incident.Time, document: incident})
+	}
+	return this.write(ctx, EntityIncidents, records)
+}
+
+type record struct {
+	networkId string
+	time      time.Time
+	document  interface{}
+}
+
+func partition(entity string, networkId string, t time.Time) string {
+	month := "unknown"
+	if !t.IsZero() {
+		month = t.UTC().Format("2006-01")
+	}
+	return entity + "/" + networkId + "/" + month
+}
+
+func (this *Archiver) write(ctx context.Context, entity string, records []record) error {
+	partitions := map[string][]record{}
+	for _, r := range records {
+		key := partition(entity, r.networkId, r.time)
+		partitions[key] = append(partitions[key], r)
+	}
+	keys := make([]string, 0, len(partitions))
+	for key := range partitions {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	timestamp := configuration.TimeNow().UTC().Format("20060102T150405.000000000")
+	for i, key := range keys {
+		buf := bytes.NewBuffer(nil)
+		writer := gzip.NewWriter(buf)
+		encoder := json.NewEncoder(writer)
+		for _, r := range partitions[key] {
+			err := encoder.Encode(r.document)
+			if err != nil {
+				return err
+			}
+		}
+		err := writer.Close()
+		if err != nil {
+			return err
+		}
+		err = this.store.Put(ctx, fmt.Sprintf("%v/%v-%v%v", key, timestamp, i, fileSuffix), buf.Bytes())
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RestoreTarget is implemented by database.Database
+type RestoreTarget interface {
+	SaveHistoricProcessInstance(historicProcessInstance model.HistoricProcessInstance) error
+	SaveIncident(incident model.Incident) (newDocument bool, err error)
+}
+
+// Restore re-imports all archive files with the key prefix (e.g. "history/{network_id}/2026-01/") into target; existing documents are overwritten
+func Restore(ctx context.Context, store Store, target RestoreTarget, prefix string) (count int, err error) {
+	keys, err := store.List(ctx, prefix)
+	if err != nil {
+		return 0, err
+	}
+	for _, key := range keys {
+		if !strings.HasSuffix(key, fileSuffix) {
+			continue
+		}
+		n, err := restoreFile(ctx, store, target, key)
+		count += n
+		if err != nil {
+			return count, fmt.Errorf("unable to restore %v: %w", key, err)
+		}
+	}
+	return count, nil
+}
+
+func restoreFile(ctx context.Context, store Store, target RestoreTarget, key string) (count int, err error) {
+	entity, _, _ := strings.Cut(key, "/")
+	if entity != EntityHistory && entity != EntityIncidents {
+		return 0, errors.New("unknown archive entity")
+	}
+	file, err := store.Get(ctx, key)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+	reader, err := gzip.NewReader(file)
+	if err != nil {
+		return 0, err
+	}
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 64*1024), 64*1024*1024)
+	for scanner.Scan() {
+		switch entity {
+		case EntityHistory:
+			instance := model.HistoricProcessInstance{}
+			err = json.Unmarshal(scanner.Bytes(), &instance)
+			if err != nil {
+				return count, err
+			}
+			err = target.SaveHistoricProcessInstance(instance)
+		case EntityIncidents:
+			incident := model.Incident{}
+			err = json.Unmarshal(scanner.Bytes(), &incident)
+			if err != nil {
+				return count, err
+			}
+			_, err = target.SaveIncident(incident)
+		}
+		if err != nil {
+			return count, err
+		}
+		count++
+	}
+	return count, scanner.Err()
+}
@@ -0,0 +1,105 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package archive
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/SENERGY-Platform/process-sync/pkg/model"
+	"github.com/SENERGY-Platform/process-sync/pkg/model/camundamodel"
+)
+
+type restoreTarget struct {
+	history   []model.HistoricProcessInstance
+	incidents []model.Incident
+}
+
+func (this *restoreTarget) SaveHistoricProcessInstance(historicProcessInstance model.HistoricProcessInstance) error {
+	this.history = append(this.history, historicProcessInstance)
+	return nil
+}
+
+func (this *restoreTarget) SaveIncident(incident model.Incident) (newDocument bool, err error) {
+	this.incidents = append(this.incidents, incident)
+	return true, nil
+}
+
+func TestArchiveRestore(t *testing.T) {
+	store, err := NewLocalStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	archiver := New(store)
+	ctx := context.Background()
+
+	history := []model.HistoricProcessInstance{
+		{HistoricProcessInstance: camundamodel.HistoricProcessInstance{Id: "h1", StartTime: "2026-01-01T10:00:00.000+0000", EndTime: "2026-01-31T10:00:00.000+0000"}, SyncInfo: model.SyncInfo{NetworkId: "n1"}},
+		{HistoricProcessInstance: camundamodel.HistoricProcessInstance{Id: "h2", StartTime: "2026-01-31T10:00:00.000+0000", EndTime: "2026-02-01T10:00:00.000+0000"}, SyncInfo: model.SyncInfo{NetworkId: "n1"}},
+		{HistoricProcessInstance: camundamodel.HistoricProcessInstance{Id: "h3", StartTime: "2026-01-01T10:00:00.000+0000", EndTime: "2026-01-01T11:00:00.000+0000"}, SyncInfo: model.SyncInfo{NetworkId: "n2"}},
+	}
+	for i, instance := range history {
+		history[i] = instance.WithParsedDates()
+	}
+	err = archiver.ArchiveHistory(ctx, history)
+	if err != nil {
+		t.Fatal(err)
+	}
+	incidents := []model.Incident{
+		{Incident: camundamodel.Incident{Id: "i1", ErrorMessage: "error", Time: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)}, SyncInfo: model.SyncInfo{NetworkId: "n1"}},
+	}
+	err = archiver.ArchiveIncidents(ctx, incidents)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keys, err := store.List(ctx, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	partitions := []string{}
+	for _, key := range keys {
+		partitions = append(partitions, key[:len(key)-len("20060102T150405.000000000-0.ndjson.gz")])
+	}
+	expected := []string{"history/n1/2026-01/", "history/n1/2026-02/", "history/n2/2026-01/", "incidents/n1/2026-03/"}
+	if !reflect.DeepEqual(partitions, expected) {
+		t.Fatal(partitions)
+	}
+
+	target := &restoreTarget{}
+	count, err := Restore(ctx, store, target, "history/n1/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 2 || len(target.history) != 2 || len(target.incidents) != 0 {
+		t.Fatal(count, target)
+	}
+	if !reflect.DeepEqual(target.history, history[:2]) {
+		t.Errorf("%#v", target.history)
+	}
+
+	target = &restoreTarget{}
+	count, err = Restore(ctx, store, target, "incidents/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 1 || !reflect.DeepEqual(target.incidents, incidents) {
+		t.Errorf("%#v", target.incidents)
+	}
+}
@@ -0,0 +1,55 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package archive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/SENERGY-Platform/process-sync/pkg/configuration"
+)
+
+var ErrNotFound = errors.New("archive not found")
+
+// Store persists archive files by key; keys use '/' as separator
+type Store interface {
+	Put(ctx context.Context, key string, data []byte) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	List(ctx context.Context, prefix string) (keys []string, err error)
+}
+
+// NewStore returns the store selected by config.ArchiveStore or nil if archiving is disabled
+func NewStore(config configuration.Config) (Store, error) {
+	switch config.ArchiveStore {
+	case "", "-":
+		return nil, nil
+	case "local":
+		return NewLocalStore(config.ArchiveLocalDir)
+	case "s3":
+		return NewS3Store(S3Config{
+			Endpoint:  config.ArchiveS3Endpoint,
+			Region:    config.ArchiveS3Region,
+			Bucket:    config.ArchiveS3Bucket,
+			AccessKey: config.ArchiveS3AccessKey,
+			SecretKey: config.ArchiveS3SecretKey,
+		})
+	default:
+		return nil, fmt.Errorf("unknown archive_store %q", config.ArchiveStore)
+	}
+}
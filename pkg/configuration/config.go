@@ -38,11 +38,14 @@ type Config struct {
 	KafkaConsumerGroup         string `json:"kafka_consumer_group"`
 	ProcessDeploymentDoneTopic string `json:"process_deployment_done_topic"`
 	DeviceGroupTopic           string `json:"device_group_topic"`
+	PermissionChangeTopic      string `json:"permission_change_topic"` //permissions-v2 topic of the hubs kind; every message invalidates the cached permission checks of its id; empty or '-' disables the invalidation
 
 	AuthExpirationTimeBuffer float64 `json:"auth_expiration_time_buffer"`
 	AuthEndpoint             string  `json:"auth_endpoint"`
 	AuthClientId             string  `json:"auth_client_id" config:"secret"`
 	AuthClientSecret         string  `json:"auth_client_secret" config:"secret"`
+	AuthJwksUrl              string  `json:"auth_jwks_url"`            //keys to validate user tokens; empty: {auth_endpoint}/auth/realms/master/protocol/openid-connect/certs; '-' disables the signature validation
+	AuthJwksCacheDuration    string  `json:"auth_jwks_cache_duration"` //keys are refetched after this duration or if a token references an unknown key; default 1h
 
 	MongoUrl string `json:"mongo_url"`
 
@@ -57,7 +60,15 @@ type Config struct {
 	MongoIncidentCollection           string `json:"mongo_incident_collection"`
 	MongoProcessInstanceCollection    string `json:"mongo_process_instance_collection"`
 	MongoLastNetworkContactCollection string `json:"mongo_last_network_contact_collection"`
+	MongoScheduleCollection           string `json:"mongo_schedule_collection"`
+	MongoLeaseCollection              string `json:"mongo_lease_collection"`
+	MongoWardenDecisionCollection     string `json:"mongo_warden_decision_collection"`
+	MongoAuditCollection              string `json:"mongo_audit_collection"`
+	MongoIncidentGroupCollection      string `json:"mongo_incident_group_collection"`
+	MongoWardenCheckCollection        string `json:"mongo_warden_check_collection"`
 	PermissionsV2Url                  string `json:"permissions_v2_url"`
+	PermissionCacheDuration           string `json:"permission_cache_duration"`          //permission checks are cached for this duration; empty or '-' disables the cache, which is also disabled without token validation (auth_jwks_url)
+	PermissionCacheNegativeDuration   string `json:"permission_cache_negative_duration"` //denied permission checks are cached for this duration; empty or '-' disables negative caching
 	DeviceRepoUrl                     string `json:"device_repo_url"`
 	AnalyticsEnvelopePrefix           string `json:"analytics_envelope_prefix"`
 
@@ -67,6 +78,7 @@ type Config struct {
 	ApiDocsProviderBaseUrl string `json:"api_docs_provider_base_url"`
 
 	DeveloperNotificationUrl string `json:"developer_notification_url"`
+	NotificationConfigFile   string `json:"notification_config_file"` //optional json file with notification channels and routing rules; empty sends all notifications to developer_notification_url
 
 	InitTopics bool `json:"init_topics"`
 
@@ -74,6 +86,12 @@ type Config struct {
 	MqttGroupId      string       `json:"mqtt_group_id"` //optional
 	MqttCleanSession bool         `json:"mqtt_clean_session"`
 
+	MqttSigningKeyFile    string `json:"mqtt_signing_key_file"`   //PKCS#8 PEM Ed25519 key to sign commands; empty or '-' sends plain commands
+	MqttSigningKeyId      string `json:"mqtt_signing_key_id"`     //optional; defaults to a hash of the public key
+	MqttNetworkKeyFile    string `json:"mqtt_network_key_file"`   //optional json file with encryption_key and public_key per network id; reloaded on change
+	MqttStateVerification string `json:"mqtt_state_verification"` //off, optional (verify signed state messages, accept plain ones) or required
+	MqttEnvelopeMaxAge    string `json:"mqtt_envelope_max_age"`   //accepted clock difference of signed state messages
+
 	LogLevel             string       `json:"log_level"`
 	LoggerTrimFormat     string       `json:"logger_trim_format"`
 	LoggerTrimAttributes string       `json:"logger_trim_attributes"`
@@ -85,14 +103,85 @@ type Config struct {
 	RunWardenProcessLoop    bool   `json:"run_warden_process_loop"`
 	RunWardenDeploymentLoop bool   `json:"run_warden_deployment_loop"`
 
+	WardenLeaderElection bool   `json:"warden_leader_election"` //if true, each warden loop runs only on the replica holding the loops lease
+	WardenLeaseDuration  string `json:"warden_lease_duration"`  //must be longer than warden_interval; the lease is renewed on every interval
+
+	WardenEventChecks      bool   `json:"warden_event_checks"`       //if true, synced changes of process instances, histories and incidents trigger debounced warden checks of the affected business key
+	WardenFullScanInterval string `json:"warden_full_scan_interval"` //optional; interval of the full db and process loops (safety net for missed events); empty or '-' runs them every warden_interval
+
+	WardenDryRun            bool   `json:"warden_dry_run"`              //if true, the warden only logs its decisions without starting, stopping or redeploying anything
+	WardenDecisionLogMaxAge string `json:"warden_decision_log_max_age"` //decisions are removed after this duration; empty or '-' keeps them until the network is cleaned up
+
+	MetricsPort string `json:"metrics_port"` //empty or '-' disables the metrics endpoint
+
+	IncidentCommandTimeout string `json:"incident_command_timeout"` //a pending incident command (retry, resolve, restart) blocks further commands for this duration; default 10m
+
+	AuditLogMaxAge string `json:"audit_log_max_age"` //audit entries are removed after this duration; empty or '-' keeps them forever
+
+	NetworkOfflineAfter    string `json:"network_offline_after"`    //networks without contact for this duration are reported as offline; default 30m
+	NetworkPlaceholderWait string `json:"network_placeholder_wait"` //placeholders waiting longer for the mgw degrade the network health; default 10m; may be overwritten per request
+
+	TracingOtlpEndpoint string  `json:"tracing_otlp_endpoint"` //otlp/http endpoint url (e.g. http://otel-collector:4318); empty or '-' disables the export of traces
+	TracingServiceName  string  `json:"tracing_service_name"`
+	TracingSampleRatio  float64 `json:"tracing_sample_ratio"` //ratio of traces started by this service which are sampled; incoming trace contexts decide for themselves
+
+	Retention         []RetentionPolicy `json:"retention"`          //env RETENTION as json list
+	RetentionInterval string            `json:"retention_interval"` //interval of the retention job; default 1h; '-' disables the job
+
+	ArchiveStore       string `json:"archive_store"` //local or s3; empty or '-' removes history and incidents without archive
+	ArchiveLocalDir    string `json:"archive_local_dir"`
+	ArchiveS3Endpoint  string `json:"archive_s3_endpoint"` //e.g. https://s3.example.com; objects are addressed path style
+	ArchiveS3Region    string `json:"archive_s3_region"`
+	ArchiveS3Bucket    string `json:"archive_s3_bucket"`
+	ArchiveS3AccessKey string `json:"archive_s3_access_key" config:"secret"`
+	ArchiveS3SecretKey string `json:"archive_s3_secret_key" config:"secret"`
+
+	OperationRights           map[string]string `json:"operation_rights"`            //permissions-v2 rights on the network per operation; operations without entry use their default; env OPERATION_RIGHTS as 'read:r,start:x'
+	DeploymentOwnerProtection bool              `json:"deployment_owner_protection"` //if true, only the user who deployed a process and users with the admin operation rights on the network may start, stop, change or delete it
+
 	RunWardenMigration bool `json:"run_warden_migration"`
+
+	RunScheduler                bool   `json:"run_scheduler"`
+	SchedulerInterval           string `json:"scheduler_interval"`
+	SchedulerLockDuration       string `json:"scheduler_lock_duration"`
+	SchedulerMissedRunTolerance string `json:"scheduler_missed_run_tolerance"`
 }
 
+// MqttConfig describes a broker; TLS options apply to brokers with ssl://, tls:// or mqtts:// urls
 type MqttConfig struct {
 	Broker   string `json:"broker"`
 	ClientId string `json:"client_id" config:"secret"`
 	User     string `json:"user" config:"secret"`
 	Pw       string `json:"pw" config:"secret"`
+
+	CaFile             string `json:"ca_file,omitempty"`              //optional PEM bundle to verify the broker certificate; empty uses the system pool
+	CertFile           string `json:"cert_file,omitempty"`            //optional PEM client certificate for mutual TLS; requires key_file
+	KeyFile            string `json:"key_file,omitempty"`             //PEM private key of cert_file
+	ServerName         string `json:"server_name,omitempty"`          //optional name to verify the broker certificate against; empty uses the host of the broker url
+	InsecureSkipVerify bool   `json:"insecure_skip_verify,omitempty"` //disables the verification of the broker certificate
+}
+
+const (
+	RetentionEntityHistory   = "history"   //finished historic process instances by end time
+	RetentionEntityIncidents = "incidents" //stored incidents by incident time; incidents of running process instances are kept
+)
+
+// operations with configurable rights (OperationRights); default rights in brackets
+const (
+	OperationRead   = "read"   //read and export (r)
+	OperationStart  = "start"  //start processes, manage schedules, incident commands, warden checks (x)
+	OperationStop   = "stop"   //stop process instances (x)
+	OperationDeploy = "deploy" //create, update and sync deployments (w)
+	OperationDelete = "delete" //delete deployments, history, incidents and networks (a)
+	OperationAdmin  = "admin"  //audit log, network import, warden and incident group management, override of the deployment owner protection (a)
+)
+
+// RetentionPolicy removes entities older than MaxAge; policies with NetworkIds replace the policy without NetworkIds of the same entity for these networks
+type RetentionPolicy struct {
+	Entity       string   `json:"entity"` //history or incidents
+	MaxAge       string   `json:"max_age"`
+	NetworkIds   []string `json:"network_ids"`
+	DeleteOnEdge bool     `json:"delete_on_edge"` //history only: sends a delete command for each expired instance to the mgw, which removes it on both sides
 }
 
 // loads config from json in location and used environment variables (e.g ZookeeperUrl --> ZOOKEEPER_URL)
@@ -110,53 +199,43 @@ func Load(location string) (config Config, err error) {
 	return config, nil
 }
 
+// mqttEnvFields sets the MqttConfig fields from MQTT_{FIELD} and MQTT_{FIELD}_{key} environment variables
+var mqttEnvFields = []struct {
+	name string
+	set  func(conf *MqttConfig, value string)
+}{
+	{name: "MQTT_BROKER", set: func(conf *MqttConfig, value string) { conf.Broker = value }},
+	{name: "MQTT_PW", set: func(conf *MqttConfig, value string) { conf.Pw = value }},
+	{name: "MQTT_USER", set: func(conf *MqttConfig, value string) { conf.User = value }},
+	{name: "MQTT_CLIENT_ID", set: func(conf *MqttConfig, value string) { conf.ClientId = value }},
+	{name: "MQTT_CA_FILE", set: func(conf *MqttConfig, value string) { conf.CaFile = value }},
+	{name: "MQTT_CERT_FILE", set: func(conf *MqttConfig, value string) { conf.CertFile = value }},
+	{name: "MQTT_KEY_FILE", set: func(conf *MqttConfig, value string) { conf.KeyFile = value }},
+	{name: "MQTT_SERVER_NAME", set: func(conf *MqttConfig, value string) { conf.ServerName = value }},
+	{name: "MQTT_INSECURE_SKIP_VERIFY", set: func(conf *MqttConfig, value string) {
+		conf.InsecureSkipVerify, _ = strconv.ParseBool(value)
+	}},
+}
+
 func handleMqttConfig(config *Config) {
 	m := map[string]MqttConfig{}
 
 	for _, env := range os.Environ() {
 		parts := strings.Split(env, "=")
 		if len(parts) == 2 {
-			switch {
-			case parts[0] == "MQTT_BROKER":
-				key := ""
-				conf := m[key]
-				conf.Broker = parts[1]
-				m[key] = conf
-			case parts[0] == "MQTT_PW":
-				key := ""
-				conf := m[key]
-				conf.Pw = parts[1]
-				m[key] = conf
-			case parts[0] == "MQTT_USER":
-				key := ""
-				conf := m[key]
-				conf.User = parts[1]
-				m[key] = conf
-			case parts[0] == "MQTT_CLIENT_ID":
+			for _, field := range mqttEnvFields {
 				key := ""
+				if parts[0] != field.name {
+					suffix, ok := strings.CutPrefix(parts[0], field.name+"_")
+					if !ok {
+						continue
+					}
+					key = strings.ToLower(suffix)
+				}
 				conf := m[key]
-				conf.ClientId = parts[1]
-				m[key] = conf
-			case strings.HasPrefix(parts[0], "MQTT_BROKER_"):
-				key := strings.ToLower(strings.TrimPrefix(parts[0], "MQTT_BROKER_"))
-				conf := m[key]
-				conf.Broker = parts[1]
-				m[key] = conf
-			case strings.HasPrefix(parts[0], "MQTT_PW_"):
-				key := strings.ToLower(strings.TrimPrefix(parts[0], "MQTT_PW_"))
-				conf := m[key]
-				conf.Pw = parts[1]
-				m[key] = conf
-			case strings.HasPrefix(parts[0], "MQTT_USER_"):
-				key := strings.ToLower(strings.TrimPrefix(parts[0], "MQTT_USER_"))
-				conf := m[key]
-				conf.User = parts[1]
-				m[key] = conf
-			case strings.HasPrefix(parts[0], "MQTT_CLIENT_ID_"):
-				key := strings.ToLower(strings.TrimPrefix(parts[0], "MQTT_CLIENT_ID_"))
-				conf := m[key]
-				conf.ClientId = parts[1]
+				field.set(&conf, parts[1])
 				m[key] = conf
+				break
 			}
 		}
 	}
@@ -211,12 +290,21 @@ func handleEnvironmentVars(config *Config) {
 				f, _ := strconv.ParseFloat(envValue, 64)
 				configValue.FieldByName(fieldName).SetFloat(f)
 			}
-			if configValue.FieldByName(fieldName).Kind() == reflect.Slice {
+			if configValue.FieldByName(fieldName).Kind() == reflect.Slice && configValue.FieldByName(fieldName).Type().Elem().Kind() == reflect.String {
 				val := []string{}
 				for _, element := range strings.Split(envValue, ",") {
 					val = append(val, strings.TrimSpace(element))
 				}
 				configValue.FieldByName(fieldName).Set(reflect.ValueOf(val))
+			} else if configValue.FieldByName(fieldName).Kind() == reflect.Slice {
+				//lists of structs are expected as json
+				val := reflect.New(configValue.FieldByName(fieldName).Type())
+				err := json.Unmarshal([]byte(envValue), val.Interface())
+				if err != nil {
+					fmt.Println("ERROR: invalid json in environment variable:", envName, err)
+				} else {
+					configValue.FieldByName(fieldName).Set(val.Elem())
+				}
 			}
 			if configValue.FieldByName(fieldName).Kind() == reflect.Map {
 				value := map[string]string{}
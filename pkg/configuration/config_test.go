@@ -135,4 +135,54 @@ func TestMqttConfig(t *testing.T) {
 			return
 		}
 	})
+
+	t.Run("tls env", func(t *testing.T) {
+		t.Setenv("MQTT_BROKER", "ssl://localhost:8883")
+		t.Setenv("MQTT_CA_FILE", "/certs/ca.pem")
+		t.Setenv("MQTT_SERVER_NAME", "broker.example.com")
+
+		t.Setenv("MQTT_BROKER_2", "ssl://localhost:8884")
+		t.Setenv("MQTT_CERT_FILE_2", "/certs/client.pem")
+		t.Setenv("MQTT_KEY_FILE_2", "/certs/client-key.pem")
+		t.Setenv("MQTT_INSECURE_SKIP_VERIFY_2", "true")
+		defaultConfig, err := Load("../../config.json")
+		if err != nil {
+			t.Error(err)
+		}
+		slices.SortFunc(defaultConfig.Mqtt, func(a, b MqttConfig) int {
+			return strings.Compare(a.Broker, b.Broker)
+		})
+		if !reflect.DeepEqual(defaultConfig.Mqtt, []MqttConfig{
+			{
+				Broker:     "ssl://localhost:8883",
+				CaFile:     "/certs/ca.pem",
+				ServerName: "broker.example.com",
+			},
+			{
+				Broker:             "ssl://localhost:8884",
+				CertFile:           "/certs/client.pem",
+				KeyFile:            "/certs/client-key.pem",
+				InsecureSkipVerify: true,
+			},
+		}) {
+			t.Error("unexpected mqtt config", defaultConfig.Mqtt)
+			return
+		}
+	})
+}
+
+func TestRetentionEnv(t *testing.T) {
+	t.Setenv("RETENTION", `[{"entity":"history","max_age":"2160h","delete_on_edge":true},{"entity":"incidents","max_age":"720h","network_ids":["n1"]}]`)
+	config, err := Load("../../config.json")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	expected := []RetentionPolicy{
+		{Entity: RetentionEntityHistory, MaxAge: "2160h", DeleteOnEdge: true},
+		{Entity: RetentionEntityIncidents, MaxAge: "720h", NetworkIds: []string{"n1"}},
+	}
+	if !reflect.DeepEqual(config.Retention, expected) {
+		t.Errorf("%#v", config.Retention)
+	}
 }
@@ -19,38 +19,65 @@ package controller
 import (
 	"errors"
 	"net/http"
+
+	"github.com/SENERGY-Platform/process-sync/pkg/security"
 )
 
-func (this *Controller) ApiCheckAccess(request *http.Request, networkId string, rights string) (err error, errCode int) {
-	token := request.Header.Get("Authorization")
-	allowed, err := this.security.CheckBool(token, "hubs", networkId, rights)
+// ApiVerifyToken returns security.ErrInvalidToken if the signature of the token (Authorization header value) is invalid or the token is expired
+func (this *Controller) ApiVerifyToken(token string) (security.Claims, error) {
+	return this.security.VerifyToken(token)
+}
+
+func accessErrCode(err error) int {
+	if errors.Is(err, security.ErrInvalidToken) {
+		return http.StatusUnauthorized
+	}
+	return http.StatusInternalServerError
+}
+
+// ApiCheckAccess checks the configured rights of the operation (e.g. configuration.OperationRead) on the network
+func (this *Controller) ApiCheckAccess(request *http.Request, networkId string, operation string) (err error, errCode int) {
+	rights, err := this.rightsOfOperation(operation)
 	if err != nil {
 		return err, http.StatusInternalServerError
 	}
+	token := request.Header.Get("Authorization")
+	allowed, err := this.security.CheckBool(request.Context(), token, "hubs", networkId, rights)
+	if err != nil {
+		return err, accessErrCode(err)
+	}
 	if !allowed {
 		return errors.New("not allowed"), http.StatusForbidden
 	}
 	return nil, http.StatusOK
 }
 
-func (this *Controller) ApiCheckAccessReturnToken(request *http.Request, networkId string, rights string) (token string, err error, errCode int) {
+func (this *Controller) ApiCheckAccessReturnToken(request *http.Request, networkId string, operation string) (token string, err error, errCode int) {
 	token = request.Header.Get("Authorization")
-	allowed, err := this.security.CheckBool(token, "hubs", networkId, rights)
+	rights, err := this.rightsOfOperation(operation)
 	if err != nil {
 		return token, err, http.StatusInternalServerError
 	}
+	allowed, err := this.security.CheckBool(request.Context(), token, "hubs", networkId, rights)
+	if err != nil {
+		return token, err, accessErrCode(err)
+	}
 	if !allowed {
 		return token, errors.New("not allowed"), http.StatusForbidden
 	}
 	return token, nil, http.StatusOK
 }
 
-func (this *Controller) ApiCheckAccessMultiple(request *http.Request, networkIds []string, rights string) (err error, errCode int) {
-	token := request.Header.Get("Authorization")
-	allowed, err := this.security.CheckMultiple(token, "hubs", networkIds, rights)
+func (this *Controller) ApiCheckAccessMultiple(request *http.Request, networkIds []string, operation string) (err error, errCode int) {
+	rights, err := this.rightsOfOperation(operation)
 	if err != nil {
 		return err, http.StatusInternalServerError
 	}
+	token := request.Header.Get("Authorization")
+	allowed, err := this.security.CheckMultiple(request.Context(), token, "hubs", networkIds, rights)
+	if err != nil {
+		return err, accessErrCode(err)
+	}
 	for _, id := range networkIds {
 		if !allowed[id] {
 			return errors.New("not allowed"), http.StatusForbidden
@@ -58,3 +85,10 @@ func (this *Controller) ApiCheckAccessMultiple(request *http.Request, networkIds
 	}
 	return nil, http.StatusOK
 }
+
+func (this *Controller) ApiCheckAdmin(request *http.Request) (err error, errCode int) {
+	if !this.security.IsAdmin(request.Context(), request.Header.Get("Authorization")) {
+		return errors.New("only admins are allowed"), http.StatusForbidden
+	}
+	return nil, http.StatusOK
+}
@@ -0,0 +1,107 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/SENERGY-Platform/process-sync/pkg/model"
+)
+
+// archiveAndRemoveHistory archives and removes the historic process instances of the scope in batches,
+// so that a failing archive store leaves only archived instances removed; a canceled ctx stops before the next document
+func (this *Controller) archiveAndRemoveHistory(ctx context.Context, scope model.RetentionScope) (count int64, err error) {
+	for {
+		if ctx.Err() != nil {
+			return count, context.Cause(ctx)
+		}
+		batch, err := this.db.FindHistoricProcessInstancesInScope(scope, retentionBatchSize)
+		if err != nil {
+			return count, err
+		}
+		if len(batch) == 0 {
+			return count, nil
+		}
+		err = this.archive.ArchiveHistory(ctx, batch)
+		if err != nil {
+			return count, err
+		}
+		for _, instance := range batch {
+			if ctx.Err() != nil {
+				return count, context.Cause(ctx)
+			}
+			err = this.db.RemoveHistoricProcessInstance(instance.NetworkId, instance.Id)
+			if err != nil {
+				return count, err
+			}
+			count++
+		}
+	}
+}
+
+// archiveAndRemoveIncidents archives and removes the incidents of the scope in batches
+func (this *Controller) archiveAndRemoveIncidents(ctx context.Context, scope model.RetentionScope) (count int64, err error) {
+	for {
+		if ctx.Err() != nil {
+			return count, context.Cause(ctx)
+		}
+		batch, err := this.db.FindIncidentsInScope(scope, retentionBatchSize)
+		if err != nil {
+			return count, err
+		}
+		if len(batch) == 0 {
+			return count, nil
+		}
+		err = this.archive.ArchiveIncidents(ctx, batch)
+		if err != nil {
+			return count, err
+		}
+		for _, incident := range batch {
+			if ctx.Err() != nil {
+				return count, context.Cause(ctx)
+			}
+			err = this.db.RemoveIncident(incident.NetworkId, incident.Id)
+			if err != nil {
+				return count, err
+			}
+			count++
+		}
+	}
+}
+
+// archiveOldNetworks archives the history and incidents of networks, which will be removed by RemoveOldElements
+func (this *Controller) archiveOldNetworks(ctx context.Context, maxAge time.Duration) error {
+	networkIds, err := this.db.GetOldNetworkIds(maxAge)
+	if err != nil {
+		return err
+	}
+	if len(networkIds) == 0 {
+		return nil
+	}
+	scope := model.RetentionScope{NetworkIds: networkIds}
+	history, err := this.archiveAndRemoveHistory(ctx, scope)
+	if err != nil {
+		return err
+	}
+	incidents, err := this.archiveAndRemoveIncidents(ctx, scope)
+	if err != nil {
+		return err
+	}
+	this.logger.Info("archived elements of old networks", "network_ids", networkIds, "history", history, "incidents", incidents)
+	return nil
+}
@@ -0,0 +1,51 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"net/http"
+	"runtime/debug"
+
+	"github.com/SENERGY-Platform/process-sync/pkg/configuration"
+	"github.com/SENERGY-Platform/process-sync/pkg/model"
+)
+
+// LogAudit stores the entry; failures are only logged, because the audited call has already been handled
+func (this *Controller) LogAudit(entry model.AuditEntry) {
+	entry.Id = configuration.Id()
+	entry.Time = configuration.TimeNow()
+	if this.auditLogMaxAge > 0 {
+		expiresAt := entry.Time.Add(this.auditLogMaxAge)
+		entry.ExpiresAt = &expiresAt
+	}
+	this.logger.Info("audit", "user", entry.UserId, "network", entry.NetworkId, "action", entry.Action, "entity_id", entry.EntityId, "status", entry.StatusCode)
+	err := this.db.SaveAuditEntry(entry)
+	if err != nil {
+		this.logger.Error("unable to save audit entry", "error", err, "stack", debug.Stack())
+	}
+}
+
+func (this *Controller) ApiListAuditEntries(query model.AuditQuery) (result []model.AuditEntry, err error, errCode int) {
+	result, err = this.db.ListAuditEntries(query)
+	if err != nil {
+		return result, err, http.StatusInternalServerError
+	}
+	if result == nil {
+		result = []model.AuditEntry{}
+	}
+	return result, nil, http.StatusOK
+}
@@ -0,0 +1,54 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import "context"
+
+// runBackfills derives fields of documents stored by older versions of this service;
+// the backfills run under the BackfillLease, so that only one replica updates the documents, while the others skip them
+func (this *Controller) runBackfills(ctx context.Context) {
+	backfills := []struct {
+		name string
+		run  func() (int, error)
+	}{
+		{name: "incident group info", run: this.db.BackfillIncidentGroupInfo},
+		{name: "historic process instance dates", run: this.db.BackfillHistoricProcessInstanceDates},
+	}
+	ran, err := this.runLeased(ctx, BackfillLease, func(ctx context.Context) error {
+		for _, backfill := range backfills {
+			if ctx.Err() != nil {
+				return context.Cause(ctx)
+			}
+			count, err := backfill.run()
+			if err != nil {
+				this.logger.Error("unable to backfill "+backfill.name, "error", err)
+				continue
+			}
+			if count > 0 {
+				this.logger.Info("backfilled "+backfill.name, "count", count)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		this.logger.Error("unable to run backfills", "error", err)
+		return
+	}
+	if !ran {
+		this.logger.Info("backfills skipped, another replica holds the backfill lease")
+	}
+}
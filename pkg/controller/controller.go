@@ -25,19 +25,23 @@ import (
 	"slices"
 	"time"
 
-	developerNotifications "github.com/SENERGY-Platform/developer-notifications/pkg/client"
 	eventinterfaces "github.com/SENERGY-Platform/event-deployment/lib/interfaces"
 	"github.com/SENERGY-Platform/event-deployment/lib/model"
 	"github.com/SENERGY-Platform/models/go/models"
 	"github.com/SENERGY-Platform/process-deployment/lib/auth"
 	"github.com/SENERGY-Platform/process-deployment/lib/interfaces"
 	"github.com/SENERGY-Platform/process-deployment/lib/model/devicemodel"
+	"github.com/SENERGY-Platform/process-sync/pkg/archive"
 	"github.com/SENERGY-Platform/process-sync/pkg/configuration"
 	"github.com/SENERGY-Platform/process-sync/pkg/database"
 	"github.com/SENERGY-Platform/process-sync/pkg/database/mongo"
 	"github.com/SENERGY-Platform/process-sync/pkg/devices"
 	"github.com/SENERGY-Platform/process-sync/pkg/kafka"
+	"github.com/SENERGY-Platform/process-sync/pkg/lease"
+	"github.com/SENERGY-Platform/process-sync/pkg/metrics"
 	"github.com/SENERGY-Platform/process-sync/pkg/mgw"
+	"github.com/SENERGY-Platform/process-sync/pkg/notifier"
+	"github.com/SENERGY-Platform/process-sync/pkg/scheduler"
 	"github.com/SENERGY-Platform/process-sync/pkg/security"
 	"github.com/SENERGY-Platform/process-sync/pkg/warden"
 
@@ -52,9 +56,18 @@ type Controller struct {
 	baseDeviceRepoFactory  BaseDeviceRepoFactory
 	devicerepo             Devices
 	deploymentDoneNotifier interfaces.Producer
-	devNotifications       developerNotifications.Client
+	notifier               *notifier.Router
 	logger                 *slog.Logger
 	warden                 warden.Warden
+	metrics                *metrics.Metrics
+	networkOfflineAfter    time.Duration
+	networkPlaceholderWait time.Duration
+	auditLogMaxAge         time.Duration
+	incidentCommandTimeout time.Duration
+	retentionPolicies      []retentionPolicy
+	archive                *archive.Archiver //nil if archiving is disabled
+	operationRights        map[string]string
+	jobLease               *lease.Elector
 }
 
 type BaseDeviceRepoFactory = func(token string, deviceRepoUrl string) eventinterfaces.Devices
@@ -73,19 +86,28 @@ type Devices interface {
 
 type Security interface {
 	GetAdminToken() (token string, err error)
-	CheckBool(token string, kind string, id string, rights string) (allowed bool, err error)
-	CheckMultiple(token string, kind string, ids []string, rights string) (result map[string]bool, err error)
+	VerifyToken(token string) (claims security.Claims, err error)
+	IsAdmin(ctx context.Context, token string) bool
+	CheckBool(ctx context.Context, token string, kind string, id string, rights string) (allowed bool, err error)
+	CheckMultiple(ctx context.Context, token string, kind string, ids []string, rights string) (result map[string]bool, err error)
+	InvalidatePermissions(id string)
 }
 
 func NewDefault(conf configuration.Config, ctx context.Context) (ctrl *Controller, err error) {
-	db, err := mongo.New(conf)
+	m := metrics.New()
+	db, err := mongo.NewWithMetrics(conf, m)
 	if err != nil {
 		return ctrl, err
 	}
-	return New(conf, ctx, db, security.New(conf), devices.DefaultBaseDeviceRepoFactory, devices.DefaultDeviceProvider)
+	return NewWithMetrics(conf, ctx, db, m, security.NewWithMetrics(conf, m), devices.DefaultBaseDeviceRepoFactory, devices.DefaultDeviceProvider)
 }
 
 func New(config configuration.Config, ctx context.Context, db database.Database, security Security, baseDeviceRepoFactory BaseDeviceRepoFactory, deviceProvider DeviceProvider) (ctrl *Controller, err error) {
+	return NewWithMetrics(config, ctx, db, metrics.New(), security, baseDeviceRepoFactory, deviceProvider)
+}
+
+// NewWithMetrics serves m on config.MetricsPort (if set) and records the metrics of the controller, warden, mgw and kafka consumers in m
+func NewWithMetrics(config configuration.Config, ctx context.Context, db database.Database, m *metrics.Metrics, security Security, baseDeviceRepoFactory BaseDeviceRepoFactory, deviceProvider DeviceProvider) (ctrl *Controller, err error) {
 	d, err := devices.New(config, baseDeviceRepoFactory, deviceProvider)
 	if err != nil {
 		return ctrl, err
@@ -104,15 +126,77 @@ func New(config configuration.Config, ctx context.Context, db database.Database,
 		return ctrl, err
 	}
 
-	ctrl = &Controller{config: config, db: db, security: security, baseDeviceRepoFactory: baseDeviceRepoFactory, devicerepo: d, logger: logger}
-	w, err := warden.New(warden.Config{
+	ctrl = &Controller{config: config, db: db, security: security, baseDeviceRepoFactory: baseDeviceRepoFactory, devicerepo: d, logger: logger, metrics: m, jobLease: lease.New(db, jobLeaseDuration)}
+	ctrl.networkOfflineAfter, ctrl.networkPlaceholderWait, err = parseNetworkStatusConfig(config)
+	if err != nil {
+		return ctrl, err
+	}
+	ctrl.incidentCommandTimeout = defaultIncidentCommandTimeout
+	if config.IncidentCommandTimeout != "" {
+		ctrl.incidentCommandTimeout, err = time.ParseDuration(config.IncidentCommandTimeout)
+		if err != nil {
+			return ctrl, err
+		}
+	}
+	ctrl.retentionPolicies, err = parseRetentionPolicies(config.Retention)
+	if err != nil {
+		return ctrl, err
+	}
+	ctrl.operationRights, err = parseOperationRights(config.OperationRights)
+	if err != nil {
+		return ctrl, err
+	}
+	archiveStore, err := archive.NewStore(config)
+	if err != nil {
+		return ctrl, err
+	}
+	if archiveStore != nil {
+		ctrl.archive = archive.New(archiveStore)
+	}
+	if config.AuditLogMaxAge != "" && config.AuditLogMaxAge != "-" {
+		ctrl.auditLogMaxAge, err = time.ParseDuration(config.AuditLogMaxAge)
+		if err != nil {
+			return ctrl, err
+		}
+	}
+	m.RegisterNetworkContacts(ctrl.lastNetworkContacts, config.GetLogger())
+	if config.MetricsPort != "" && config.MetricsPort != "-" {
+		m.Start(ctx, config.MetricsPort, config.GetLogger())
+	}
+	wardenConfig := warden.Config{
 		Interval:          wardenInterval,
 		AgeGate:           wardenAgeGate,
 		RunDbLoop:         config.RunWardenDbLoop,
 		RunProcessLoop:    config.RunWardenProcessLoop,
 		RunDeploymentLoop: config.RunWardenDeploymentLoop,
 		Logger:            config.GetLogger(),
-	}, ctrl, db)
+		DryRun:            config.WardenDryRun,
+		EventChecks:       config.WardenEventChecks,
+		Metrics:           ctrl.metrics,
+	}
+	if config.WardenFullScanInterval != "" && config.WardenFullScanInterval != "-" {
+		wardenConfig.FullScanInterval, err = time.ParseDuration(config.WardenFullScanInterval)
+		if err != nil {
+			return ctrl, err
+		}
+	}
+	if config.WardenDecisionLogMaxAge != "" && config.WardenDecisionLogMaxAge != "-" {
+		wardenConfig.DecisionLogMaxAge, err = time.ParseDuration(config.WardenDecisionLogMaxAge)
+		if err != nil {
+			return ctrl, err
+		}
+	}
+	if config.WardenLeaderElection {
+		leaseDuration, err := time.ParseDuration(config.WardenLeaseDuration)
+		if err != nil {
+			return ctrl, err
+		}
+		if leaseDuration <= wardenInterval {
+			return ctrl, errors.New("warden_lease_duration must be longer than warden_interval")
+		}
+		wardenConfig.Lease = lease.New(db, leaseDuration)
+	}
+	w, err := warden.New(wardenConfig, ctrl, db)
 	if err != nil {
 		return ctrl, err
 	}
@@ -123,10 +207,18 @@ func New(config configuration.Config, ctx context.Context, db database.Database,
 			return ctrl, err
 		}
 	}
-	if config.DeveloperNotificationUrl != "" && config.DeveloperNotificationUrl != "-" {
-		ctrl.devNotifications = developerNotifications.New(config.DeveloperNotificationUrl)
+	notificationConfig := notifier.DefaultConfig(config)
+	if config.NotificationConfigFile != "" && config.NotificationConfigFile != "-" {
+		notificationConfig, err = notifier.LoadConfig(config.NotificationConfigFile)
+		if err != nil {
+			return ctrl, err
+		}
 	}
-	ctrl.mgw, err = mgw.New(config, ctx, ctrl)
+	ctrl.notifier, err = notifier.New(ctx, notificationConfig, notifier.NewFactory(config), config.GetLogger(), m)
+	if err != nil {
+		return ctrl, err
+	}
+	ctrl.mgw, err = mgw.New(config, ctx, ctrl, m)
 	if err != nil {
 		return ctrl, err
 	}
@@ -134,12 +226,25 @@ func New(config configuration.Config, ctx context.Context, db database.Database,
 	if err != nil {
 		return ctrl, err
 	}
+	err = ctrl.initPermissionChangeWatcher(ctx)
+	if err != nil {
+		return ctrl, err
+	}
 
 	err = w.Start(ctx)
 	if err != nil {
 		return ctrl, err
 	}
 
+	if config.RunScheduler {
+		err = ctrl.startScheduler(ctx)
+		if err != nil {
+			return ctrl, err
+		}
+	}
+
+	go ctrl.runBackfills(ctx)
+
 	if config.RunWardenMigration {
 		err = ctrl.MigrateToWarden()
 		if err != nil {
@@ -150,12 +255,53 @@ func New(config configuration.Config, ctx context.Context, db database.Database,
 	return ctrl, nil
 }
 
+func (this *Controller) Metrics() *metrics.Metrics {
+	return this.metrics
+}
+
+func (this *Controller) startScheduler(ctx context.Context) error {
+	interval, err := time.ParseDuration(this.config.SchedulerInterval)
+	if err != nil {
+		return err
+	}
+	lockDuration, err := time.ParseDuration(this.config.SchedulerLockDuration)
+	if err != nil {
+		return err
+	}
+	missedRunTolerance, err := time.ParseDuration(this.config.SchedulerMissedRunTolerance)
+	if err != nil {
+		return err
+	}
+	return scheduler.New(scheduler.Config{
+		Interval:           interval,
+		LockDuration:       lockDuration,
+		MissedRunTolerance: missedRunTolerance,
+		Logger:             this.config.GetLogger(),
+	}, this, this.db).Start(ctx)
+}
+
 var IsPlaceholderProcessErr = errors.New("is placeholder process")
 var IsMarkedForDeleteErr = errors.New("is market for deletion")
+var IncidentCommandPendingErr = errors.New("incident command pending")
+var TooManyIncidentStatsBucketsErr = errors.New("too many incident stats buckets; increase the interval or reduce the time range")
+var UnknownIncidentCommandErr = errors.New("unknown incident command")
 var HistoryMayOnlyDeletedIfFinishedOrPlaceholderErr = errors.New("history may only deleted if the process instance is finished or the element is a placeholder")
 var IsMarkedAsMissingErr = errors.New("is market as missing (you may try to redeploy)")
+var InvalidNetworkImportErr = errors.New("invalid network import")
 
 func (this *Controller) SetErrCode(err error) int {
+	if errors.Is(err, model2.ErrInvalidStartParameter) {
+		return http.StatusBadRequest
+	}
+	if errors.Is(err, scheduler.ErrInvalidSchedule) {
+		return http.StatusBadRequest
+	}
+	if errors.Is(err, model2.ErrInvalidRestartPolicy) {
+		return http.StatusBadRequest
+	}
+	if errors.Is(err, InvalidNetworkImportErr) {
+		return http.StatusBadRequest
+	}
 	switch err {
 	case nil:
 		return http.StatusOK
@@ -167,24 +313,32 @@ func (this *Controller) SetErrCode(err error) int {
 		return http.StatusBadRequest
 	case HistoryMayOnlyDeletedIfFinishedOrPlaceholderErr:
 		return http.StatusBadRequest
+	case UnknownIncidentCommandErr:
+		return http.StatusBadRequest
+	case IncidentCommandPendingErr:
+		return http.StatusConflict
+	case TooManyIncidentStatsBucketsErr:
+		return http.StatusBadRequest
+	case warden.ErrNotDbLoopLeaseHolder:
+		return http.StatusServiceUnavailable
 	default:
 		return http.StatusInternalServerError
 	}
 }
 
-func (this *Controller) RemoveOldEntities(maxAge time.Duration) error {
+// RemoveOldEntities removes all elements of networks not seen for maxAge; with configured archive, their history and incidents are archived before
+func (this *Controller) RemoveOldEntities(ctx context.Context, maxAge time.Duration) error {
+	if this.archive != nil {
+		err := this.archiveOldNetworks(ctx, maxAge)
+		if err != nil {
+			return err
+		}
+	}
 	return this.db.RemoveOldElements(maxAge)
 }
 
-func (this *Controller) DeleteProcessInstanceByBusinessKey(networkId string, businessKey string) (err error, code int) {
-	bkList := []string{this.warden.MarkInstanceBusinessKeyAsWardenHandled(businessKey)}
-	if !slices.Contains(bkList, businessKey) {
-		bkList = append(bkList, businessKey)
-	}
-	instances, err := this.db.FindProcessInstances(model2.InstanceQuery{
-		BusinessKeys: bkList,
-		NetworkIds:   []string{networkId},
-	})
+func (this *Controller) DeleteProcessInstanceByBusinessKey(ctx context.Context, networkId string, businessKey string) (err error, code int) {
+	instances, err := this.findProcessInstancesByBusinessKey(networkId, businessKey)
 	if err != nil {
 		return err, this.SetErrCode(err)
 	}
@@ -193,10 +347,22 @@ func (this *Controller) DeleteProcessInstanceByBusinessKey(networkId string, bus
 		if err != nil {
 			return err, this.SetErrCode(err)
 		}
-		err, code = this.StopProcessInstanceWithoutWardenHandling(instance)
+		err, code = this.StopProcessInstanceWithoutWardenHandling(ctx, instance)
 		if err != nil {
 			return err, code
 		}
 	}
 	return nil, http.StatusOK
 }
+
+// findProcessInstancesByBusinessKey includes the instances with the warden handled variant of the business key
+func (this *Controller) findProcessInstancesByBusinessKey(networkId string, businessKey string) ([]model2.ProcessInstance, error) {
+	bkList := []string{this.warden.MarkInstanceBusinessKeyAsWardenHandled(businessKey)}
+	if !slices.Contains(bkList, businessKey) {
+		bkList = append(bkList, businessKey)
+	}
+	return this.db.FindProcessInstances(model2.InstanceQuery{
+		BusinessKeys: bkList,
+		NetworkIds:   []string{networkId},
+	})
+}
@@ -17,6 +17,7 @@
 package controller
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log"
@@ -33,11 +34,14 @@ import (
 	"github.com/SENERGY-Platform/process-sync/pkg/database"
 	"github.com/SENERGY-Platform/process-sync/pkg/model"
 	"github.com/SENERGY-Platform/process-sync/pkg/model/camundamodel"
+	"github.com/SENERGY-Platform/process-sync/pkg/security"
+	"github.com/SENERGY-Platform/process-sync/pkg/tracing"
 	"github.com/SENERGY-Platform/process-sync/pkg/warden"
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
 )
 
-func (this *Controller) UpdateDeployment(networkId string, deployment camundamodel.Deployment) {
+func (this *Controller) UpdateDeployment(ctx context.Context, networkId string, deployment camundamodel.Deployment) {
 	err := this.db.RemovePlaceholderDeployments(networkId)
 	if err != nil {
 		this.config.GetLogger().Error("failed to remove placeholder deployments", "error", err, "stack", debug.Stack())
@@ -56,7 +60,7 @@ func (this *Controller) UpdateDeployment(networkId string, deployment camundamod
 	}
 }
 
-func (this *Controller) DeleteDeployment(networkId string, deploymentId string) {
+func (this *Controller) DeleteDeployment(ctx context.Context, networkId string, deploymentId string) {
 	deployment, err := this.db.ReadDeployment(networkId, deploymentId)
 	if errors.Is(err, database.ErrNotFound) {
 		this.deleteDeployment(networkId, deploymentId)
@@ -100,7 +104,7 @@ func (this *Controller) deleteDeployment(networkId string, deploymentId string)
 	return err
 }
 
-func (this *Controller) DeleteUnknownDeployments(networkId string, knownIds []string) {
+func (this *Controller) DeleteUnknownDeployments(ctx context.Context, networkId string, knownIds []string) {
 	deployments, err := this.db.ListUnknownDeployments(networkId, knownIds)
 	if err != nil {
 		this.config.GetLogger().Error("error", "error", err, "stack", debug.Stack())
@@ -149,7 +153,7 @@ func (this *Controller) ApiReadDeploymentMetadata(networkId string, deploymentId
 	return
 }
 
-func (this *Controller) ApiDeleteDeployment(networkId string, deploymentId string) (err error, errCode int) {
+func (this *Controller) ApiDeleteDeployment(ctx context.Context, networkId string, deploymentId string) (err error, errCode int) {
 	defer func() {
 		errCode = this.SetErrCode(err)
 	}()
@@ -161,6 +165,10 @@ func (this *Controller) ApiDeleteDeployment(networkId string, deploymentId strin
 	if err != nil {
 		return
 	}
+	err = this.db.RemoveSchedulesOfDeployment(networkId, deploymentId)
+	if err != nil {
+		return
+	}
 	err = this.deleteInstancesOfDeployment(networkId, deploymentId)
 	if err != nil {
 		return
@@ -173,7 +181,7 @@ func (this *Controller) ApiDeleteDeployment(networkId string, deploymentId strin
 	if current.IsPlaceholder || current.MarkedAsMissing {
 		err = this.deleteDeployment(networkId, deploymentId)
 	} else {
-		err = this.mgw.SendDeploymentDeleteCommand(networkId, deploymentId)
+		err = this.mgw.SendDeploymentDeleteCommand(ctx, networkId, deploymentId)
 		if err != nil {
 			return
 		}
@@ -238,7 +246,7 @@ func SetProcessId(xml string, id string) (result string, err error) {
 	return doc.WriteToString()
 }
 
-func (this *Controller) ApiCreateDeployment(token string, networkId string, deployment deploymentmodel.Deployment) (err error, errCode int) {
+func (this *Controller) ApiCreateDeployment(ctx context.Context, token string, networkId string, deployment deploymentmodel.Deployment) (err error, errCode int) {
 	if deployment.Id == "" {
 		deployment.Id = uuid.NewString()
 	}
@@ -258,14 +266,16 @@ func (this *Controller) ApiCreateDeployment(token string, networkId string, depl
 	if err != nil {
 		return err, errCode
 	}
-	err = this.DeployProcessWithoutWardenHandling(networkId, withEvents)
+	err = this.DeployProcessWithoutWardenHandling(ctx, networkId, withEvents)
 	if err != nil {
 		return err, this.SetErrCode(err)
 	}
+	owner := security.ContextUserId(ctx, token)
 	err = this.warden.AddDeploymentWarden(warden.DeploymentWardenInfo{
 		DeploymentId: deployment.Id,
 		NetworkId:    networkId,
 		Deployment:   withEvents,
+		Owner:        owner,
 	})
 	if err != nil {
 		return err, this.SetErrCode(err)
@@ -273,8 +283,8 @@ func (this *Controller) ApiCreateDeployment(token string, networkId string, depl
 	return nil, http.StatusOK
 }
 
-func (this *Controller) DeployProcessWithoutWardenHandling(networkId string, deployment model.DeploymentWithEventDesc) (err error) {
-	err = this.mgw.SendDeploymentCommand(networkId, deployment)
+func (this *Controller) DeployProcessWithoutWardenHandling(ctx context.Context, networkId string, deployment model.DeploymentWithEventDesc) (err error) {
+	err = this.mgw.SendDeploymentCommand(ctx, networkId, deployment)
 	if err != nil {
 		return err
 	}
@@ -296,12 +306,17 @@ func (this *Controller) DeployProcessWithoutWardenHandling(networkId string, dep
 	})
 }
 
-func (this *Controller) StartDeploymentWithoutWardenHandling(networkId string, deploymentId string, businessKey string, parameter map[string]interface{}) (err error, errCode int) {
+func (this *Controller) StartDeploymentWithoutWardenHandling(ctx context.Context, networkId string, deploymentId string, businessKey string, parameter map[string]interface{}) (err error, errCode int) {
+	ctx, span := tracing.Start(ctx, "Controller.StartDeploymentWithoutWardenHandling", attribute.String("network.id", networkId), attribute.String("deployment.id", deploymentId))
 	defer func() {
 		errCode = this.SetErrCode(err)
+		tracing.End(span, err)
 	}()
 	var deployment model.Deployment
-	deployment, err = this.db.ReadDeployment(networkId, deploymentId)
+	err = tracing.Call(ctx, "db.ReadDeployment", func(context.Context) (err error) {
+		deployment, err = this.db.ReadDeployment(networkId, deploymentId)
+		return err
+	})
 	if err != nil {
 		debug.PrintStack()
 		return
@@ -325,6 +340,7 @@ func (this *Controller) StartDeploymentWithoutWardenHandling(networkId string, d
 	if businessKey == "" {
 		businessKey = uuid.NewString()
 	}
+	span.SetAttributes(attribute.String("business_key", businessKey), attribute.Bool("placeholder", deployment.IsPlaceholder))
 
 	//we want to be able to start placeholder deployments
 	/*
@@ -336,7 +352,7 @@ func (this *Controller) StartDeploymentWithoutWardenHandling(networkId string, d
 	*/
 
 	if !deployment.IsPlaceholder {
-		err = this.mgw.SendDeploymentStartCommand(networkId, deploymentId, businessKey, parameter)
+		err = this.mgw.SendDeploymentStartCommand(ctx, networkId, deploymentId, businessKey, parameter)
 		if err != nil {
 			debug.PrintStack()
 			return
@@ -345,6 +361,10 @@ func (this *Controller) StartDeploymentWithoutWardenHandling(networkId string, d
 
 	now := configuration.TimeNow()
 	instanceId := "placeholder-" + configuration.Id()
+	_, dbSpan := tracing.Start(ctx, "db.SavePlaceholder")
+	defer func() {
+		tracing.End(dbSpan, err)
+	}()
 	err = this.db.SaveProcessInstance(model.ProcessInstance{
 		ProcessInstance: camundamodel.ProcessInstance{
 			Id: instanceId,
@@ -394,7 +414,29 @@ func (this *Controller) StartDeploymentWithoutWardenHandling(networkId string, d
 	return
 }
 
-func (this *Controller) ApiStartDeployment(networkId string, deploymentId string, businessKey string, parameter map[string]interface{}) (err error, errCode int) {
+func (this *Controller) ApiStartDeployment(ctx context.Context, networkId string, deploymentId string, businessKey string, parameter map[string]interface{}) (err error, errCode int) {
+	return this.startDeployment(ctx, networkId, deploymentId, businessKey, parameter, nil)
+}
+
+// startDeployment starts a warden handled process instance; without restartPolicy, the default restart policy of the deployment is used.
+// the instance inherits the paused state of the deployment warden.
+func (this *Controller) startDeployment(ctx context.Context, networkId string, deploymentId string, businessKey string, parameter map[string]interface{}, restartPolicy *model.RestartPolicy) (err error, errCode int) {
+	ctx, span := tracing.Start(ctx, "Controller.startDeployment", attribute.String("network.id", networkId), attribute.String("deployment.id", deploymentId))
+	defer func() {
+		tracing.End(span, err)
+	}()
+	var deploymentInfo model.DeploymentWardenInfo
+	var deploymentInfoExists bool
+	err = tracing.Call(ctx, "db.GetDeploymentWardenInfoByDeploymentId", func(context.Context) (err error) {
+		deploymentInfo, deploymentInfoExists, err = this.db.GetDeploymentWardenInfoByDeploymentId(networkId, deploymentId)
+		return err
+	})
+	if err != nil {
+		return err, this.SetErrCode(err)
+	}
+	if restartPolicy == nil && deploymentInfoExists {
+		restartPolicy = deploymentInfo.RestartPolicy
+	}
 	businessKey = this.warden.MarkInstanceBusinessKeyAsWardenHandled(businessKey)
 	info := warden.WardenInfo{
 		CreationTime:        time.Now().Unix(),
@@ -402,12 +444,17 @@ func (this *Controller) ApiStartDeployment(networkId string, deploymentId string
 		BusinessKey:         businessKey,
 		ProcessDeploymentId: deploymentId,
 		StartParameters:     parameter,
+		RestartPolicy:       restartPolicy,
+		Paused:              deploymentInfoExists && deploymentInfo.Paused,
 	}
-	err = this.warden.AddInstanceWarden(info)
+	span.SetAttributes(attribute.String("business_key", businessKey))
+	err = tracing.Call(ctx, "Warden.AddInstanceWarden", func(context.Context) error {
+		return this.warden.AddInstanceWarden(info)
+	})
 	if err != nil {
 		return err, this.SetErrCode(err)
 	}
-	err, errCode = this.StartDeploymentWithoutWardenHandling(networkId, deploymentId, businessKey, parameter)
+	err, errCode = this.StartDeploymentWithoutWardenHandling(ctx, networkId, deploymentId, businessKey, parameter)
 	if err != nil {
 		rollBackErr := this.warden.RemoveInstanceWarden(info)
 		if rollBackErr != nil {
@@ -418,6 +465,57 @@ func (this *Controller) ApiStartDeployment(networkId string, deploymentId string
 	return nil, http.StatusOK
 }
 
+// ApiStartDeploymentWithValidatedParameter validates and coerces the parameter against the deployments ProcessParameter before starting it.
+// deployments without synced metadata (e.g. placeholders) can not be validated and are started with the unchanged parameter.
+func (this *Controller) ApiStartDeploymentWithValidatedParameter(ctx context.Context, networkId string, deploymentId string, request model.StartRequest) (err error, errCode int) {
+	ctx, span := tracing.Start(ctx, "Controller.ApiStartDeploymentWithValidatedParameter", attribute.String("network.id", networkId), attribute.String("deployment.id", deploymentId))
+	defer func() {
+		tracing.End(span, err)
+	}()
+	parameter := request.Parameter
+	if parameter == nil {
+		parameter = map[string]interface{}{}
+	}
+	var metadata model.DeploymentMetadata
+	err = tracing.Call(ctx, "db.ReadDeploymentMetadata", func(context.Context) (err error) {
+		metadata, err = this.db.ReadDeploymentMetadata(networkId, deploymentId)
+		return err
+	})
+	if err != nil && !errors.Is(err, database.ErrNotFound) {
+		return err, this.SetErrCode(err)
+	}
+	if err == nil {
+		parameter, err = metadata.ValidateStartParameter(parameter)
+		if err != nil {
+			return err, this.SetErrCode(err)
+		}
+	} else {
+		this.config.GetLogger().Warn("no deployment metadata found to validate start parameter --> start without validation", "network-id", networkId, "deployment-id", deploymentId)
+	}
+	return this.startDeployment(ctx, networkId, deploymentId, request.BusinessKey, parameter, request.RestartPolicy)
+}
+
+// ApiSetDeploymentRestartPolicy sets the default restart policy for instances of the deployment; a nil policy removes the default.
+// instances which are already started keep their restart policy.
+func (this *Controller) ApiSetDeploymentRestartPolicy(networkId string, deploymentId string, policy *model.RestartPolicy) (err error, errCode int) {
+	if policy != nil {
+		err = policy.Validate()
+		if err != nil {
+			return err, this.SetErrCode(err)
+		}
+	}
+	info, exists, err := this.db.GetDeploymentWardenInfoByDeploymentId(networkId, deploymentId)
+	if err != nil {
+		return err, this.SetErrCode(err)
+	}
+	if !exists {
+		return database.ErrNotFound, http.StatusNotFound
+	}
+	info.RestartPolicy = policy
+	err = this.warden.AddDeploymentWarden(info)
+	return err, this.SetErrCode(err)
+}
+
 func (this *Controller) ExtendDeployments(deployments []model.Deployment) (result []model.ExtendedDeployment) {
 	deploymentIds := map[string][]string{} //key = network_id
 	for _, deployment := range deployments {
@@ -35,7 +35,7 @@ func (this *Controller) initDeviceGroupWatcher(ctx context.Context) (err error)
 		this.config.GetLogger().Info("skip device-group handler: missing auth url config")
 		return nil
 	}
-	return kafka.NewConsumer(ctx, this.config, this.config.DeviceGroupTopic, func(msg []byte) error {
+	return kafka.NewConsumer(ctx, this.config, this.config.DeviceGroupTopic, this.metrics, func(msg []byte) error {
 		this.config.GetLogger().Debug("receive device-group command", "msg", string(msg))
 		cmd := DeviceGroupCommand{}
 		err := json.Unmarshal(msg, &cmd)
@@ -81,7 +81,7 @@ func (this *Controller) UpdateDeviceGroup(token string, deviceGroupId string) er
 		if err != nil {
 			return err
 		}
-		err = this.mgw.SendDeploymentEventUpdateCommand(element.NetworkId,
+		err = this.mgw.SendDeploymentEventUpdateCommand(context.Background(), element.NetworkId,
 			element.CamundaDeploymentId,
 			withEvents.EventDescriptions,
 			withEvents.DeviceIdToLocalId,
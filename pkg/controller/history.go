@@ -17,14 +17,18 @@
 package controller
 
 import (
+	"context"
+	"errors"
+	"net/http"
 	"runtime/debug"
+	"time"
 
 	"github.com/SENERGY-Platform/process-sync/pkg/configuration"
 	"github.com/SENERGY-Platform/process-sync/pkg/model"
 	"github.com/SENERGY-Platform/process-sync/pkg/model/camundamodel"
 )
 
-func (this *Controller) UpdateHistoricProcessInstance(networkId string, historicProcessInstance camundamodel.HistoricProcessInstance) {
+func (this *Controller) UpdateHistoricProcessInstance(ctx context.Context, networkId string, historicProcessInstance camundamodel.HistoricProcessInstance) {
 	err := this.db.RemovePlaceholderHistoricProcessInstances(networkId)
 	if err != nil {
 		this.config.GetLogger().Error("error", "error", err, "stack", debug.Stack())
@@ -45,16 +49,17 @@ func (this *Controller) UpdateHistoricProcessInstance(networkId string, historic
 	if err != nil {
 		this.config.GetLogger().Error("error", "error", err, "stack", debug.Stack())
 	}
+	this.enqueueWardenCheck(networkId, historicProcessInstance.BusinessKey)
 }
 
-func (this *Controller) DeleteHistoricProcessInstance(networkId string, historicInstanceId string) {
+func (this *Controller) DeleteHistoricProcessInstance(ctx context.Context, networkId string, historicInstanceId string) {
 	err := this.db.RemoveHistoricProcessInstance(networkId, historicInstanceId)
 	if err != nil {
 		this.config.GetLogger().Error("error", "error", err, "stack", debug.Stack())
 	}
 }
 
-func (this *Controller) DeleteUnknownHistoricProcessInstances(networkId string, knownIds []string) {
+func (this *Controller) DeleteUnknownHistoricProcessInstances(ctx context.Context, networkId string, knownIds []string) {
 	err := this.db.RemoveUnknownHistoricProcessInstances(networkId, knownIds)
 	if err != nil {
 		this.config.GetLogger().Error("error", "error", err, "stack", debug.Stack())
@@ -67,7 +72,7 @@ func (this *Controller) ApiReadHistoricProcessInstance(networkId string, id stri
 	return
 }
 
-func (this *Controller) ApiDeleteHistoricProcessInstance(networkId string, id string) (err error, errCode int) {
+func (this *Controller) ApiDeleteHistoricProcessInstance(ctx context.Context, networkId string, id string) (err error, errCode int) {
 	defer func() {
 		errCode = this.SetErrCode(err)
 	}()
@@ -83,7 +88,7 @@ func (this *Controller) ApiDeleteHistoricProcessInstance(networkId string, id st
 			err = HistoryMayOnlyDeletedIfFinishedOrPlaceholderErr
 			return
 		}
-		err = this.mgw.SendProcessHistoryDeleteCommand(networkId, id)
+		err = this.mgw.SendProcessHistoryDeleteCommand(ctx, networkId, id)
 		if err != nil {
 			return
 		}
@@ -101,3 +106,31 @@ func (this *Controller) ApiListHistoricProcessInstance(networkIds []string, quer
 	}
 	return
 }
+
+const defaultHistoryAnalyticsRange = 7 * 24 * time.Hour
+
+// ApiGetHistoryAnalytics analyses the process instances started in the time window; defaults to the last 7 days
+func (this *Controller) ApiGetHistoryAnalytics(query model.HistoryAnalyticsQuery) (result []model.HistoryAnalytics, err error, errCode int) {
+	switch query.GroupBy {
+	case "":
+		query.GroupBy = model.HistoryAnalyticsGroupByDefinition
+	case model.HistoryAnalyticsGroupByDefinition, model.HistoryAnalyticsGroupByDeployment:
+	default:
+		return result, errors.New("group_by must be definition or deployment"), http.StatusBadRequest
+	}
+	if query.To.IsZero() {
+		query.To = configuration.TimeNow()
+	}
+	if query.From.IsZero() {
+		query.From = query.To.Add(-defaultHistoryAnalyticsRange)
+	}
+	if !query.From.Before(query.To) {
+		return result, errors.New("from must be before to"), http.StatusBadRequest
+	}
+	result, err = this.db.AnalyseHistoricProcessInstances(query)
+	errCode = this.SetErrCode(err)
+	if result == nil {
+		result = []model.HistoryAnalytics{}
+	}
+	return
+}
@@ -17,14 +17,23 @@
 package controller
 
 import (
+	"context"
+	"net/http"
 	"runtime/debug"
+	"strings"
+	"time"
 
 	"github.com/SENERGY-Platform/process-sync/pkg/configuration"
 	"github.com/SENERGY-Platform/process-sync/pkg/model"
 	"github.com/SENERGY-Platform/process-sync/pkg/model/camundamodel"
+	"github.com/SENERGY-Platform/process-sync/pkg/tracing"
+	"github.com/SENERGY-Platform/process-sync/pkg/warden"
+	"go.opentelemetry.io/otel/attribute"
 )
 
-func (this *Controller) UpdateIncident(networkId string, incident camundamodel.Incident) {
+const defaultIncidentCommandTimeout = 10 * time.Minute
+
+func (this *Controller) UpdateIncident(ctx context.Context, networkId string, incident camundamodel.Incident) {
 	newDocument, err := this.db.SaveIncident(model.Incident{
 		Incident: incident,
 		SyncInfo: model.SyncInfo{
@@ -41,16 +50,39 @@ func (this *Controller) UpdateIncident(networkId string, incident camundamodel.I
 	if newDocument {
 		this.logAndNotify(networkId, incident)
 	}
+	this.enqueueWardenCheckOfIncident(networkId, incident)
+}
+
+// enqueueWardenCheckOfIncident resolves the business key of the incident (older mgw versions do not send it)
+// and falls back to a check of the whole deployment if the key is unknown
+func (this *Controller) enqueueWardenCheckOfIncident(networkId string, incident camundamodel.Incident) {
+	businessKey := incident.BusinessKey
+	if businessKey == "" {
+		history, err := this.db.ReadHistoricProcessInstance(networkId, incident.ProcessInstanceId)
+		if err == nil {
+			businessKey = history.BusinessKey
+		}
+	}
+	if businessKey != "" {
+		this.enqueueWardenCheck(networkId, businessKey)
+		return
+	}
+	definition, err := this.db.ReadProcessDefinition(networkId, incident.ProcessDefinitionId)
+	if err != nil {
+		this.logger.Debug("unable to resolve deployment of incident for warden check", "error", err, "network-id", networkId, "incident-id", incident.Id)
+		return
+	}
+	this.warden.EnqueueCheck(warden.CheckRequest{NetworkId: networkId, DeploymentId: definition.DeploymentId})
 }
 
-func (this *Controller) DeleteIncident(networkId string, incidentId string) {
+func (this *Controller) DeleteIncident(ctx context.Context, networkId string, incidentId string) {
 	err := this.db.RemoveIncident(networkId, incidentId)
 	if err != nil {
 		this.config.GetLogger().Error("error", "error", err, "stack", debug.Stack())
 	}
 }
 
-func (this *Controller) DeleteUnknownIncidents(networkId string, knownIds []string) {
+func (this *Controller) DeleteUnknownIncidents(ctx context.Context, networkId string, knownIds []string) {
 	err := this.db.RemoveUnknownIncidents(networkId, knownIds)
 	if err != nil {
 		this.config.GetLogger().Error("error", "error", err, "stack", debug.Stack())
@@ -71,6 +103,61 @@ func (this *Controller) ApiDeleteIncident(networkId string, id string) (err erro
 	return
 }
 
+// ApiSendIncidentCommand sends a retry, resolve or restart command for the incident to the mgw and marks the incident as pending;
+// further commands are rejected until the mgw removes the incident or the incident_command_timeout is reached
+func (this *Controller) ApiSendIncidentCommand(ctx context.Context, networkId string, id string, command string, retries int) (result model.Incident, err error, errCode int) {
+	ctx, span := tracing.Start(ctx, "Controller.ApiSendIncidentCommand", attribute.String("network.id", networkId), attribute.String("incident.id", id), attribute.String("incident.command", command))
+	defer func() {
+		errCode = this.SetErrCode(err)
+		tracing.End(span, err)
+	}()
+	switch command {
+	case model.IncidentCommandRetry:
+		if retries <= 0 {
+			retries = 1
+		}
+	case model.IncidentCommandResolve, model.IncidentCommandRestart:
+		retries = 0
+	default:
+		return result, UnknownIncidentCommandErr, http.StatusBadRequest
+	}
+	result, err = this.db.ReadIncident(networkId, id)
+	if err != nil {
+		return result, err, errCode
+	}
+	now := configuration.TimeNow()
+	if result.PendingCommand != nil && now.Sub(result.PendingCommand.Time) < this.incidentCommandTimeout {
+		return result, IncidentCommandPendingErr, errCode
+	}
+	if command == model.IncidentCommandRestart {
+		//the warden restarts stopped instances with its own start parameters; a restart by the mgw would race with the warden
+		instance, readErr := this.db.ReadProcessInstance(networkId, result.ProcessInstanceId)
+		if readErr == nil && strings.HasPrefix(instance.BusinessKey, model.WardenBusinessKeyPrefix) {
+			err, _ = this.StopProcessInstanceWithoutWardenHandling(ctx, instance)
+			if err != nil {
+				return result, err, errCode
+			}
+			result.PendingCommand = &model.IncidentCommand{Command: command, Time: now}
+			err = this.db.SetIncidentPendingCommand(networkId, id, result.PendingCommand)
+			return result, err, errCode
+		}
+	}
+	err = this.mgw.SendIncidentCommand(ctx, networkId, command, model.IncidentCommandMessage{
+		IncidentId:          result.Id,
+		ExternalTaskId:      result.ExternalTaskId,
+		ProcessInstanceId:   result.ProcessInstanceId,
+		ProcessDefinitionId: result.ProcessDefinitionId,
+		BusinessKey:         result.BusinessKey,
+		Retries:             retries,
+	})
+	if err != nil {
+		return result, err, errCode
+	}
+	result.PendingCommand = &model.IncidentCommand{Command: command, Time: now}
+	err = this.db.SetIncidentPendingCommand(networkId, id, result.PendingCommand)
+	return result, err, errCode
+}
+
 func (this *Controller) ApiListIncidents(networkIds []string, processInstanceId string, limit int64, offset int64, sort string) (result []model.Incident, err error, errCode int) {
 	result, err = this.db.ListIncidents(networkIds, processInstanceId, limit, offset, sort)
 	errCode = this.SetErrCode(err)
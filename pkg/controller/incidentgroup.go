@@ -0,0 +1,139 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/SENERGY-Platform/process-sync/pkg/configuration"
+	"github.com/SENERGY-Platform/process-sync/pkg/model"
+)
+
+const defaultIncidentStatsRange = 24 * time.Hour
+const defaultIncidentStatsInterval = time.Hour
+const maxIncidentStatsBuckets = 1000
+
+// isIncidentGroupMuted reports whether notifications of the incident group are muted; read errors are logged and treated as not muted
+func (this *Controller) isIncidentGroupMuted(networkId string, groupId string) bool {
+	state, exists, err := this.db.ReadIncidentGroupState(networkId, groupId)
+	if err != nil {
+		this.logger.Error("unable to read incident group state", "error", err, "network-id", networkId, "group-id", groupId)
+		return false
+	}
+	return exists && state.IsMuted(configuration.TimeNow())
+}
+
+func (this *Controller) ApiListIncidentGroups(query model.IncidentGroupQuery) (result []model.IncidentGroup, err error, errCode int) {
+	result, err = this.db.ListIncidentGroups(query)
+	errCode = this.SetErrCode(err)
+	if result == nil {
+		result = []model.IncidentGroup{}
+	}
+	return
+}
+
+// ApiGetIncidentStats counts the incidents per interval; buckets without incidents are included with count 0
+func (this *Controller) ApiGetIncidentStats(query model.IncidentStatsQuery) (result model.IncidentStats, err error, errCode int) {
+	if query.To.IsZero() {
+		query.To = configuration.TimeNow()
+	}
+	if query.From.IsZero() {
+		query.From = query.To.Add(-defaultIncidentStatsRange)
+	}
+	if query.Interval == 0 {
+		query.Interval = defaultIncidentStatsInterval
+	}
+	if query.Interval < time.Second {
+		return result, errors.New("interval must be at least 1s"), http.StatusBadRequest
+	}
+	if !query.From.Before(query.To) {
+		return result, errors.New("from must be before to"), http.StatusBadRequest
+	}
+	if query.To.Sub(query.From)/query.Interval >= maxIncidentStatsBuckets {
+		return result, TooManyIncidentStatsBucketsErr, this.SetErrCode(TooManyIncidentStatsBucketsErr)
+	}
+	buckets, err := this.db.CountIncidentsPerInterval(query)
+	if err != nil {
+		return result, err, this.SetErrCode(err)
+	}
+	counts := map[int64]int64{}
+	for _, bucket := range buckets {
+		counts[bucket.Time.UnixMilli()] = bucket.Count
+	}
+	result = model.IncidentStats{
+		From:     query.From,
+		To:       query.To,
+		Interval: query.Interval.String(),
+		Buckets:  []model.IncidentStatsBucket{},
+	}
+	for t := query.From; t.Before(query.To); t = t.Add(query.Interval) {
+		count := counts[t.UnixMilli()]
+		result.Total += count
+		result.Buckets = append(result.Buckets, model.IncidentStatsBucket{Time: t, Count: count})
+	}
+	return result, nil, http.StatusOK
+}
+
+func (this *Controller) ApiAcknowledgeIncidentGroup(networkId string, groupId string, userId string) (result model.IncidentGroupState, err error, errCode int) {
+	return this.updateIncidentGroupState(networkId, groupId, func(state *model.IncidentGroupState) {
+		now := configuration.TimeNow()
+		state.AcknowledgedAt = &now
+		state.AcknowledgedBy = userId
+	})
+}
+
+// ApiMuteIncidentGroup stops notifications of the incident group; a zero duration mutes until ApiUnmuteIncidentGroup is called
+func (this *Controller) ApiMuteIncidentGroup(networkId string, groupId string, userId string, duration time.Duration) (result model.IncidentGroupState, err error, errCode int) {
+	if duration < 0 {
+		return result, errors.New("duration must not be negative"), http.StatusBadRequest
+	}
+	return this.updateIncidentGroupState(networkId, groupId, func(state *model.IncidentGroupState) {
+		state.Muted = true
+		state.MutedBy = userId
+		state.MutedUntil = nil
+		if duration > 0 {
+			until := configuration.TimeNow().Add(duration)
+			state.MutedUntil = &until
+		}
+	})
+}
+
+func (this *Controller) ApiUnmuteIncidentGroup(networkId string, groupId string) (result model.IncidentGroupState, err error, errCode int) {
+	return this.updateIncidentGroupState(networkId, groupId, func(state *model.IncidentGroupState) {
+		state.Muted = false
+		state.MutedBy = ""
+		state.MutedUntil = nil
+	})
+}
+
+func (this *Controller) updateIncidentGroupState(networkId string, groupId string, update func(state *model.IncidentGroupState)) (result model.IncidentGroupState, err error, errCode int) {
+	defer func() {
+		errCode = this.SetErrCode(err)
+	}()
+	result, exists, err := this.db.ReadIncidentGroupState(networkId, groupId)
+	if err != nil {
+		return result, err, errCode
+	}
+	if !exists {
+		result = model.IncidentGroupState{NetworkId: networkId, GroupId: groupId}
+	}
+	update(&result)
+	err = this.db.SetIncidentGroupState(result)
+	return result, err, errCode
+}
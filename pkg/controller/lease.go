@@ -0,0 +1,93 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/SENERGY-Platform/process-sync/pkg/model"
+)
+
+// leases of jobs which must run on only one replica at a time
+const (
+	BackfillLease  = "backfill"
+	RetentionLease = "retention"
+)
+
+// jobLeaseDuration is the validity of a job lease; the lease is renewed every third of the duration while the job runs,
+// so that another replica may take over shortly after the holder died
+const jobLeaseDuration = time.Minute
+
+var ErrJobLeaseLost = errors.New("job lease lost")
+
+func (this *Controller) ApiListLeases() (result []model.Lease, err error, errCode int) {
+	result, err = this.db.ListLeases()
+	errCode = this.SetErrCode(err)
+	if result == nil {
+		result = []model.Lease{}
+	}
+	return
+}
+
+// runLeased runs job only if this replica acquires the lease, so that replicas do not run the same job concurrently;
+// the lease is renewed while the job runs and released afterwards. the ctx of the job is canceled with ErrJobLeaseLost if a renewal fails.
+// ran is false if another replica holds the lease
+func (this *Controller) runLeased(ctx context.Context, name string, job func(ctx context.Context) error) (ran bool, err error) {
+	acquired, err := this.jobLease.TryAcquire(name)
+	if err != nil || !acquired {
+		return false, err
+	}
+	defer func() {
+		releaseErr := this.jobLease.Release(name)
+		if releaseErr != nil {
+			this.logger.Warn("unable to release job lease", "error", releaseErr, "lease", name)
+		}
+	}()
+	ctx, cancel := context.WithCancelCause(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(this.jobLease.Duration() / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				acquired, err := this.jobLease.TryAcquire(name)
+				if err != nil {
+					err = fmt.Errorf("%w: %w", ErrJobLeaseLost, err)
+				} else if !acquired {
+					err = ErrJobLeaseLost
+				}
+				if err != nil {
+					this.logger.Error("unable to renew job lease --> stop job", "error", err, "lease", name)
+					cancel(err)
+					return
+				}
+			}
+		}
+	}()
+	defer func() {
+		cancel(nil)
+		<-done
+	}()
+	return true, job(ctx)
+}
@@ -0,0 +1,170 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SENERGY-Platform/process-sync/pkg/archive"
+	"github.com/SENERGY-Platform/process-sync/pkg/configuration"
+	"github.com/SENERGY-Platform/process-sync/pkg/database"
+	"github.com/SENERGY-Platform/process-sync/pkg/lease"
+	"github.com/SENERGY-Platform/process-sync/pkg/model"
+	"github.com/SENERGY-Platform/process-sync/pkg/model/camundamodel"
+)
+
+// leaseDb grants leases while available is true
+type leaseDb struct {
+	database.Database
+	mux       sync.Mutex
+	available bool
+	acquired  int
+	released  int
+	removed   int
+}
+
+func (this *leaseDb) TryAcquireLease(name string, holder string, now time.Time, duration time.Duration) (model.Lease, bool, error) {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	if !this.available {
+		return model.Lease{}, false, nil
+	}
+	this.acquired++
+	return model.Lease{Name: name, Holder: holder}, true, nil
+}
+
+func (this *leaseDb) ReleaseLease(name string, holder string) error {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	this.released++
+	return nil
+}
+
+func (this *leaseDb) RemoveIncidentsInScope(scope model.RetentionScope) (int64, error) {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	this.removed++
+	return 0, nil
+}
+
+func (this *leaseDb) setAvailable(available bool) {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	this.available = available
+}
+
+func TestRunLeased(t *testing.T) {
+	db := &leaseDb{}
+	ctrl := &Controller{db: db, logger: slog.Default(), jobLease: lease.New(db, 30*time.Millisecond)}
+
+	ran, err := ctrl.runLeased(context.Background(), BackfillLease, func(ctx context.Context) error {
+		t.Error("job should not run while another replica holds the lease")
+		return nil
+	})
+	if ran || err != nil {
+		t.Error(ran, err)
+	}
+
+	db.setAvailable(true)
+	ran, err = ctrl.runLeased(context.Background(), BackfillLease, func(ctx context.Context) error {
+		time.Sleep(50 * time.Millisecond)
+		return ctx.Err()
+	})
+	if !ran || err != nil {
+		t.Error(ran, err)
+	}
+	if db.acquired < 2 || db.released != 1 {
+		t.Error("lease should be renewed while the job runs and released afterwards", db.acquired, db.released)
+	}
+
+	ran, err = ctrl.runLeased(context.Background(), BackfillLease, func(ctx context.Context) error {
+		db.setAvailable(false)
+		<-ctx.Done()
+		return context.Cause(ctx)
+	})
+	if !ran || !errors.Is(err, ErrJobLeaseLost) {
+		t.Error(ran, err)
+	}
+}
+
+func TestApplyRetentionPoliciesLeased(t *testing.T) {
+	db := &leaseDb{}
+	ctrl := &Controller{db: db, logger: slog.Default(), jobLease: lease.New(db, time.Minute), retentionPolicies: []retentionPolicy{{entity: configuration.RetentionEntityIncidents, maxAge: time.Hour}}}
+	err := ctrl.ApplyRetentionPolicies(context.Background())
+	if err != nil {
+		t.Error(err)
+	}
+	if db.removed != 0 {
+		t.Error("retention should be skipped while another replica holds the lease")
+	}
+	db.setAvailable(true)
+	err = ctrl.ApplyRetentionPolicies(context.Background())
+	if err != nil {
+		t.Error(err)
+	}
+	if db.removed != 1 || db.released != 1 {
+		t.Error(db.removed, db.released)
+	}
+}
+
+// archiveDb returns the same incidents on every find and cancels the job after the first removed incident
+type archiveDb struct {
+	database.Database
+	incidents []model.Incident
+	cancel    context.CancelFunc
+	removed   int
+}
+
+func (this *archiveDb) FindIncidentsInScope(scope model.RetentionScope, limit int64) ([]model.Incident, error) {
+	return this.incidents, nil
+}
+
+func (this *archiveDb) RemoveIncident(networkId string, incidentId string) error {
+	this.removed++
+	this.cancel()
+	return nil
+}
+
+func TestArchiveAndRemoveStopsOnLostLease(t *testing.T) {
+	store, err := archive.NewLocalStore(t.TempDir())
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	ctx, cancel := context.WithCancelCause(context.Background())
+	defer cancel(nil)
+	db := &archiveDb{
+		incidents: []model.Incident{
+			{Incident: camundamodel.Incident{Id: "i1"}, SyncInfo: model.SyncInfo{NetworkId: "n1"}},
+			{Incident: camundamodel.Incident{Id: "i2"}, SyncInfo: model.SyncInfo{NetworkId: "n1"}},
+		},
+		cancel: func() { cancel(ErrJobLeaseLost) },
+	}
+	ctrl := &Controller{db: db, logger: slog.Default(), archive: archive.New(store)}
+	count, err := ctrl.archiveAndRemoveIncidents(ctx, model.RetentionScope{})
+	if !errors.Is(err, ErrJobLeaseLost) {
+		t.Error(err)
+	}
+	if count != 1 || db.removed != 1 {
+		t.Error("archive job should stop before the next document", count, db.removed)
+	}
+}
@@ -17,6 +17,7 @@
 package controller
 
 import (
+	"context"
 	"encoding/json"
 	"runtime/debug"
 
@@ -28,7 +29,7 @@ func (this *Controller) ListDeploymentMetadata(query model.MetadataQuery) (resul
 	return this.db.ListDeploymentMetadata(query)
 }
 
-func (this *Controller) UpdateDeploymentMetadata(networkId string, metadata model.Metadata) {
+func (this *Controller) UpdateDeploymentMetadata(ctx context.Context, networkId string, metadata model.Metadata) {
 	err := this.db.SaveDeploymentMetadata(model.DeploymentMetadata{
 		Metadata: metadata,
 		SyncInfo: model.SyncInfo{
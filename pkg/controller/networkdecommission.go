@@ -0,0 +1,106 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"context"
+
+	"github.com/SENERGY-Platform/process-sync/pkg/model"
+)
+
+// ApiDecommissionNetwork removes all data of a retired network and reports each finished step to progress:
+// warden infos are removed first, so that the warden stops redeploying to the network; with options.DeleteOnEdge, delete commands for all deployments are sent to the mgw;
+// with configured archive, history and incidents are archived; finally all elements and the last contact of the network are removed
+func (this *Controller) ApiDecommissionNetwork(ctx context.Context, networkId string, options model.NetworkDecommissionOptions, progress func(model.NetworkDecommissionProgress)) (err error, errCode int) {
+	defer func() {
+		errCode = this.SetErrCode(err)
+	}()
+	step := func(name string, run func() (count int64, err error)) error {
+		count, err := run()
+		report := model.NetworkDecommissionProgress{NetworkId: networkId, Step: name, Count: count}
+		if err != nil {
+			report.Error = err.Error()
+		}
+		progress(report)
+		return err
+	}
+	err = step(model.NetworkDecommissionRemoveWardenInfos, func() (int64, error) {
+		return this.db.RemoveNetworkWardenInfos(networkId)
+	})
+	if err != nil {
+		return
+	}
+	if options.DeleteOnEdge {
+		err = step(model.NetworkDecommissionDeleteOnEdge, func() (int64, error) {
+			return this.deleteNetworkDeploymentsOnEdge(ctx, networkId)
+		})
+		if err != nil {
+			return
+		}
+	}
+	if this.archive != nil {
+		err = step(model.NetworkDecommissionArchive, func() (int64, error) {
+			scope := model.RetentionScope{NetworkIds: []string{networkId}}
+			history, err := this.archiveAndRemoveHistory(ctx, scope)
+			if err != nil {
+				return history, err
+			}
+			incidents, err := this.archiveAndRemoveIncidents(ctx, scope)
+			return history + incidents, err
+		})
+		if err != nil {
+			return
+		}
+	}
+	err = step(model.NetworkDecommissionPurge, func() (int64, error) {
+		return 0, this.db.PurgeNetwork(networkId)
+	})
+	if err != nil {
+		return
+	}
+	err = step(model.NetworkDecommissionRemoveLastContact, func() (int64, error) {
+		return 0, this.db.RemoveLastContact(networkId)
+	})
+	if err != nil {
+		return
+	}
+	progress(model.NetworkDecommissionProgress{NetworkId: networkId, Step: model.NetworkDecommissionDone})
+	this.logger.Info("decommissioned network", "network_id", networkId)
+	return
+}
+
+func (this *Controller) deleteNetworkDeploymentsOnEdge(ctx context.Context, networkId string) (count int64, err error) {
+	for offset := int64(0); ; offset += networkExportBatchSize {
+		deployments, err := this.db.ListDeployments([]string{networkId}, networkExportBatchSize, offset, "id.asc")
+		if err != nil {
+			return count, err
+		}
+		for _, deployment := range deployments {
+			if deployment.IsPlaceholder {
+				continue
+			}
+			err = this.mgw.SendDeploymentDeleteCommand(ctx, networkId, deployment.Id)
+			if err != nil {
+				return count, err
+			}
+			count++
+		}
+		if len(deployments) < networkExportBatchSize {
+			return count, nil
+		}
+	}
+}
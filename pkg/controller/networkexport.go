@@ -0,0 +1,289 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/SENERGY-Platform/process-sync/pkg/configuration"
+	"github.com/SENERGY-Platform/process-sync/pkg/model"
+)
+
+const networkExportBatchSize = 1000
+
+// networkExportRecordTypes are exported in this order
+var networkExportRecordTypes = []string{
+	model.NetworkExportDeployment,
+	model.NetworkExportDeploymentMetadata,
+	model.NetworkExportProcessDefinition,
+	model.NetworkExportProcessInstance,
+	model.NetworkExportHistoricProcessInstance,
+	model.NetworkExportIncident,
+	model.NetworkExportDeploymentWardenInfo,
+	model.NetworkExportWardenInfo,
+}
+
+// ApiExportNetwork writes the complete sync state of the network as NDJSON: a model.NetworkExportHeader followed by model.NetworkExportRecord lines;
+// the final record of type model.NetworkExportEnd is missing if the export failed after the first write
+func (this *Controller) ApiExportNetwork(networkId string, writer io.Writer) (err error, errCode int) {
+	defer func() {
+		errCode = this.SetErrCode(err)
+	}()
+	encoder := json.NewEncoder(writer)
+	err = encoder.Encode(model.NetworkExportHeader{
+		Version:    model.NetworkExportVersion,
+		NetworkId:  networkId,
+		ExportedAt: configuration.TimeNow(),
+	})
+	if err != nil {
+		return
+	}
+	counts := map[string]int{}
+	for _, recordType := range networkExportRecordTypes {
+		afterId := ""
+		for {
+			var page []interface{}
+			page, afterId, err = this.db.ListNetworkExportDocuments(networkId, recordType, afterId, networkExportBatchSize)
+			if err != nil {
+				return
+			}
+			for _, element := range page {
+				err = encodeExportRecord(encoder, recordType, element)
+				if err != nil {
+					return
+				}
+				counts[recordType]++
+			}
+			if len(page) < networkExportBatchSize {
+				break
+			}
+		}
+	}
+	err = encodeExportRecord(encoder, model.NetworkExportEnd, model.NetworkExportEndDocument{Counts: counts})
+	return
+}
+
+func encodeExportRecord(encoder *json.Encoder, recordType string, document interface{}) error {
+	raw, err := json.Marshal(document)
+	if err != nil {
+		return err
+	}
+	return encoder.Encode(model.NetworkExportRecord{Type: recordType, Document: raw})
+}
+
+// ApiImportNetwork reads an export of ApiExportNetwork and saves its documents in the network networkId;
+// the export is buffered in a temporary file and completely validated before any document is saved or removed
+func (this *Controller) ApiImportNetwork(networkId string, reader io.Reader, options model.NetworkImportOptions) (result model.NetworkImportResult, err error, errCode int) {
+	defer func() {
+		errCode = this.SetErrCode(err)
+	}()
+	buffer, err := os.CreateTemp("", "network-import-*.ndjson")
+	if err != nil {
+		return
+	}
+	defer os.Remove(buffer.Name())
+	defer buffer.Close()
+	_, err = readNetworkImport(networkId, io.TeeReader(reader, buffer), options, func(document interface{}) error {
+		return nil
+	})
+	if err != nil {
+		return
+	}
+	_, err = buffer.Seek(0, io.SeekStart)
+	if err != nil {
+		return
+	}
+	if options.Replace {
+		err = this.db.RemoveNetworkSyncState(networkId)
+		if err != nil {
+			return
+		}
+	}
+	result, err = readNetworkImport(networkId, buffer, options, this.saveNetworkImportDocument)
+	return
+}
+
+// readNetworkImport checks the header and records of an export and calls save for every document, which is already moved to the network networkId;
+// documents skipped by the options are counted but not passed to save
+func readNetworkImport(networkId string, reader io.Reader, options model.NetworkImportOptions, save func(document interface{}) error) (result model.NetworkImportResult, err error) {
+	result.Imported = map[string]int{}
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 64*1024), 64*1024*1024)
+	if !scanner.Scan() {
+		err = errors.Join(InvalidNetworkImportErr, errors.New("missing header"), scanner.Err())
+		return
+	}
+	header := model.NetworkExportHeader{}
+	err = json.Unmarshal(scanner.Bytes(), &header)
+	if err != nil {
+		err = errors.Join(InvalidNetworkImportErr, err)
+		return
+	}
+	if header.Version != model.NetworkExportVersion {
+		err = fmt.Errorf("%w: unsupported version %v", InvalidNetworkImportErr, header.Version)
+		return
+	}
+	result.SourceNetworkId = header.NetworkId
+	if header.NetworkId != networkId && !options.RewriteNetworkId {
+		err = fmt.Errorf("%w: export of network %v may only be imported with rewrite_network_id", InvalidNetworkImportErr, header.NetworkId)
+		return
+	}
+	var end *model.NetworkExportEndDocument
+	for scanner.Scan() {
+		record := model.NetworkExportRecord{}
+		err = json.Unmarshal(scanner.Bytes(), &record)
+		if err != nil {
+			err = errors.Join(InvalidNetworkImportErr, err)
+			return
+		}
+		if end != nil {
+			err = fmt.Errorf("%w: records after end", InvalidNetworkImportErr)
+			return
+		}
+		if record.Type == model.NetworkExportEnd {
+			end = &model.NetworkExportEndDocument{}
+			err = json.Unmarshal(record.Document, end)
+			if err != nil {
+				err = errors.Join(InvalidNetworkImportErr, err)
+				return
+			}
+			continue
+		}
+		var document interface{}
+		var skipped bool
+		document, skipped, err = decodeNetworkImportRecord(networkId, record, options)
+		if err != nil {
+			return
+		}
+		if skipped {
+			result.Skipped++
+			continue
+		}
+		err = save(document)
+		if err != nil {
+			return
+		}
+		result.Imported[record.Type]++
+	}
+	err = scanner.Err()
+	if err != nil {
+		return
+	}
+	if end == nil {
+		err = fmt.Errorf("%w: incomplete export (missing end record)", InvalidNetworkImportErr)
+	}
+	return
+}
+
+func decodeNetworkImportRecord(networkId string, record model.NetworkExportRecord, options model.NetworkImportOptions) (document interface{}, skipped bool, err error) {
+	decode := func(target interface{}) error {
+		err := json.Unmarshal(record.Document, target)
+		if err != nil {
+			return errors.Join(InvalidNetworkImportErr, err)
+		}
+		return nil
+	}
+	switch record.Type {
+	case model.NetworkExportDeployment:
+		element := model.Deployment{}
+		if err = decode(&element); err != nil {
+			return nil, false, err
+		}
+		element.NetworkId = networkId
+		return element, options.SkipPlaceholders && element.IsPlaceholder, nil
+	case model.NetworkExportDeploymentMetadata:
+		element := model.DeploymentMetadata{}
+		if err = decode(&element); err != nil {
+			return nil, false, err
+		}
+		element.NetworkId = networkId
+		return element, options.SkipPlaceholders && element.IsPlaceholder, nil
+	case model.NetworkExportProcessDefinition:
+		element := model.ProcessDefinition{}
+		if err = decode(&element); err != nil {
+			return nil, false, err
+		}
+		element.NetworkId = networkId
+		return element, options.SkipPlaceholders && element.IsPlaceholder, nil
+	case model.NetworkExportProcessInstance:
+		element := model.ProcessInstance{}
+		if err = decode(&element); err != nil {
+			return nil, false, err
+		}
+		element.NetworkId = networkId
+		return element, options.SkipPlaceholders && element.IsPlaceholder, nil
+	case model.NetworkExportHistoricProcessInstance:
+		element := model.HistoricProcessInstance{}
+		if err = decode(&element); err != nil {
+			return nil, false, err
+		}
+		element.NetworkId = networkId
+		return element, options.SkipPlaceholders && element.IsPlaceholder, nil
+	case model.NetworkExportIncident:
+		element := model.Incident{}
+		if err = decode(&element); err != nil {
+			return nil, false, err
+		}
+		element.NetworkId = networkId
+		return element, options.SkipPlaceholders && element.IsPlaceholder, nil
+	case model.NetworkExportDeploymentWardenInfo:
+		element := model.DeploymentWardenInfo{}
+		if err = decode(&element); err != nil {
+			return nil, false, err
+		}
+		element.NetworkId = networkId
+		return element, false, nil
+	case model.NetworkExportWardenInfo:
+		element := model.WardenInfo{}
+		if err = decode(&element); err != nil {
+			return nil, false, err
+		}
+		element.NetworkId = networkId
+		return element, false, nil
+	default:
+		return nil, false, fmt.Errorf("%w: unknown record type %q", InvalidNetworkImportErr, record.Type)
+	}
+}
+
+func (this *Controller) saveNetworkImportDocument(document interface{}) error {
+	switch element := document.(type) {
+	case model.Deployment:
+		return this.db.SaveDeployment(element)
+	case model.DeploymentMetadata:
+		return this.db.SaveDeploymentMetadata(element)
+	case model.ProcessDefinition:
+		return this.db.SaveProcessDefinition(element)
+	case model.ProcessInstance:
+		return this.db.SaveProcessInstance(element)
+	case model.HistoricProcessInstance:
+		return this.db.SaveHistoricProcessInstance(element)
+	case model.Incident:
+		_, err := this.db.SaveIncident(element)
+		return err
+	case model.DeploymentWardenInfo:
+		return this.db.SetDeploymentWardenInfo(element)
+	case model.WardenInfo:
+		return this.db.SetWardenInfo(element)
+	default:
+		return fmt.Errorf("unexpected network import document %T", document)
+	}
+}
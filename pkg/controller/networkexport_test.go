@@ -0,0 +1,80 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/SENERGY-Platform/process-sync/pkg/model"
+)
+
+const testNetworkExport = `{"version":1,"network_id":"source"}
+{"type":"deployment","document":{"id":"d1","network_id":"source"}}
+{"type":"deployment","document":{"id":"d2","network_id":"source","is_placeholder":true}}
+{"type":"warden_info","document":{"business_key":"b1","network_id":"source"}}
+{"type":"end","document":{"counts":{"deployment":2,"warden_info":1}}}
+`
+
+func TestReadNetworkImport(t *testing.T) {
+	saved := []interface{}{}
+	result, err := readNetworkImport("target", strings.NewReader(testNetworkExport), model.NetworkImportOptions{RewriteNetworkId: true, SkipPlaceholders: true}, func(document interface{}) error {
+		saved = append(saved, document)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.SourceNetworkId != "source" || result.Skipped != 1 || result.Imported[model.NetworkExportDeployment] != 1 || result.Imported[model.NetworkExportWardenInfo] != 1 {
+		t.Errorf("%#v", result)
+	}
+	if len(saved) != 2 {
+		t.Fatal(saved)
+	}
+	if deployment, ok := saved[0].(model.Deployment); !ok || deployment.Id != "d1" || deployment.NetworkId != "target" {
+		t.Errorf("%#v", saved[0])
+	}
+	if info, ok := saved[1].(model.WardenInfo); !ok || info.BusinessKey != "b1" || info.NetworkId != "target" {
+		t.Errorf("%#v", saved[1])
+	}
+
+	_, err = readNetworkImport("target", strings.NewReader(testNetworkExport), model.NetworkImportOptions{}, func(document interface{}) error {
+		return nil
+	})
+	if !errors.Is(err, InvalidNetworkImportErr) {
+		t.Error("export of other network should require rewrite_network_id", err)
+	}
+}
+
+func TestImportNetworkValidatesBeforeReplace(t *testing.T) {
+	lines := strings.SplitAfter(testNetworkExport, "\n")
+	invalid := map[string]string{
+		"missing end":  strings.Join(lines[:4], ""),
+		"unknown type": strings.Join(lines[:2], "") + `{"type":"unknown","document":{}}` + "\n" + strings.Join(lines[2:], ""),
+		"invalid json": strings.Join(lines[:3], "") + `{"type":"warden_info","document":` + "\n" + strings.Join(lines[3:], ""),
+	}
+	//without database, any write or removal before the validation would panic
+	ctrl := &Controller{}
+	for name, export := range invalid {
+		_, err, errCode := ctrl.ApiImportNetwork("source", strings.NewReader(export), model.NetworkImportOptions{Replace: true})
+		if !errors.Is(err, InvalidNetworkImportErr) || errCode != http.StatusBadRequest {
+			t.Error(name, err, errCode)
+		}
+	}
+}
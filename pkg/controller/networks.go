@@ -17,14 +17,20 @@
 package controller
 
 import (
-	devicerpo "github.com/SENERGY-Platform/device-repository/lib/client"
-	"github.com/SENERGY-Platform/models/go/models"
-	"github.com/SENERGY-Platform/process-sync/pkg/model"
+	"context"
 	"net/http"
 	"runtime/debug"
 	"time"
+
+	devicerpo "github.com/SENERGY-Platform/device-repository/lib/client"
+	"github.com/SENERGY-Platform/models/go/models"
+	"github.com/SENERGY-Platform/process-sync/pkg/configuration"
+	"github.com/SENERGY-Platform/process-sync/pkg/model"
 )
 
+const defaultNetworkOfflineAfter = 30 * time.Minute
+const defaultNetworkPlaceholderWait = 10 * time.Minute
+
 type SearchHub = models.Hub
 
 func (this *Controller) ApiListNetworks(request *http.Request) (result []SearchHub, err error, errCode int) {
@@ -57,10 +63,83 @@ func (this *Controller) ApiListNetworks(request *http.Request) (result []SearchH
 	return result, nil, http.StatusOK
 }
 
-func (this *Controller) LogNetworkInteraction(networkId string) {
+func (this *Controller) lastNetworkContacts() (map[string]time.Time, error) {
+	contacts, err := this.db.ListLastContacts()
+	if err != nil {
+		return nil, err
+	}
+	result := map[string]time.Time{}
+	for _, contact := range contacts {
+		result[contact.NetworkId] = contact.Time
+	}
+	return result, nil
+}
+
+func parseNetworkStatusConfig(config configuration.Config) (offlineAfter time.Duration, placeholderWait time.Duration, err error) {
+	offlineAfter = defaultNetworkOfflineAfter
+	if config.NetworkOfflineAfter != "" {
+		offlineAfter, err = time.ParseDuration(config.NetworkOfflineAfter)
+		if err != nil {
+			return offlineAfter, placeholderWait, err
+		}
+	}
+	placeholderWait = defaultNetworkPlaceholderWait
+	if config.NetworkPlaceholderWait != "" {
+		placeholderWait, err = time.ParseDuration(config.NetworkPlaceholderWait)
+		if err != nil {
+			return offlineAfter, placeholderWait, err
+		}
+	}
+	return offlineAfter, placeholderWait, nil
+}
+
+// ApiGetNetworkStatus returns the sync status of the network; placeholderWait <= 0 uses the configured network_placeholder_wait
+func (this *Controller) ApiGetNetworkStatus(networkId string, placeholderWait time.Duration) (result model.NetworkStatus, err error, errCode int) {
+	if placeholderWait <= 0 {
+		placeholderWait = this.networkPlaceholderWait
+	}
+	now := configuration.TimeNow()
+	result = model.NetworkStatus{
+		NetworkId:         networkId,
+		LastTopicContacts: map[string]time.Time{},
+	}
+	contact, exists, err := this.db.ReadLastContact(networkId)
+	if err != nil {
+		return result, err, http.StatusInternalServerError
+	}
+	if exists {
+		result.LastContact = &contact.Time
+		if contact.Topics != nil {
+			result.LastTopicContacts = contact.Topics
+		}
+	}
+	result.NetworkStatusCounts, err = this.db.CountNetworkStatus(networkId, now.Add(-placeholderWait))
+	if err != nil {
+		return result, err, http.StatusInternalServerError
+	}
+	result.Health = model.NetworkHealth(now, result.LastContact, this.networkOfflineAfter, result.NetworkStatusCounts)
+	return result, nil, http.StatusOK
+}
+
+func (this *Controller) ApiListNetworkStatus(networkIds []string, placeholderWait time.Duration) (result []model.NetworkStatus, err error, errCode int) {
+	result = []model.NetworkStatus{}
+	for _, networkId := range networkIds {
+		status, err, errCode := this.ApiGetNetworkStatus(networkId, placeholderWait)
+		if err != nil {
+			return result, err, errCode
+		}
+		result = append(result, status)
+	}
+	return result, nil, http.StatusOK
+}
+
+// LogNetworkInteraction stores the contact time of the network and of the entity topic (e.g. deployment or incident)
+func (this *Controller) LogNetworkInteraction(ctx context.Context, networkId string, topic string) {
+	now := configuration.TimeNow()
 	err := this.db.SaveLastContact(model.LastNetworkContact{
 		NetworkId: networkId,
-		Time:      time.Now(),
+		Time:      now,
+		Topics:    map[string]time.Time{topic: now},
 	})
 	if err != nil {
 		this.config.GetLogger().Error("error", "error", err, "stack", debug.Stack())
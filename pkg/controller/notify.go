@@ -17,29 +17,47 @@
 package controller
 
 import (
-	"fmt"
-	developerNotifications "github.com/SENERGY-Platform/developer-notifications/pkg/client"
+	"github.com/SENERGY-Platform/process-sync/pkg/model"
 	"github.com/SENERGY-Platform/process-sync/pkg/model/camundamodel"
+	"github.com/SENERGY-Platform/process-sync/pkg/notifier"
 )
 
 func (this *Controller) logAndNotify(networkid string, incident camundamodel.Incident) {
-	if this.devNotifications != nil {
-		go func() {
-			this.logger.Info("process-incident", "snrgy-log-type", "process-incident", "network-id", networkid, "error", incident.ErrorMessage, "user", incident.TenantId, "deployment-name", incident.DeploymentName, "process-definition-id", incident.ProcessDefinitionId)
-			err := this.devNotifications.SendMessage(developerNotifications.Message{
-				Sender: "github.com/SENERGY-Platform/process-sync",
-				Title:  "Fog-Process-Incident-User-Notification",
-				Tags:   []string{"fog", "process-incident", "user-notification", incident.TenantId, networkid},
-				Body: fmt.Sprintf("Notification For %v in network %v\nTitle: %v\nMessage: %v\n",
-					incident.TenantId,
-					networkid,
-					"Fog-Process-Incident in "+incident.DeploymentName,
-					incident.ErrorMessage,
-				),
-			})
-			if err != nil {
-				this.logger.Error("unable to send developer-notification", "snrgy-log-type", "error", "error", err.Error(), "user", incident.TenantId, "process-definition-id", incident.ProcessDefinitionId, "incident-msg", incident.ErrorMessage)
-			}
-		}()
+	this.logger.Info("process-incident", "snrgy-log-type", "process-incident", "network-id", networkid, "error", incident.ErrorMessage, "user", incident.TenantId, "deployment-name", incident.DeploymentName, "process-definition-id", incident.ProcessDefinitionId)
+	group := model.NewIncidentGroupInfo(incident.ProcessDefinitionId, incident.ActivityId, incident.ErrorMessage)
+	if this.isIncidentGroupMuted(networkid, group.GroupId) {
+		this.logger.Debug("incident group is muted; skip notification", "network-id", networkid, "group-id", group.GroupId)
+		return
 	}
+	this.notifier.Notify(notifier.Notification{
+		Kind:                notifier.KindIncident,
+		NetworkId:           networkid,
+		TenantId:            incident.TenantId,
+		DeploymentName:      incident.DeploymentName,
+		ProcessDefinitionId: incident.ProcessDefinitionId,
+		ProcessInstanceId:   incident.ProcessInstanceId,
+		IncidentId:          incident.Id,
+		IncidentGroupId:     group.GroupId,
+		BusinessKey:         incident.BusinessKey,
+		ErrorMessage:        incident.ErrorMessage,
+		Time:                incident.Time,
+	})
+}
+
+// NotifyWardenGiveUp informs the user that the restart policy of a warden handled process allows no further restarts
+func (this *Controller) NotifyWardenGiveUp(info model.WardenInfo, incident model.Incident) {
+	this.logger.Warn("warden gave up restarting process", "snrgy-log-type", "warden-give-up", "network-id", info.NetworkId, "business-key", info.BusinessKey, "deployment-id", info.ProcessDeploymentId, "restart-count", info.RestartCount, "user", incident.TenantId, "error", incident.ErrorMessage)
+	this.notifier.Notify(notifier.Notification{
+		Kind:                notifier.KindWardenGiveUp,
+		NetworkId:           info.NetworkId,
+		TenantId:            incident.TenantId,
+		DeploymentId:        info.ProcessDeploymentId,
+		DeploymentName:      incident.DeploymentName,
+		ProcessDefinitionId: incident.ProcessDefinitionId,
+		ProcessInstanceId:   incident.ProcessInstanceId,
+		IncidentId:          incident.Id,
+		BusinessKey:         info.BusinessKey,
+		ErrorMessage:        incident.ErrorMessage,
+		RestartCount:        info.ConsecutiveFailures,
+	})
 }
@@ -0,0 +1,69 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+
+	"github.com/SENERGY-Platform/process-sync/pkg/kafka"
+)
+
+// initPermissionChangeWatcher invalidates cached permission checks on updates of the permissions-v2 topic;
+// every instance consumes every message, because every instance has its own cache
+func (this *Controller) initPermissionChangeWatcher(ctx context.Context) (err error) {
+	if this.config.KafkaUrl == "" || this.config.KafkaUrl == "-" {
+		this.config.GetLogger().Info("skip permission-change handler: missing kafka url config")
+		return nil
+	}
+	if this.config.PermissionChangeTopic == "" || this.config.PermissionChangeTopic == "-" {
+		this.config.GetLogger().Info("skip permission-change handler: missing permission_change_topic config")
+		return nil
+	}
+	return kafka.NewBroadcastConsumer(ctx, this.config, this.config.PermissionChangeTopic, this.metrics, func(msg []byte) error {
+		cmd := PermissionChangeCommand{}
+		err := json.Unmarshal(msg, &cmd)
+		if err != nil {
+			this.config.GetLogger().Warn("unable to interpret permission change command --> invalidate all cached permissions", "error", err)
+			this.security.InvalidatePermissions("")
+			return nil
+		}
+		this.config.GetLogger().Debug("receive permission change command", "command", cmd.Command, "id", cmd.Id)
+		this.security.InvalidatePermissions(cmd.Id)
+		return nil
+	}, func(err error) (fatal bool) {
+		if errors.Is(err, kafka.FetchError) {
+			this.config.GetLogger().Error("kafka fetch error", "error", err)
+			log.Fatal(err)
+			return true
+		}
+		if errors.Is(err, kafka.CommitError) {
+			this.config.GetLogger().Error("kafka commit error", "error", err)
+			log.Fatal(err)
+			return true
+		}
+		return true
+	})
+}
+
+// PermissionChangeCommand is the relevant part of the messages published by permissions-v2
+type PermissionChangeCommand struct {
+	Command string `json:"command"`
+	Id      string `json:"id"`
+}
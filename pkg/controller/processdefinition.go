@@ -17,6 +17,7 @@
 package controller
 
 import (
+	"context"
 	"runtime/debug"
 
 	"github.com/SENERGY-Platform/process-sync/pkg/configuration"
@@ -24,7 +25,7 @@ import (
 	"github.com/SENERGY-Platform/process-sync/pkg/model/camundamodel"
 )
 
-func (this *Controller) UpdateProcessDefinition(networkId string, processDefinition camundamodel.ProcessDefinition) {
+func (this *Controller) UpdateProcessDefinition(ctx context.Context, networkId string, processDefinition camundamodel.ProcessDefinition) {
 	err := this.db.SaveProcessDefinition(model.ProcessDefinition{
 		ProcessDefinition: processDefinition,
 		SyncInfo: model.SyncInfo{
@@ -39,7 +40,7 @@ func (this *Controller) UpdateProcessDefinition(networkId string, processDefinit
 	}
 }
 
-func (this *Controller) DeleteProcessDefinition(networkId string, definitionId string) {
+func (this *Controller) DeleteProcessDefinition(ctx context.Context, networkId string, definitionId string) {
 	err := this.db.RemoveProcessDefinition(networkId, definitionId)
 	if err != nil {
 		this.config.GetLogger().Error("error", "error", err, "stack", debug.Stack())
@@ -54,7 +55,7 @@ func (this *Controller) DeleteProcessDefinition(networkId string, definitionId s
 	}
 }
 
-func (this *Controller) DeleteUnknownProcessDefinitions(networkId string, knownIds []string) {
+func (this *Controller) DeleteUnknownProcessDefinitions(ctx context.Context, networkId string, knownIds []string) {
 	err := this.db.RemoveUnknownProcessDefinitions(networkId, knownIds)
 	if err != nil {
 		this.config.GetLogger().Error("error", "error", err, "stack", debug.Stack())
@@ -17,6 +17,7 @@
 package controller
 
 import (
+	"context"
 	"errors"
 	"runtime/debug"
 
@@ -26,7 +27,7 @@ import (
 	"github.com/SENERGY-Platform/process-sync/pkg/model/camundamodel"
 )
 
-func (this *Controller) UpdateProcessInstance(networkId string, instance camundamodel.ProcessInstance) {
+func (this *Controller) UpdateProcessInstance(ctx context.Context, networkId string, instance camundamodel.ProcessInstance) {
 	err := this.db.RemovePlaceholderProcessInstances(networkId)
 	if err != nil {
 		this.config.GetLogger().Error("error", "error", err, "stack", debug.Stack())
@@ -43,9 +44,11 @@ func (this *Controller) UpdateProcessInstance(networkId string, instance camunda
 	if err != nil {
 		this.config.GetLogger().Error("error", "error", err, "stack", debug.Stack())
 	}
+	this.enqueueWardenCheck(networkId, instance.BusinessKey)
 }
 
-func (this *Controller) DeleteProcessInstance(networkId string, instanceId string) {
+func (this *Controller) DeleteProcessInstance(ctx context.Context, networkId string, instanceId string) {
+	current, readErr := this.db.ReadProcessInstance(networkId, instanceId)
 	err := this.db.RemoveProcessInstance(networkId, instanceId)
 	if err != nil {
 		this.config.GetLogger().Error("error", "error", err, "stack", debug.Stack())
@@ -54,9 +57,12 @@ func (this *Controller) DeleteProcessInstance(networkId string, instanceId strin
 	if err != nil {
 		this.config.GetLogger().Error("error", "error", err, "stack", debug.Stack())
 	}
+	if readErr == nil {
+		this.enqueueWardenCheck(networkId, current.BusinessKey)
+	}
 }
 
-func (this *Controller) DeleteUnknownProcessInstances(networkId string, knownIds []string) {
+func (this *Controller) DeleteUnknownProcessInstances(ctx context.Context, networkId string, knownIds []string) {
 	err := this.db.RemoveUnknownProcessInstances(networkId, knownIds)
 	if err != nil {
 		this.config.GetLogger().Error("error", "error", err, "stack", debug.Stack())
@@ -73,7 +79,7 @@ func (this *Controller) ApiReadProcessInstance(networkId string, id string) (res
 	return
 }
 
-func (this *Controller) StopProcessInstanceWithoutWardenHandling(instance model.ProcessInstance) (err error, errCode int) {
+func (this *Controller) StopProcessInstanceWithoutWardenHandling(ctx context.Context, instance model.ProcessInstance) (err error, errCode int) {
 	defer func() {
 		errCode = this.SetErrCode(err)
 	}()
@@ -87,7 +93,7 @@ func (this *Controller) StopProcessInstanceWithoutWardenHandling(instance model.
 			return
 		}
 	} else {
-		err = this.mgw.SendProcessStopCommand(instance.NetworkId, instance.Id)
+		err = this.mgw.SendProcessStopCommand(ctx, instance.NetworkId, instance.Id)
 		if err != nil {
 			return
 		}
@@ -97,7 +103,7 @@ func (this *Controller) StopProcessInstanceWithoutWardenHandling(instance model.
 	return
 }
 
-func (this *Controller) ApiDeleteProcessInstance(networkId string, id string) (err error, errCode int) {
+func (this *Controller) ApiDeleteProcessInstance(ctx context.Context, networkId string, id string) (err error, errCode int) {
 	current, err := this.db.ReadProcessInstance(networkId, id)
 	if err != nil && !errors.Is(err, database.ErrNotFound) {
 		return err, this.SetErrCode(err)
@@ -108,7 +114,7 @@ func (this *Controller) ApiDeleteProcessInstance(networkId string, id string) (e
 			return err, this.SetErrCode(err)
 		}
 	}
-	return this.StopProcessInstanceWithoutWardenHandling(current)
+	return this.StopProcessInstanceWithoutWardenHandling(ctx, current)
 }
 
 func (this *Controller) ApiListProcessInstances(networkIds []string, limit int64, offset int64, sort string) (result []model.ProcessInstance, err error, errCode int) {
@@ -0,0 +1,167 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/SENERGY-Platform/process-sync/pkg/configuration"
+	"github.com/SENERGY-Platform/process-sync/pkg/model"
+)
+
+const retentionBatchSize = 1000
+
+type retentionPolicy struct {
+	entity       string
+	maxAge       time.Duration
+	networkIds   []string //nil for the global policy of the entity
+	excludeIds   []string //networks with their own policy of the entity
+	deleteOnEdge bool
+}
+
+func parseRetentionPolicies(policies []configuration.RetentionPolicy) (result []retentionPolicy, err error) {
+	networkSpecific := map[string][]string{}
+	for _, policy := range policies {
+		if policy.Entity != configuration.RetentionEntityHistory && policy.Entity != configuration.RetentionEntityIncidents {
+			return nil, fmt.Errorf("unknown retention entity %q", policy.Entity)
+		}
+		if policy.DeleteOnEdge && policy.Entity != configuration.RetentionEntityHistory {
+			return nil, fmt.Errorf("retention delete_on_edge is only supported for %q", configuration.RetentionEntityHistory)
+		}
+		maxAge, err := time.ParseDuration(policy.MaxAge)
+		if err != nil {
+			return nil, fmt.Errorf("invalid retention max_age %q: %w", policy.MaxAge, err)
+		}
+		if maxAge <= 0 {
+			return nil, errors.New("retention max_age must be positive")
+		}
+		if len(policy.NetworkIds) > 0 {
+			for _, networkId := range policy.NetworkIds {
+				if slices.Contains(networkSpecific[policy.Entity], networkId) {
+					return nil, fmt.Errorf("multiple %q retention policies for network %q", policy.Entity, networkId)
+				}
+				networkSpecific[policy.Entity] = append(networkSpecific[policy.Entity], networkId)
+			}
+		} else {
+			if slices.ContainsFunc(result, func(e retentionPolicy) bool { return e.entity == policy.Entity && e.networkIds == nil }) {
+				return nil, fmt.Errorf("multiple %q retention policies without network_ids", policy.Entity)
+			}
+		}
+		result = append(result, retentionPolicy{
+			entity:       policy.Entity,
+			maxAge:       maxAge,
+			networkIds:   slices.Clone(policy.NetworkIds),
+			deleteOnEdge: policy.DeleteOnEdge,
+		})
+	}
+	for i, policy := range result {
+		if policy.networkIds == nil {
+			result[i].excludeIds = networkSpecific[policy.entity]
+		}
+	}
+	return result, nil
+}
+
+// ApplyRetentionPolicies removes the finished history and incidents exceeding the max age of the configured retention policies;
+// the policies are applied under the RetentionLease, so that replicas never remove documents or send delete commands to the mgw concurrently
+func (this *Controller) ApplyRetentionPolicies(ctx context.Context) (err error) {
+	ran, err := this.runLeased(ctx, RetentionLease, this.applyRetentionPolicies)
+	if err == nil && !ran {
+		this.logger.Debug("retention skipped, another replica holds the retention lease")
+	}
+	return err
+}
+
+func (this *Controller) applyRetentionPolicies(ctx context.Context) (err error) {
+	now := configuration.TimeNow()
+	for _, policy := range this.retentionPolicies {
+		if ctx.Err() != nil {
+			return context.Cause(ctx)
+		}
+		scope := model.RetentionScope{
+			NetworkIds:        policy.networkIds,
+			ExcludeNetworkIds: policy.excludeIds,
+			Before:            now.Add(-policy.maxAge),
+		}
+		if policy.entity == configuration.RetentionEntityIncidents {
+			scope.SkipRunningInstances = true
+		}
+		var removed int64
+		switch {
+		case policy.entity == configuration.RetentionEntityIncidents && this.archive != nil:
+			removed, err = this.archiveAndRemoveIncidents(ctx, scope)
+		case policy.entity == configuration.RetentionEntityIncidents:
+			removed, err = this.db.RemoveIncidentsInScope(scope)
+		case policy.deleteOnEdge:
+			scope.SkipMarkedForDelete = true
+			removed, err = this.removeHistoryOnEdge(ctx, scope)
+		case this.archive != nil:
+			removed, err = this.archiveAndRemoveHistory(ctx, scope)
+		default:
+			removed, err = this.db.RemoveHistoricProcessInstancesInScope(scope)
+		}
+		if err != nil {
+			return fmt.Errorf("unable to apply %v retention policy: %w", policy.entity, err)
+		}
+		if removed > 0 {
+			this.logger.Info("applied retention policy", "entity", policy.entity, "network_ids", policy.networkIds, "max_age", policy.maxAge.String(), "removed", removed)
+		}
+	}
+	return nil
+}
+
+// removeHistoryOnEdge sends delete commands to the mgw and marks the instances for delete, like ApiDeleteHistoricProcessInstance;
+// the local documents are removed when the mgw reports the deletion; with configured archive, the instances are archived before
+func (this *Controller) removeHistoryOnEdge(ctx context.Context, scope model.RetentionScope) (count int64, err error) {
+	for {
+		if ctx.Err() != nil {
+			return count, context.Cause(ctx)
+		}
+		batch, err := this.db.FindHistoricProcessInstancesInScope(scope, retentionBatchSize)
+		if err != nil {
+			return count, err
+		}
+		if this.archive != nil && len(batch) > 0 {
+			err = this.archive.ArchiveHistory(ctx, batch)
+			if err != nil {
+				return count, err
+			}
+		}
+		for _, instance := range batch {
+			if ctx.Err() != nil {
+				return count, context.Cause(ctx)
+			}
+			err = this.mgw.SendProcessHistoryDeleteCommand(ctx, instance.NetworkId, instance.Id)
+			if err != nil {
+				return count, err
+			}
+			instance.MarkedForDelete = true
+			err = this.db.SaveHistoricProcessInstance(instance)
+			if err != nil {
+				return count, err
+			}
+			count++
+		}
+		if len(batch) < retentionBatchSize {
+			return count, nil
+		}
+	}
+}
@@ -0,0 +1,200 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"strings"
+
+	"github.com/SENERGY-Platform/process-sync/pkg/configuration"
+	"github.com/SENERGY-Platform/process-sync/pkg/database"
+	"github.com/SENERGY-Platform/process-sync/pkg/model"
+	"github.com/SENERGY-Platform/process-sync/pkg/security"
+)
+
+var defaultOperationRights = map[string]string{
+	configuration.OperationRead:   "r",
+	configuration.OperationStart:  "x",
+	configuration.OperationStop:   "x",
+	configuration.OperationDeploy: "w",
+	configuration.OperationDelete: "a",
+	configuration.OperationAdmin:  "a",
+}
+
+var ErrDeploymentOwnerProtection = errors.New("deployment is protected by its owner")
+
+func parseOperationRights(config map[string]string) (result map[string]string, err error) {
+	result = maps.Clone(defaultOperationRights)
+	for operation, rights := range config {
+		if _, ok := defaultOperationRights[operation]; !ok {
+			return nil, fmt.Errorf("unknown operation %q in operation_rights", operation)
+		}
+		if rights == "" || strings.Trim(rights, "rwxa") != "" {
+			return nil, fmt.Errorf("invalid operation_rights %q for %q: expect a combination of r, w, x and a", rights, operation)
+		}
+		result[operation] = rights
+	}
+	return result, nil
+}
+
+func (this *Controller) rightsOfOperation(operation string) (rights string, err error) {
+	rights, ok := this.operationRights[operation]
+	if !ok {
+		return "", fmt.Errorf("unknown operation %q", operation)
+	}
+	return rights, nil
+}
+
+// ApiCheckDeploymentOwner returns ErrDeploymentOwnerProtection if deployment_owner_protection is enabled
+// and the user is neither the owner of one of the deployments nor has the admin operation rights on the network;
+// deployments without known owner are not protected
+func (this *Controller) ApiCheckDeploymentOwner(request *http.Request, networkId string, deploymentIds ...string) (err error, errCode int) {
+	if !this.config.DeploymentOwnerProtection {
+		return nil, http.StatusOK
+	}
+	userId := security.ContextUserId(request.Context(), request.Header.Get("Authorization"))
+	for _, deploymentId := range deploymentIds {
+		info, exists, err := this.db.GetDeploymentWardenInfoByDeploymentId(networkId, deploymentId)
+		if err != nil {
+			return err, this.SetErrCode(err)
+		}
+		if !exists || info.Owner == "" || info.Owner == userId {
+			continue
+		}
+		err, errCode = this.ApiCheckAccess(request, networkId, configuration.OperationAdmin)
+		if errCode == http.StatusForbidden {
+			return ErrDeploymentOwnerProtection, http.StatusForbidden
+		}
+		return err, errCode //admins may access all deployments of the network
+	}
+	return nil, http.StatusOK
+}
+
+// ApiCheckProcessInstanceOwner is ApiCheckDeploymentOwner for the deployment of the process instance
+func (this *Controller) ApiCheckProcessInstanceOwner(request *http.Request, networkId string, instanceId string) (err error, errCode int) {
+	if !this.config.DeploymentOwnerProtection {
+		return nil, http.StatusOK
+	}
+	instance, err := this.db.ReadProcessInstance(networkId, instanceId)
+	if errors.Is(err, database.ErrNotFound) {
+		return nil, http.StatusOK
+	}
+	if err != nil {
+		return err, this.SetErrCode(err)
+	}
+	return this.checkProcessInstancesOwner(request, networkId, []model.ProcessInstance{instance})
+}
+
+// ApiCheckBusinessKeyOwner is ApiCheckDeploymentOwner for the deployments of the process instances with the business key
+func (this *Controller) ApiCheckBusinessKeyOwner(request *http.Request, networkId string, businessKey string) (err error, errCode int) {
+	if !this.config.DeploymentOwnerProtection {
+		return nil, http.StatusOK
+	}
+	instances, err := this.findProcessInstancesByBusinessKey(networkId, businessKey)
+	if err != nil {
+		return err, this.SetErrCode(err)
+	}
+	return this.checkProcessInstancesOwner(request, networkId, instances)
+}
+
+// ApiCheckIncidentOwner is ApiCheckDeploymentOwner for the deployment of the process definition of the incident;
+// the definition of the incident is used, so that incidents of finished process instances stay protected
+func (this *Controller) ApiCheckIncidentOwner(request *http.Request, networkId string, incidentId string) (err error, errCode int) {
+	if !this.config.DeploymentOwnerProtection {
+		return nil, http.StatusOK
+	}
+	incident, err := this.db.ReadIncident(networkId, incidentId)
+	if errors.Is(err, database.ErrNotFound) {
+		return nil, http.StatusOK
+	}
+	if err != nil {
+		return err, this.SetErrCode(err)
+	}
+	return this.checkProcessDefinitionsOwner(request, networkId, incident.ProcessDefinitionId)
+}
+
+// ApiCheckHistoricProcessInstanceOwner is ApiCheckDeploymentOwner for the deployment of the process definition of the historic process instance
+func (this *Controller) ApiCheckHistoricProcessInstanceOwner(request *http.Request, networkId string, instanceId string) (err error, errCode int) {
+	if !this.config.DeploymentOwnerProtection {
+		return nil, http.StatusOK
+	}
+	instance, err := this.db.ReadHistoricProcessInstance(networkId, instanceId)
+	if errors.Is(err, database.ErrNotFound) {
+		return nil, http.StatusOK
+	}
+	if err != nil {
+		return err, this.SetErrCode(err)
+	}
+	return this.checkProcessDefinitionsOwner(request, networkId, instance.ProcessDefinitionId)
+}
+
+// ApiCheckScheduleOwner is ApiCheckDeploymentOwner for the deployment of the stored schedule; unknown schedules are not protected
+func (this *Controller) ApiCheckScheduleOwner(request *http.Request, networkId string, scheduleId string) (err error, errCode int) {
+	if !this.config.DeploymentOwnerProtection {
+		return nil, http.StatusOK
+	}
+	schedule, err := this.db.ReadSchedule(networkId, scheduleId)
+	if errors.Is(err, database.ErrNotFound) {
+		return nil, http.StatusOK
+	}
+	if err != nil {
+		return err, this.SetErrCode(err)
+	}
+	return this.ApiCheckDeploymentOwner(request, networkId, schedule.DeploymentId)
+}
+
+// ApiCheckWardenInfoOwner is ApiCheckDeploymentOwner for the deployment of the warden info; unknown warden infos are not protected
+func (this *Controller) ApiCheckWardenInfoOwner(request *http.Request, networkId string, businessKey string) (err error, errCode int) {
+	if !this.config.DeploymentOwnerProtection {
+		return nil, http.StatusOK
+	}
+	info, err := this.readWardenInfo(networkId, businessKey)
+	if errors.Is(err, database.ErrNotFound) {
+		return nil, http.StatusOK
+	}
+	if err != nil {
+		return err, this.SetErrCode(err)
+	}
+	return this.ApiCheckDeploymentOwner(request, networkId, info.ProcessDeploymentId)
+}
+
+func (this *Controller) checkProcessInstancesOwner(request *http.Request, networkId string, instances []model.ProcessInstance) (err error, errCode int) {
+	definitionIds := []string{}
+	for _, instance := range instances {
+		definitionIds = append(definitionIds, instance.DefinitionId)
+	}
+	return this.checkProcessDefinitionsOwner(request, networkId, definitionIds...)
+}
+
+// checkProcessDefinitionsOwner is ApiCheckDeploymentOwner for the deployments of the process definitions; unknown definitions are not protected
+func (this *Controller) checkProcessDefinitionsOwner(request *http.Request, networkId string, definitionIds ...string) (err error, errCode int) {
+	deploymentIds := []string{}
+	for _, definitionId := range definitionIds {
+		definition, err := this.db.ReadProcessDefinition(networkId, definitionId)
+		if errors.Is(err, database.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return err, this.SetErrCode(err)
+		}
+		deploymentIds = append(deploymentIds, definition.DeploymentId)
+	}
+	return this.ApiCheckDeploymentOwner(request, networkId, deploymentIds...)
+}
@@ -0,0 +1,128 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/SENERGY-Platform/process-sync/pkg/configuration"
+	"github.com/SENERGY-Platform/process-sync/pkg/database"
+	"github.com/SENERGY-Platform/process-sync/pkg/model"
+	"github.com/SENERGY-Platform/process-sync/pkg/model/camundamodel"
+	"github.com/SENERGY-Platform/process-sync/pkg/security"
+)
+
+func TestParseOperationRights(t *testing.T) {
+	rights, err := parseOperationRights(map[string]string{configuration.OperationStop: "a", configuration.OperationRead: "rx"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]string{
+		configuration.OperationRead:   "rx",
+		configuration.OperationStart:  "x",
+		configuration.OperationStop:   "a",
+		configuration.OperationDeploy: "w",
+		configuration.OperationDelete: "a",
+		configuration.OperationAdmin:  "a",
+	}
+	for operation, expectedRights := range expected {
+		if rights[operation] != expectedRights {
+			t.Error(operation, rights[operation], expectedRights)
+		}
+	}
+	if defaultOperationRights[configuration.OperationStop] != "x" {
+		t.Error("defaults must not be modified")
+	}
+
+	for _, invalid := range []map[string]string{
+		{"unknown": "r"},
+		{configuration.OperationRead: ""},
+		{configuration.OperationRead: "read"},
+	} {
+		_, err = parseOperationRights(invalid)
+		if err == nil {
+			t.Error("expected error", invalid)
+		}
+	}
+}
+
+// ownerDb stores one incident and one historic instance of definition d1, which belongs to the deployment of owner
+type ownerDb struct {
+	database.Database
+}
+
+func (this *ownerDb) ReadIncident(networkId string, incidentId string) (model.Incident, error) {
+	return model.Incident{Incident: camundamodel.Incident{Id: incidentId, ProcessInstanceId: "finished", ProcessDefinitionId: "d1"}}, nil
+}
+
+func (this *ownerDb) ReadHistoricProcessInstance(networkId string, instanceId string) (model.HistoricProcessInstance, error) {
+	return model.HistoricProcessInstance{HistoricProcessInstance: camundamodel.HistoricProcessInstance{Id: instanceId, ProcessDefinitionId: "d1"}}, nil
+}
+
+func (this *ownerDb) ReadProcessDefinition(networkId string, definitionId string) (model.ProcessDefinition, error) {
+	return model.ProcessDefinition{ProcessDefinition: camundamodel.ProcessDefinition{Id: definitionId, DeploymentId: "dep1"}}, nil
+}
+
+func (this *ownerDb) GetDeploymentWardenInfoByDeploymentId(networkId string, deploymentId string) (model.DeploymentWardenInfo, bool, error) {
+	return model.DeploymentWardenInfo{DeploymentId: deploymentId, Owner: "owner"}, true, nil
+}
+
+// noAdminSecurity denies every permission check
+type noAdminSecurity struct {
+	Security
+}
+
+func (this noAdminSecurity) CheckBool(ctx context.Context, token string, kind string, id string, rights string) (bool, error) {
+	return false, nil
+}
+
+func TestDeleteOwnerChecks(t *testing.T) {
+	ctrl := &Controller{
+		db:              &ownerDb{},
+		security:        noAdminSecurity{},
+		config:          configuration.Config{DeploymentOwnerProtection: true},
+		operationRights: defaultOperationRights,
+	}
+	request := func(userId string) *http.Request {
+		result := httptest.NewRequest(http.MethodDelete, "/", nil)
+		result.Header.Set("Authorization", "Bearer "+userId)
+		ctx := security.ContextWithClaimsHolder(result.Context())
+		security.SetContextClaims(ctx, security.Claims{Token: "Bearer " + userId, UserId: userId})
+		return result.WithContext(ctx)
+	}
+	for name, check := range map[string]func(request *http.Request) (error, int){
+		"incident": func(request *http.Request) (error, int) {
+			return ctrl.ApiCheckIncidentOwner(request, "n1", "i1")
+		},
+		"history": func(request *http.Request) (error, int) {
+			return ctrl.ApiCheckHistoricProcessInstanceOwner(request, "n1", "h1")
+		},
+	} {
+		err, code := check(request("owner"))
+		if err != nil || code != http.StatusOK {
+			t.Error(name, err, code)
+		}
+		err, code = check(request("other"))
+		if !errors.Is(err, ErrDeploymentOwnerProtection) || code != http.StatusForbidden {
+			t.Error(name, err, code)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/SENERGY-Platform/process-sync/pkg/configuration"
+	"github.com/SENERGY-Platform/process-sync/pkg/database"
+	"github.com/SENERGY-Platform/process-sync/pkg/model"
+	"github.com/SENERGY-Platform/process-sync/pkg/scheduler"
+)
+
+func (this *Controller) ApiReadSchedule(networkId string, id string) (result model.Schedule, err error, errCode int) {
+	result, err = this.db.ReadSchedule(networkId, id)
+	errCode = this.SetErrCode(err)
+	return
+}
+
+func (this *Controller) ApiListSchedules(query model.ScheduleQuery) (result []model.Schedule, err error, errCode int) {
+	result, err = this.db.ListSchedules(query)
+	errCode = this.SetErrCode(err)
+	if result == nil {
+		result = []model.Schedule{}
+	}
+	return
+}
+
+func (this *Controller) ApiCreateSchedule(networkId string, schedule model.Schedule) (result model.Schedule, err error, errCode int) {
+	schedule.Id = configuration.Id()
+	schedule.NetworkId = networkId
+	schedule.LastRun = nil
+	return this.saveSchedule(schedule)
+}
+
+// ApiUpdateSchedule replaces the user defined fields of the schedule; the last run status is kept and the next run is recomputed
+func (this *Controller) ApiUpdateSchedule(networkId string, id string, schedule model.Schedule) (result model.Schedule, err error, errCode int) {
+	current, err := this.db.ReadSchedule(networkId, id)
+	if err != nil {
+		return result, err, this.SetErrCode(err)
+	}
+	schedule.Id = current.Id
+	schedule.NetworkId = current.NetworkId
+	schedule.LastRun = current.LastRun
+	schedule.LockedUntil = current.LockedUntil
+	schedule.LockOwner = current.LockOwner
+	return this.saveSchedule(schedule)
+}
+
+func (this *Controller) saveSchedule(schedule model.Schedule) (result model.Schedule, err error, errCode int) {
+	if schedule.MissedRunPolicy == "" {
+		schedule.MissedRunPolicy = model.ScheduleMissedRunSkip
+	}
+	err = scheduler.Validate(schedule)
+	if err != nil {
+		return result, err, this.SetErrCode(err)
+	}
+	_, err = this.db.ReadDeployment(schedule.NetworkId, schedule.DeploymentId)
+	if errors.Is(err, database.ErrNotFound) {
+		return result, errors.New("unknown deployment"), http.StatusBadRequest
+	}
+	if err != nil {
+		return result, err, this.SetErrCode(err)
+	}
+	schedule.NextRun, err = scheduler.NextRun(schedule, configuration.TimeNow())
+	if err != nil {
+		return result, err, this.SetErrCode(err)
+	}
+	err = this.db.SaveSchedule(schedule)
+	if err != nil {
+		return result, err, this.SetErrCode(err)
+	}
+	return schedule, nil, http.StatusOK
+}
+
+func (this *Controller) ApiDeleteSchedule(networkId string, id string) (err error, errCode int) {
+	_, err = this.db.ReadSchedule(networkId, id)
+	if err != nil {
+		return err, this.SetErrCode(err)
+	}
+	err = this.db.RemoveSchedule(networkId, id)
+	return err, this.SetErrCode(err)
+}
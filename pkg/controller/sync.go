@@ -17,6 +17,7 @@
 package controller
 
 import (
+	"context"
 	"errors"
 	"net/http"
 	"slices"
@@ -129,11 +130,11 @@ func (this *Controller) migrateToWarden(networkId string) error {
 				if businessKey == "" {
 					businessKey = "migration_of_" + instance.Id
 				}
-				err, _ = this.ApiStartDeployment(networkId, deployment.Id, businessKey, map[string]interface{}{})
+				err, _ = this.ApiStartDeployment(context.Background(), networkId, deployment.Id, businessKey, map[string]interface{}{})
 				if err != nil {
 					return err
 				}
-				err, _ = this.ApiDeleteProcessInstance(networkId, instance.Id)
+				err, _ = this.ApiDeleteProcessInstance(context.Background(), networkId, instance.Id)
 				if err != nil {
 					this.config.GetLogger().Error("MigrateToWarden(): unable to delete old instance", "error", err, "instanceId", instance.Id)
 					wardenErr := this.warden.RemoveInstanceWardenByBusinessKey(networkId, businessKey) //instance.Id is not stable --> use businessKey --> warden will remove the unneeded instance later
@@ -148,7 +149,7 @@ func (this *Controller) migrateToWarden(networkId string) error {
 	return nil
 }
 
-func (this *Controller) ApiSyncDeployments(networkId string) (error, int) {
+func (this *Controller) ApiSyncDeployments(ctx context.Context, networkId string) (error, int) {
 	err := this.db.RemovePlaceholderDeployments(networkId)
 	if err != nil {
 		return err, http.StatusInternalServerError
@@ -164,7 +165,7 @@ func (this *Controller) ApiSyncDeployments(networkId string) (error, int) {
 		}
 		for _, deployment := range deployments {
 			if deployment.SyncInfo.MarkedForDelete {
-				err = this.mgw.SendDeploymentDeleteCommand(networkId, deployment.Id)
+				err = this.mgw.SendDeploymentDeleteCommand(ctx, networkId, deployment.Id)
 				if err != nil {
 					errorList = append(errorList, err)
 					continue
@@ -208,7 +209,7 @@ func (this *Controller) ApiSyncDeployments(networkId string) (error, int) {
 					errorList = append(errorList, err)
 					continue
 				}
-				err = this.mgw.SendDeploymentCommand(networkId, metadata.DeploymentModel)
+				err = this.mgw.SendDeploymentCommand(ctx, networkId, metadata.DeploymentModel)
 				if err != nil {
 					errorList = append(errorList, err)
 					continue
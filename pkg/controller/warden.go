@@ -0,0 +1,146 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/SENERGY-Platform/process-sync/pkg/database"
+	"github.com/SENERGY-Platform/process-sync/pkg/model"
+	"github.com/SENERGY-Platform/process-sync/pkg/warden"
+)
+
+// enqueueWardenCheck triggers a debounced warden check of the business key; keys of instances without intended warden handling are ignored
+func (this *Controller) enqueueWardenCheck(networkId string, businessKey string) {
+	if !strings.HasPrefix(businessKey, model.WardenBusinessKeyPrefix) {
+		return
+	}
+	this.warden.EnqueueCheck(warden.CheckRequest{NetworkId: networkId, BusinessKey: businessKey})
+}
+
+func (this *Controller) ApiListWardenInfo(networkId string, deploymentIds []string, limit int64, offset int64) (result []model.WardenInfoWithStatus, err error, errCode int) {
+	query := model.WardenInfoQuery{
+		NetworkIds: []string{networkId},
+		Limit:      limit,
+		Offset:     offset,
+	}
+	if len(deploymentIds) > 0 {
+		query.ProcessDeploymentIds = deploymentIds
+	}
+	infos, err := this.db.FindWardenInfo(query)
+	if err != nil {
+		return result, err, this.SetErrCode(err)
+	}
+	result = []model.WardenInfoWithStatus{}
+	for _, info := range infos {
+		status, err := this.warden.Status(info)
+		if err != nil {
+			return result, err, this.SetErrCode(err)
+		}
+		result = append(result, model.WardenInfoWithStatus{WardenInfo: info, Status: status})
+	}
+	return result, nil, http.StatusOK
+}
+
+func (this *Controller) readWardenInfo(networkId string, businessKey string) (info model.WardenInfo, err error) {
+	infos, err := this.db.FindWardenInfo(model.WardenInfoQuery{
+		NetworkIds:   []string{networkId},
+		BusinessKeys: []string{this.warden.MarkInstanceBusinessKeyAsWardenHandled(businessKey)},
+		Limit:        1,
+	})
+	if err != nil {
+		return info, err
+	}
+	if len(infos) == 0 {
+		return info, database.ErrNotFound
+	}
+	return infos[0], nil
+}
+
+func (this *Controller) ApiReadWardenInfo(networkId string, businessKey string) (result model.WardenInfoWithStatus, err error, errCode int) {
+	info, err := this.readWardenInfo(networkId, businessKey)
+	if err != nil {
+		return result, err, this.SetErrCode(err)
+	}
+	status, err := this.warden.Status(info)
+	if err != nil {
+		return result, err, this.SetErrCode(err)
+	}
+	return model.WardenInfoWithStatus{WardenInfo: info, Status: status}, nil, http.StatusOK
+}
+
+// ApiCheckWardenInfo runs the warden check for one entry (even if it is paused) on the replica holding the warden db loop lease and returns the resulting state;
+// if another replica holds the lease, the check is queued for it and the current state is returned with http.StatusAccepted
+func (this *Controller) ApiCheckWardenInfo(ctx context.Context, networkId string, businessKey string) (result model.WardenInfoWithStatus, err error, errCode int) {
+	info, err := this.readWardenInfo(networkId, businessKey)
+	if err != nil {
+		return result, err, this.SetErrCode(err)
+	}
+	err = this.warden.CheckWardenInfoExclusive(ctx, info, warden.CheckRequest{NetworkId: info.NetworkId, BusinessKey: info.BusinessKey})
+	if errors.Is(err, warden.ErrCheckQueued) {
+		result, err, errCode = this.ApiReadWardenInfo(networkId, businessKey)
+		if err != nil {
+			return result, err, errCode
+		}
+		return result, nil, http.StatusAccepted
+	}
+	if err != nil {
+		return result, err, this.SetErrCode(err)
+	}
+	result, err, errCode = this.ApiReadWardenInfo(networkId, businessKey)
+	if errors.Is(err, database.ErrNotFound) {
+		return model.WardenInfoWithStatus{WardenInfo: info, Status: warden.StatusRemoved}, nil, http.StatusOK
+	}
+	return result, err, errCode
+}
+
+// ApiSetDeploymentWardenPaused pauses or resumes the warden handling of the deployment and its process instances
+func (this *Controller) ApiSetDeploymentWardenPaused(networkId string, deploymentId string, paused bool) (err error, errCode int) {
+	_, err = this.db.ReadDeployment(networkId, deploymentId)
+	if err != nil {
+		return err, this.SetErrCode(err)
+	}
+	err = this.db.SetWardenPaused(networkId, deploymentId, paused)
+	return err, this.SetErrCode(err)
+}
+
+func (this *Controller) ApiRemoveWardenInfo(networkId string, businessKey string) (err error, errCode int) {
+	info, err := this.readWardenInfo(networkId, businessKey)
+	if err != nil {
+		return err, this.SetErrCode(err)
+	}
+	err = this.warden.RemoveInstanceWarden(info)
+	return err, this.SetErrCode(err)
+}
+
+func (this *Controller) ApiListWardenDecisions(networkId string, query model.WardenDecisionQuery) (result []model.WardenDecision, err error, errCode int) {
+	query.NetworkIds = []string{networkId}
+	for i, key := range query.BusinessKeys {
+		query.BusinessKeys[i] = this.warden.MarkInstanceBusinessKeyAsWardenHandled(key)
+	}
+	result, err = this.db.ListWardenDecisions(query)
+	if err != nil {
+		return result, err, this.SetErrCode(err)
+	}
+	if result == nil {
+		result = []model.WardenDecision{}
+	}
+	return result, nil, http.StatusOK
+}
@@ -38,6 +38,10 @@ type Database interface {
 	RemoveUnknownHistoricProcessInstances(networkId string, knownIds []string) error
 	ReadHistoricProcessInstance(networkId string, historicProcessInstanceId string) (historicProcessInstance model.HistoricProcessInstance, err error)
 	ListHistoricProcessInstances(networkIds []string, query model.HistoryQuery, limit int64, offset int64, sort string) (historicProcessInstance []model.HistoricProcessInstance, total int64, err error)
+	AnalyseHistoricProcessInstances(query model.HistoryAnalyticsQuery) ([]model.HistoryAnalytics, error)
+	BackfillHistoricProcessInstanceDates() (count int, err error)
+	FindHistoricProcessInstancesInScope(scope model.RetentionScope, limit int64) ([]model.HistoricProcessInstance, error)
+	RemoveHistoricProcessInstancesInScope(scope model.RetentionScope) (removed int64, err error)
 	FindHistoricProcessInstances(query model.InstanceQuery) (result []model.HistoricProcessInstance, err error)
 
 	SaveProcessInstance(processInstance model.ProcessInstance) error
@@ -57,6 +61,7 @@ type Database interface {
 	RemoveProcessInstancesByDefinitionId(networkId string, processDefinitionId string) error
 
 	SaveIncident(incident model.Incident) (newDocument bool, err error)
+	SetIncidentPendingCommand(networkId string, incidentId string, command *model.IncidentCommand) error
 	RemoveIncident(networkId string, incidentId string) error
 	RemoveUnknownIncidents(networkId string, knownIds []string) error
 	ReadIncident(networkId string, incidentId string) (incident model.Incident, err error)
@@ -66,6 +71,13 @@ type Database interface {
 	RemoveIncidentOfDefinition(networkId string, definitionId string) error
 	RemoveIncidentOfNotInstances(networkId string, notInstanceIds []string) error
 	RemoveIncidentOfNotDefinitions(networkId string, notDefinitionIds []string) error
+	ListIncidentGroups(query model.IncidentGroupQuery) ([]model.IncidentGroup, error)
+	CountIncidentsPerInterval(query model.IncidentStatsQuery) ([]model.IncidentStatsBucket, error)
+	ReadIncidentGroupState(networkId string, groupId string) (state model.IncidentGroupState, exists bool, err error)
+	SetIncidentGroupState(state model.IncidentGroupState) error
+	BackfillIncidentGroupInfo() (count int, err error)
+	FindIncidentsInScope(scope model.RetentionScope, limit int64) ([]model.Incident, error)
+	RemoveIncidentsInScope(scope model.RetentionScope) (removed int64, err error)
 
 	SaveDeploymentMetadata(metadata model.DeploymentMetadata) error
 	RemoveUnknownDeploymentMetadata(networkId string, knownIds []string) error
@@ -81,7 +93,15 @@ type Database interface {
 	FilterNetworkIds(networkIds []string) (result []string, err error)
 	GetOldNetworkIds(maxAge time.Duration) (result []string, err error)
 	ListKnownNetworkIds() (result []string, err error)
+	ListLastContacts() (result []model.LastNetworkContact, err error)
+	ReadLastContact(networkId string) (lastContact model.LastNetworkContact, exists bool, err error)
+	CountNetworkStatus(networkId string, placeholderSyncedBefore time.Time) (result model.NetworkStatusCounts, err error)
 	RemoveOldElements(maxAge time.Duration) (err error)
+	RemoveNetworkSyncState(networkId string) (err error)
+	ListNetworkExportDocuments(networkId string, recordType string, afterId string, limit int64) (documents []interface{}, lastId string, err error)
+	RemoveNetworkWardenInfos(networkId string) (removed int64, err error)
+	PurgeNetwork(networkId string) (err error)
+	RemoveLastContact(networkId string) error
 
 	SetDeploymentWardenInfo(info model.DeploymentWardenInfo) error
 	RemoveDeploymentWardenInfo(networkId string, deploymentId string) error
@@ -91,4 +111,26 @@ type Database interface {
 	SetWardenInfo(info model.WardenInfo) error
 	RemoveWardenInfo(networkId string, businessKey string) error
 	FindWardenInfo(query model.WardenInfoQuery) ([]model.WardenInfo, error)
+	SetWardenPaused(networkId string, deploymentId string, paused bool) error
+
+	SaveWardenDecision(decision model.WardenDecision) error
+	ListWardenDecisions(query model.WardenDecisionQuery) ([]model.WardenDecision, error)
+
+	PushWardenCheck(request model.WardenCheckRequest) error
+	PopDueWardenChecks(now time.Time, limit int64) ([]model.WardenCheckRequest, error)
+
+	SaveAuditEntry(entry model.AuditEntry) error
+	ListAuditEntries(query model.AuditQuery) ([]model.AuditEntry, error)
+
+	SaveSchedule(schedule model.Schedule) error
+	RemoveSchedule(networkId string, id string) error
+	RemoveSchedulesOfDeployment(networkId string, deploymentId string) error
+	ReadSchedule(networkId string, id string) (schedule model.Schedule, err error)
+	ListSchedules(query model.ScheduleQuery) ([]model.Schedule, error)
+	ClaimDueSchedule(now time.Time, lockDuration time.Duration, owner string) (schedule model.Schedule, found bool, err error)
+	FinishScheduleRun(networkId string, id string, owner string, nextRun time.Time, run model.ScheduleRun) error
+
+	TryAcquireLease(name string, holder string, now time.Time, duration time.Duration) (lease model.Lease, acquired bool, err error)
+	ReleaseLease(name string, holder string) error
+	ListLeases() ([]model.Lease, error)
 }
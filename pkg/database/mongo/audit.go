@@ -0,0 +1,201 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mongo
+
+import (
+	"strings"
+
+	"github.com/SENERGY-Platform/process-sync/pkg/configuration"
+	"github.com/SENERGY-Platform/process-sync/pkg/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var auditIdKey string
+var auditTimeKey string
+var auditUserIdKey string
+var auditNetworkIdKey string
+var auditEntityKey string
+var auditEntityIdKey string
+var auditActionKey string
+var auditSuccessKey string
+var auditExpiresAtKey string
+
+func init() {
+	prepareCollection(func(config configuration.Config) string {
+		return config.MongoAuditCollection
+	},
+		model.AuditEntry{},
+		[]KeyMapping{
+			{
+				FieldName: "Id",
+				Key:       &auditIdKey,
+			},
+			{
+				FieldName: "Time",
+				Key:       &auditTimeKey,
+			},
+			{
+				FieldName: "UserId",
+				Key:       &auditUserIdKey,
+			},
+			{
+				FieldName: "NetworkId",
+				Key:       &auditNetworkIdKey,
+			},
+			{
+				FieldName: "Entity",
+				Key:       &auditEntityKey,
+			},
+			{
+				FieldName: "EntityId",
+				Key:       &auditEntityIdKey,
+			},
+			{
+				FieldName: "Action",
+				Key:       &auditActionKey,
+			},
+			{
+				FieldName: "Success",
+				Key:       &auditSuccessKey,
+			},
+			{
+				FieldName: "ExpiresAt",
+				Key:       &auditExpiresAtKey,
+			},
+		},
+		[]IndexDesc{
+			{
+				Name:   "audit_id_index",
+				Unique: true,
+				Asc:    true,
+				Keys:   []*string{&auditIdKey},
+			},
+			{
+				Name:   "audit_time_index",
+				Unique: false,
+				Asc:    true,
+				Keys:   []*string{&auditTimeKey},
+			},
+			{
+				Name:   "audit_networkid_time_index",
+				Unique: false,
+				Asc:    true,
+				Keys:   []*string{&auditNetworkIdKey, &auditTimeKey},
+			},
+			{
+				Name:   "audit_userid_time_index",
+				Unique: false,
+				Asc:    true,
+				Keys:   []*string{&auditUserIdKey, &auditTimeKey},
+			},
+			{
+				Name:       "audit_expiration_index",
+				Asc:        true,
+				Keys:       []*string{&auditExpiresAtKey},
+				IsTTLIndex: true,
+			},
+		},
+	)
+}
+
+func (this *Mongo) auditCollection() *mongo.Collection {
+	return this.client.Database(this.config.MongoTable).Collection(this.config.MongoAuditCollection)
+}
+
+// SaveAuditEntry inserts the entry; audit entries are never updated and only removed by their expiration
+func (this *Mongo) SaveAuditEntry(entry model.AuditEntry) error {
+	ctx, _ := this.getTimeoutContext()
+	_, err := this.auditCollection().InsertOne(ctx, entry)
+	return err
+}
+
+func (this *Mongo) ListAuditEntries(query model.AuditQuery) (result []model.AuditEntry, err error) {
+	opt := options.Find()
+	opt.SetLimit(query.Limit)
+	opt.SetSkip(query.Offset)
+
+	if query.Sort == "" {
+		query.Sort = "time.desc"
+	}
+	parts := strings.Split(query.Sort, ".")
+	sortby := auditTimeKey
+	switch parts[0] {
+	case "time":
+		sortby = auditTimeKey
+	case "user_id":
+		sortby = auditUserIdKey
+	case "network_id":
+		sortby = auditNetworkIdKey
+	case "entity":
+		sortby = auditEntityKey
+	case "action":
+		sortby = auditActionKey
+	}
+	direction := int32(1)
+	if len(parts) > 1 && parts[1] == "desc" {
+		direction = int32(-1)
+	}
+	opt.SetSort(bson.D{{Key: sortby, Value: direction}, {Key: auditIdKey, Value: direction}})
+
+	filter := bson.M{}
+	if query.NetworkIds != nil {
+		filter[auditNetworkIdKey] = bson.M{"$in": query.NetworkIds}
+	}
+	if query.UserIds != nil {
+		filter[auditUserIdKey] = bson.M{"$in": query.UserIds}
+	}
+	if query.Entities != nil {
+		filter[auditEntityKey] = bson.M{"$in": query.Entities}
+	}
+	if query.EntityIds != nil {
+		filter[auditEntityIdKey] = bson.M{"$in": query.EntityIds}
+	}
+	if query.Actions != nil {
+		filter[auditActionKey] = bson.M{"$in": query.Actions}
+	}
+	if query.Success != nil {
+		filter[auditSuccessKey] = *query.Success
+	}
+	timeFilter := bson.M{}
+	if !query.Since.IsZero() {
+		timeFilter["$gte"] = query.Since
+	}
+	if !query.Until.IsZero() {
+		timeFilter["$lt"] = query.Until
+	}
+	if len(timeFilter) > 0 {
+		filter[auditTimeKey] = timeFilter
+	}
+
+	ctx, _ := this.getTimeoutContext()
+	cursor, err := this.auditCollection().Find(ctx, filter, opt)
+	if err != nil {
+		return nil, err
+	}
+	for cursor.Next(ctx) {
+		element := model.AuditEntry{}
+		err = cursor.Decode(&element)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, element)
+	}
+	err = cursor.Err()
+	return
+}
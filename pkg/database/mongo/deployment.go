@@ -34,6 +34,9 @@ var deploymentIdKey string
 var deploymentNameKey string
 var deploymentNetworkIdKey string
 var deploymentPlaceholderKey string
+var deploymentMarkedAsMissingKey string
+var deploymentMarkedForDeleteKey string
+var deploymentSyncDateKey string
 
 func init() {
 	prepareCollection(func(config configuration.Config) string {
@@ -57,6 +60,18 @@ func init() {
 				FieldName: "SyncInfo.IsPlaceholder",
 				Key:       &deploymentPlaceholderKey,
 			},
+			{
+				FieldName: "SyncInfo.MarkedAsMissing",
+				Key:       &deploymentMarkedAsMissingKey,
+			},
+			{
+				FieldName: "SyncInfo.MarkedForDelete",
+				Key:       &deploymentMarkedForDeleteKey,
+			},
+			{
+				FieldName: "SyncInfo.SyncDate",
+				Key:       &deploymentSyncDateKey,
+			},
 		},
 		[]IndexDesc{
 			{
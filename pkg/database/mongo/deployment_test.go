@@ -53,6 +53,12 @@ func TestDeployment(t *testing.T) {
 		MongoLastNetworkContactCollection: "last_network_collection",
 		MongoWardenCollection:             "warden",
 		MongoDeploymentWardenCollection:   "deployment_warden",
+		MongoScheduleCollection:           "schedules",
+		MongoLeaseCollection:              "leases",
+		MongoWardenDecisionCollection:     "warden_decisions",
+		MongoAuditCollection:              "audit",
+		MongoIncidentGroupCollection:      "incident_groups",
+		MongoWardenCheckCollection:        "warden_checks",
 	}
 
 	db, err := New(config)
@@ -214,6 +220,12 @@ func TestDeploymentSearch(t *testing.T) {
 		MongoLastNetworkContactCollection: "last_network_collection",
 		MongoWardenCollection:             "warden",
 		MongoDeploymentWardenCollection:   "deployment_warden",
+		MongoScheduleCollection:           "schedules",
+		MongoLeaseCollection:              "leases",
+		MongoWardenDecisionCollection:     "warden_decisions",
+		MongoAuditCollection:              "audit",
+		MongoIncidentGroupCollection:      "incident_groups",
+		MongoWardenCheckCollection:        "warden_checks",
 	}
 
 	db, err := New(config)
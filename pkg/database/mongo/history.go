@@ -20,6 +20,7 @@ import (
 	"errors"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/SENERGY-Platform/process-sync/pkg/configuration"
 	"github.com/SENERGY-Platform/process-sync/pkg/database"
@@ -37,6 +38,11 @@ var historyEndTimeKey string
 var historyProcessDefinitionKey string
 var historyNameKey string
 var historyBusinessKeyKey string
+var historyStateKey string
+var historyDurationKey string
+var historyStartDateKey string
+var historyEndDateKey string
+var historyMarkedForDeleteKey string
 
 func init() {
 	prepareCollection(func(config configuration.Config) string {
@@ -72,6 +78,26 @@ func init() {
 				FieldName: "HistoricProcessInstance.ProcessDefinitionName",
 				Key:       &historyNameKey,
 			},
+			{
+				FieldName: "HistoricProcessInstance.State",
+				Key:       &historyStateKey,
+			},
+			{
+				FieldName: "HistoricProcessInstance.DurationInMillis",
+				Key:       &historyDurationKey,
+			},
+			{
+				FieldName: "StartDate",
+				Key:       &historyStartDateKey,
+			},
+			{
+				FieldName: "EndDate",
+				Key:       &historyEndDateKey,
+			},
+			{
+				FieldName: "SyncInfo.MarkedForDelete",
+				Key:       &historyMarkedForDeleteKey,
+			},
 		},
 		[]IndexDesc{
 			{
@@ -104,6 +130,18 @@ func init() {
 				Asc:    true,
 				Keys:   []*string{&historyProcessDefinitionKey},
 			},
+			{
+				Name:   "history_network_start_date_index",
+				Unique: false,
+				Asc:    true,
+				Keys:   []*string{&historyNetworkIdKey, &historyStartDateKey},
+			},
+			{
+				Name:   "history_network_end_date_index",
+				Unique: false,
+				Asc:    true,
+				Keys:   []*string{&historyNetworkIdKey, &historyEndDateKey},
+			},
 			{
 				Name:        "history_search_name_index",
 				Keys:        []*string{&historyNameKey},
@@ -119,8 +157,10 @@ func (this *Mongo) processHistoryCollection() *mongo.Collection {
 	return this.client.Database(this.config.MongoTable).Collection(this.config.MongoProcessHistoryCollection)
 }
 
+// SaveHistoricProcessInstance stores the instance with StartDate and EndDate parsed from StartTime and EndTime
 func (this *Mongo) SaveHistoricProcessInstance(historicProcessInstance model.HistoricProcessInstance) error {
 	ctx, _ := this.getTimeoutContext()
+	historicProcessInstance = historicProcessInstance.WithParsedDates()
 	_, err := this.processHistoryCollection().ReplaceOne(
 		ctx,
 		bson.M{
@@ -186,12 +226,22 @@ func (this *Mongo) ListHistoricProcessInstances(networkIds []string, query model
 	switch parts[0] {
 	case "id":
 		sortby = historyIdKey
+	case "start_time":
+		sortby = historyStartDateKey
+	case "end_time":
+		sortby = historyEndDateKey
+	case "duration":
+		sortby = historyDurationKey
 	}
 	direction := int32(1)
 	if len(parts) > 1 && parts[1] == "desc" {
 		direction = int32(-1)
 	}
-	opt.SetSort(bson.D{{sortby, direction}})
+	if sortby == historyIdKey {
+		opt.SetSort(bson.D{{sortby, direction}})
+	} else {
+		opt.SetSort(bson.D{{Key: sortby, Value: direction}, {Key: historyIdKey, Value: direction}})
+	}
 
 	ctx, _ := this.getTimeoutContext()
 
@@ -216,6 +266,16 @@ func (this *Mongo) ListHistoricProcessInstances(networkIds []string, query model
 		filter[historyNameKey] = primitive.Regex{Pattern: regexp.QuoteMeta(query.Search), Options: "i"}
 	}
 
+	if timeRange := dateRangeFilter(query.StartedAfter, query.StartedBefore); timeRange != nil {
+		filter[historyStartDateKey] = timeRange
+	}
+	if timeRange := dateRangeFilter(query.EndedAfter, query.EndedBefore); timeRange != nil {
+		filter[historyEndDateKey] = timeRange
+	}
+	if durationFilter := historyDurationFilter(query); len(durationFilter) > 0 {
+		filter["$and"] = durationFilter
+	}
+
 	collection := this.processHistoryCollection()
 	total, err = collection.CountDocuments(ctx, filter)
 	if err != nil {
@@ -292,3 +352,124 @@ func (this *Mongo) RemovePlaceholderHistoricProcessInstances(networkId string) e
 		})
 	return err
 }
+
+func dateRangeFilter(from time.Time, to time.Time) bson.M {
+	if from.IsZero() && to.IsZero() {
+		return nil
+	}
+	result := bson.M{}
+	if !from.IsZero() {
+		result["$gte"] = from
+	}
+	if !to.IsZero() {
+		result["$lt"] = to
+	}
+	return result
+}
+
+// historyDurationFilter compares finished instances by duration and unfinished instances by their start date
+func historyDurationFilter(query model.HistoryQuery) (result bson.A) {
+	now := query.Now
+	if now.IsZero() {
+		now = configuration.TimeNow()
+	}
+	if query.MinDuration > 0 {
+		result = append(result, bson.M{"$or": bson.A{
+			bson.M{historyEndTimeKey: bson.M{"$ne": ""}, historyDurationKey: bson.M{"$gte": query.MinDuration.Milliseconds()}},
+			bson.M{historyEndTimeKey: "", historyStartDateKey: bson.M{"$lte": now.Add(-query.MinDuration)}},
+		}})
+	}
+	if query.MaxDuration > 0 {
+		result = append(result, bson.M{"$or": bson.A{
+			bson.M{historyEndTimeKey: bson.M{"$ne": ""}, historyDurationKey: bson.M{"$lte": query.MaxDuration.Milliseconds()}},
+			bson.M{historyEndTimeKey: "", historyStartDateKey: bson.M{"$gte": now.Add(-query.MaxDuration)}},
+		}})
+	}
+	return result
+}
+
+// BackfillHistoricProcessInstanceDates sets StartDate and EndDate of instances stored before the introduction of these fields;
+// unparsable times are stored as null, so that every instance is handled once
+func (this *Mongo) BackfillHistoricProcessInstanceDates() (count int, err error) {
+	filter := bson.M{"$or": bson.A{
+		bson.M{historyStartDateKey: bson.M{"$exists": false}},
+		bson.M{historyEndDateKey: bson.M{"$exists": false}},
+	}}
+	for {
+		batch, err := this.findHistoricProcessInstanceBatch(filter)
+		if err != nil {
+			return count, err
+		}
+		if len(batch) == 0 {
+			return count, nil
+		}
+		for _, element := range batch {
+			element = element.WithParsedDates()
+			ctx, _ := this.getTimeoutContext()
+			_, err = this.processHistoryCollection().UpdateOne(ctx, bson.M{
+				historyIdKey:        element.Id,
+				historyNetworkIdKey: element.NetworkId,
+			}, bson.M{"$set": bson.M{historyStartDateKey: element.StartDate, historyEndDateKey: element.EndDate}})
+			if err != nil {
+				return count, err
+			}
+			count++
+		}
+	}
+}
+
+const backfillBatchSize = 1000
+
+func (this *Mongo) findHistoricProcessInstanceBatch(filter bson.M) (result []model.HistoricProcessInstance, err error) {
+	ctx, _ := this.getTimeoutContext()
+	cursor, err := this.processHistoryCollection().Find(ctx, filter, options.Find().SetLimit(backfillBatchSize))
+	if err != nil {
+		return nil, err
+	}
+	err = cursor.All(ctx, &result)
+	return result, err
+}
+
+func retentionNetworkFilter(scope model.RetentionScope) interface{} {
+	switch {
+	case scope.NetworkIds != nil:
+		return bson.M{"$in": scope.NetworkIds}
+	case len(scope.ExcludeNetworkIds) > 0:
+		return bson.M{"$nin": scope.ExcludeNetworkIds}
+	default:
+		return bson.M{"$exists": true}
+	}
+}
+
+func historyRetentionFilter(scope model.RetentionScope) bson.M {
+	filter := bson.M{historyNetworkIdKey: retentionNetworkFilter(scope)}
+	if !scope.Before.IsZero() {
+		filter[historyEndDateKey] = bson.M{"$lt": scope.Before}
+	}
+	if scope.SkipMarkedForDelete {
+		filter[historyMarkedForDeleteKey] = bson.M{"$ne": true}
+	}
+	return filter
+}
+
+// FindHistoricProcessInstancesInScope returns up to limit instances of the scope
+func (this *Mongo) FindHistoricProcessInstancesInScope(scope model.RetentionScope, limit int64) (result []model.HistoricProcessInstance, err error) {
+	ctx, _ := this.getTimeoutContext()
+	filter := historyRetentionFilter(scope)
+	cursor, err := this.processHistoryCollection().Find(ctx, filter, options.Find().SetLimit(limit))
+	if err != nil {
+		return nil, err
+	}
+	err = cursor.All(ctx, &result)
+	return result, err
+}
+
+// RemoveHistoricProcessInstancesInScope removes the instances of the scope
+func (this *Mongo) RemoveHistoricProcessInstancesInScope(scope model.RetentionScope) (removed int64, err error) {
+	ctx, _ := this.getTimeoutContext()
+	result, err := this.processHistoryCollection().DeleteMany(ctx, historyRetentionFilter(scope))
+	if err != nil {
+		return 0, err
+	}
+	return result.DeletedCount, nil
+}
@@ -18,7 +18,9 @@ package mongo
 
 import (
 	"context"
+	"math"
 	"reflect"
+	"sort"
 	"sync"
 	"testing"
 	"time"
@@ -54,6 +56,12 @@ func TestHistorySearch(t *testing.T) {
 		MongoLastNetworkContactCollection: "last_network_collection",
 		MongoWardenCollection:             "warden",
 		MongoDeploymentWardenCollection:   "deployment_warden",
+		MongoScheduleCollection:           "schedules",
+		MongoLeaseCollection:              "leases",
+		MongoWardenDecisionCollection:     "warden_decisions",
+		MongoAuditCollection:              "audit",
+		MongoIncidentGroupCollection:      "incident_groups",
+		MongoWardenCheckCollection:        "warden_checks",
 	}
 
 	db, err := New(config)
@@ -165,3 +173,314 @@ func testAddHistory(db *Mongo, instance camundamodel.HistoricProcessInstance) fu
 		}
 	}
 }
+
+func TestHistoryAnalytics(t *testing.T) {
+	wg := &sync.WaitGroup{}
+	defer wg.Wait()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mongoPort, _, err := docker.Mongo(ctx, wg)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	db, err := New(configuration.Config{
+		MongoUrl:                          "mongodb://localhost:" + mongoPort,
+		MongoTable:                        "processes",
+		MongoProcessDefinitionCollection:  "process_definition",
+		MongoDeploymentCollection:         "deployments",
+		MongoProcessHistoryCollection:     "histories",
+		MongoIncidentCollection:           "incidents",
+		MongoProcessInstanceCollection:    "instances",
+		MongoDeploymentMetadataCollection: "deployment_metadata",
+		MongoLastNetworkContactCollection: "last_network_collection",
+		MongoWardenCollection:             "warden",
+		MongoDeploymentWardenCollection:   "deployment_warden",
+		MongoScheduleCollection:           "schedules",
+		MongoLeaseCollection:              "leases",
+		MongoWardenDecisionCollection:     "warden_decisions",
+		MongoAuditCollection:              "audit",
+		MongoIncidentGroupCollection:      "incident_groups",
+		MongoWardenCheckCollection:        "warden_checks",
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	err = db.SaveProcessDefinition(model.ProcessDefinition{
+		ProcessDefinition: camundamodel.ProcessDefinition{Id: "def1", DeploymentId: "dep1"},
+		SyncInfo:          model.SyncInfo{NetworkId: "n1"},
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	for _, history := range []camundamodel.HistoricProcessInstance{
+		{Id: "h1", ProcessDefinitionId: "def1", StartTime: "2026-01-01T10:00:00.000+0000", EndTime: "2026-01-01T10:00:01.000+0000", DurationInMillis: 1000, State: model.HistoricProcessInstanceStateCompleted},
+		{Id: "h2", ProcessDefinitionId: "def1", StartTime: "2026-01-01T11:00:00.000+0000", EndTime: "2026-01-01T11:00:02.000+0000", DurationInMillis: 2000, State: model.HistoricProcessInstanceStateCompleted},
+		{Id: "h3", ProcessDefinitionId: "def1", StartTime: "2026-01-01T12:00:00.000+0000", EndTime: "2026-01-01T12:00:04.000+0000", DurationInMillis: 4000, State: model.HistoricProcessInstanceStateExternallyTerminated},
+		{Id: "h4", ProcessDefinitionId: "def1", StartTime: "2026-01-01T13:00:00.000+0000", State: "ACTIVE"},
+		{Id: "h5", ProcessDefinitionId: "def1", StartTime: "2025-12-01T13:00:00.000+0000", EndTime: "2025-12-01T13:00:04.000+0000", DurationInMillis: 4000, State: model.HistoricProcessInstanceStateCompleted},
+	} {
+		err = db.SaveHistoricProcessInstance(model.HistoricProcessInstance{HistoricProcessInstance: history, SyncInfo: model.SyncInfo{NetworkId: "n1"}})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+	}
+	_, err = db.SaveIncident(model.Incident{Incident: camundamodel.Incident{Id: "i1", ProcessInstanceId: "h4", ProcessDefinitionId: "def1"}, SyncInfo: model.SyncInfo{NetworkId: "n1"}})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, groupBy := range []string{model.HistoryAnalyticsGroupByDefinition, model.HistoryAnalyticsGroupByDeployment} {
+		t.Run(groupBy, func(t *testing.T) {
+			result, err := db.AnalyseHistoricProcessInstances(model.HistoryAnalyticsQuery{NetworkIds: []string{"n1"}, GroupBy: groupBy, From: from, To: from.Add(24 * time.Hour)})
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if len(result) != 1 {
+				t.Errorf("%#v", result)
+				return
+			}
+			actual := result[0]
+			if groupBy == model.HistoryAnalyticsGroupByDeployment && actual.DeploymentId != "dep1" {
+				t.Errorf("%#v", actual)
+			}
+			if groupBy == model.HistoryAnalyticsGroupByDefinition && actual.ProcessDefinitionId != "def1" {
+				t.Errorf("%#v", actual)
+			}
+			if actual.Runs != 4 || actual.Finished != 3 || actual.Running != 1 || actual.Completed != 2 || actual.Terminated != 1 || actual.WithIncidents != 1 {
+				t.Errorf("%#v", actual)
+			}
+			if actual.DurationMillis.Min != 1000 || actual.DurationMillis.Max != 4000 || actual.DurationMillis.P50 != 2000 || actual.DurationMillis.P99 != 2000 {
+				t.Errorf("%#v", actual.DurationMillis)
+			}
+		})
+	}
+}
+
+func TestHistoryDurationPercentiles(t *testing.T) {
+	durations := []float64{}
+	for i := 0; i < 10000; i++ {
+		durations = append(durations, float64((i*7919)%10007)*float64(i%13+1))
+	}
+	//same buckets as the aggregation of AnalyseHistoricProcessInstances
+	buckets := map[int64]*historyDurationBucket{}
+	aggregation := historyAnalyticsAggregation{MinDuration: math.Inf(1), Buckets: []historyDurationBucket{{Count: 0}}}
+	for _, duration := range durations {
+		index := int64(math.Floor(math.Log(math.Max(duration, 1)) / math.Log(historyDurationBucketGrowth)))
+		bucket, ok := buckets[index]
+		if !ok {
+			bucket = &historyDurationBucket{Bucket: index, Min: duration, Max: duration}
+			buckets[index] = bucket
+		}
+		bucket.Count++
+		bucket.Min = math.Min(bucket.Min, duration)
+		bucket.Max = math.Max(bucket.Max, duration)
+		aggregation.MinDuration = math.Min(aggregation.MinDuration, duration)
+		aggregation.MaxDuration = math.Max(aggregation.MaxDuration, duration)
+		aggregation.SumDuration += duration
+	}
+	for _, bucket := range buckets {
+		aggregation.Buckets = append(aggregation.Buckets, *bucket)
+	}
+	actual := aggregation.duration()
+
+	sort.Float64s(durations)
+	exact := func(percentile float64) float64 {
+		return durations[int(math.Floor(float64(len(durations)-1)*percentile))]
+	}
+	if actual.Min != durations[0] || actual.Max != durations[len(durations)-1] || actual.Avg != aggregation.SumDuration/float64(len(durations)) {
+		t.Errorf("%#v", actual)
+	}
+	for percentile, value := range map[float64]float64{0.5: actual.P50, 0.9: actual.P90, 0.95: actual.P95, 0.99: actual.P99} {
+		expected := exact(percentile)
+		if math.Abs(value-expected) > expected*(historyDurationBucketGrowth-1) {
+			t.Error(percentile, value, expected)
+		}
+	}
+
+	if (historyAnalyticsAggregation{Buckets: []historyDurationBucket{{Count: 0}}}).duration() != (model.HistoryDurationAnalytics{}) {
+		t.Error("runs without finished instances should have no durations")
+	}
+}
+
+func TestHistoryTimeFilter(t *testing.T) {
+	wg := &sync.WaitGroup{}
+	defer wg.Wait()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mongoPort, _, err := docker.Mongo(ctx, wg)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	db, err := New(configuration.Config{
+		MongoUrl:                          "mongodb://localhost:" + mongoPort,
+		MongoTable:                        "processes",
+		MongoProcessDefinitionCollection:  "process_definition",
+		MongoDeploymentCollection:         "deployments",
+		MongoProcessHistoryCollection:     "histories",
+		MongoIncidentCollection:           "incidents",
+		MongoProcessInstanceCollection:    "instances",
+		MongoDeploymentMetadataCollection: "deployment_metadata",
+		MongoLastNetworkContactCollection: "last_network_collection",
+		MongoWardenCollection:             "warden",
+		MongoDeploymentWardenCollection:   "deployment_warden",
+		MongoScheduleCollection:           "schedules",
+		MongoLeaseCollection:              "leases",
+		MongoWardenDecisionCollection:     "warden_decisions",
+		MongoAuditCollection:              "audit",
+		MongoIncidentGroupCollection:      "incident_groups",
+		MongoWardenCheckCollection:        "warden_checks",
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	for _, history := range []camundamodel.HistoricProcessInstance{
+		{Id: "h1", StartTime: "2026-01-01T10:00:00.000+0000", EndTime: "2026-01-01T10:00:10.000+0000", DurationInMillis: 10000},
+		{Id: "h2", StartTime: "2026-01-02T10:00:00.000+0000", EndTime: "2026-01-02T12:00:00.000+0000", DurationInMillis: 7200000},
+		{Id: "h3", StartTime: "2026-01-03T10:00:00.000+0000", EndTime: ""},
+		{Id: "h4", StartTime: "2026-01-03T12:30:00.000+0000", EndTime: ""},
+	} {
+		err = db.SaveHistoricProcessInstance(model.HistoricProcessInstance{HistoricProcessInstance: history, SyncInfo: model.SyncInfo{NetworkId: "n1"}})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+	}
+
+	now := time.Date(2026, 1, 3, 13, 0, 0, 0, time.UTC)
+	day := func(d int) time.Time {
+		return time.Date(2026, 1, d, 0, 0, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		name     string
+		query    model.HistoryQuery
+		sort     string
+		expected []string
+	}{
+		{name: "started on day 2", query: model.HistoryQuery{StartedAfter: day(2), StartedBefore: day(3)}, sort: "id.asc", expected: []string{"h2"}},
+		{name: "ended before day 2", query: model.HistoryQuery{EndedBefore: day(2)}, sort: "id.asc", expected: []string{"h1"}},
+		{name: "longer than an hour", query: model.HistoryQuery{MinDuration: time.Hour, Now: now}, sort: "id.asc", expected: []string{"h2", "h3"}},
+		{name: "shorter than an hour", query: model.HistoryQuery{MaxDuration: time.Hour, Now: now}, sort: "id.asc", expected: []string{"h1", "h4"}},
+		{name: "unfinished longer than an hour", query: model.HistoryQuery{State: "unfinished", MinDuration: time.Hour, Now: now}, sort: "id.asc", expected: []string{"h3"}},
+		{name: "sort by start time", query: model.HistoryQuery{}, sort: "start_time.desc", expected: []string{"h4", "h3", "h2", "h1"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, _, err := db.ListHistoricProcessInstances([]string{"n1"}, test.query, 100, 0, test.sort)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			actual := []string{}
+			for _, element := range result {
+				actual = append(actual, element.Id)
+			}
+			if !reflect.DeepEqual(actual, test.expected) {
+				t.Errorf("expected %v, got %v", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestHistoryRetention(t *testing.T) {
+	wg := &sync.WaitGroup{}
+	defer wg.Wait()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mongoPort, _, err := docker.Mongo(ctx, wg)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	db, err := New(configuration.Config{
+		MongoUrl:                          "mongodb://localhost:" + mongoPort,
+		MongoTable:                        "processes",
+		MongoProcessDefinitionCollection:  "process_definition",
+		MongoDeploymentCollection:         "deployments",
+		MongoProcessHistoryCollection:     "histories",
+		MongoIncidentCollection:           "incidents",
+		MongoProcessInstanceCollection:    "instances",
+		MongoDeploymentMetadataCollection: "deployment_metadata",
+		MongoLastNetworkContactCollection: "last_network_collection",
+		MongoWardenCollection:             "warden",
+		MongoDeploymentWardenCollection:   "deployment_warden",
+		MongoScheduleCollection:           "schedules",
+		MongoLeaseCollection:              "leases",
+		MongoWardenDecisionCollection:     "warden_decisions",
+		MongoAuditCollection:              "audit",
+		MongoIncidentGroupCollection:      "incident_groups",
+		MongoWardenCheckCollection:        "warden_checks",
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	for _, history := range []model.HistoricProcessInstance{
+		{HistoricProcessInstance: camundamodel.HistoricProcessInstance{Id: "old", StartTime: "2026-01-01T10:00:00.000+0000", EndTime: "2026-01-01T11:00:00.000+0000"}, SyncInfo: model.SyncInfo{NetworkId: "n1"}},
+		{HistoricProcessInstance: camundamodel.HistoricProcessInstance{Id: "running", StartTime: "2026-01-01T10:00:00.000+0000"}, SyncInfo: model.SyncInfo{NetworkId: "n1"}},
+		{HistoricProcessInstance: camundamodel.HistoricProcessInstance{Id: "new", StartTime: "2026-01-05T10:00:00.000+0000", EndTime: "2026-01-05T11:00:00.000+0000"}, SyncInfo: model.SyncInfo{NetworkId: "n1"}},
+		{HistoricProcessInstance: camundamodel.HistoricProcessInstance{Id: "other", StartTime: "2026-01-01T10:00:00.000+0000", EndTime: "2026-01-01T11:00:00.000+0000"}, SyncInfo: model.SyncInfo{NetworkId: "n2"}},
+		{HistoricProcessInstance: camundamodel.HistoricProcessInstance{Id: "marked", StartTime: "2026-01-01T10:00:00.000+0000", EndTime: "2026-01-01T11:00:00.000+0000"}, SyncInfo: model.SyncInfo{NetworkId: "n1", MarkedForDelete: true}},
+	} {
+		err = db.SaveHistoricProcessInstance(history)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+	}
+
+	scope := model.RetentionScope{ExcludeNetworkIds: []string{"n2"}, Before: time.Date(2026, 1, 3, 0, 0, 0, 0, time.UTC), SkipMarkedForDelete: true}
+	found, err := db.FindHistoricProcessInstancesInScope(scope, 10)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(found) != 1 || found[0].Id != "old" {
+		t.Errorf("%#v", found)
+		return
+	}
+
+	scope.SkipMarkedForDelete = false
+	removed, err := db.RemoveHistoricProcessInstancesInScope(scope)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if removed != 2 {
+		t.Error(removed)
+		return
+	}
+
+	remaining, _, err := db.ListHistoricProcessInstances([]string{"n1", "n2"}, model.HistoryQuery{}, 10, 0, "id.asc")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	ids := []string{}
+	for _, e := range remaining {
+		ids = append(ids, e.Id)
+	}
+	if !reflect.DeepEqual(ids, []string{"new", "other", "running"}) {
+		t.Error(ids)
+	}
+}
@@ -0,0 +1,223 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mongo
+
+import (
+	"math"
+	"sort"
+
+	"github.com/SENERGY-Platform/process-sync/pkg/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// historyDurationBucketGrowth is the ratio of the upper to the lower bound of a duration bucket;
+// percentiles are interpolated within their bucket and deviate from the exact nearest rank by less than 2%
+const historyDurationBucketGrowth = 1.02
+
+type historyAnalyticsAggregation struct {
+	Id struct {
+		NetworkId string `bson:"network_id"`
+		Key       string `bson:"key"`
+	} `bson:"_id"`
+	ProcessDefinitionName string                  `bson:"process_definition_name"`
+	Runs                  int64                   `bson:"runs"`
+	Finished              int64                   `bson:"finished"`
+	Completed             int64                   `bson:"completed"`
+	Terminated            int64                   `bson:"terminated"`
+	WithIncidents         int64                   `bson:"with_incidents"`
+	MinDuration           float64                 `bson:"min_duration"`
+	MaxDuration           float64                 `bson:"max_duration"`
+	SumDuration           float64                 `bson:"sum_duration"`
+	Buckets               []historyDurationBucket `bson:"buckets"`
+}
+
+type historyDurationBucket struct {
+	Bucket int64   `bson:"bucket"`
+	Count  int64   `bson:"count"` //finished runs; 0 for the bucket of running instances
+	Min    float64 `bson:"min"`
+	Max    float64 `bson:"max"`
+}
+
+func (this historyAnalyticsAggregation) duration() (result model.HistoryDurationAnalytics) {
+	buckets := []historyDurationBucket{}
+	total := int64(0)
+	for _, bucket := range this.Buckets {
+		if bucket.Count > 0 {
+			buckets = append(buckets, bucket)
+			total += bucket.Count
+		}
+	}
+	if total == 0 {
+		return result
+	}
+	sort.Slice(buckets, func(i, j int) bool {
+		return buckets[i].Bucket < buckets[j].Bucket
+	})
+	result.Min = this.MinDuration
+	result.Max = this.MaxDuration
+	result.Avg = this.SumDuration / float64(total)
+	result.P50 = durationPercentile(buckets, total, 0.5)
+	result.P90 = durationPercentile(buckets, total, 0.9)
+	result.P95 = durationPercentile(buckets, total, 0.95)
+	result.P99 = durationPercentile(buckets, total, 0.99)
+	return result
+}
+
+// durationPercentile returns the duration at the nearest rank (rounded down) of the sorted durations,
+// interpolated linearly between the smallest and largest duration of the bucket containing the rank
+func durationPercentile(buckets []historyDurationBucket, total int64, percentile float64) float64 {
+	rank := int64(math.Floor(float64(total-1) * percentile))
+	for _, bucket := range buckets {
+		if rank < bucket.Count {
+			if bucket.Count == 1 {
+				return bucket.Min
+			}
+			return bucket.Min + (bucket.Max-bucket.Min)*float64(rank)/float64(bucket.Count-1)
+		}
+		rank -= bucket.Count
+	}
+	return 0
+}
+
+// AnalyseHistoricProcessInstances aggregates the historic process instances started in [query.From, query.To)
+// per network and process definition (or deployment); placeholders are ignored
+func (this *Mongo) AnalyseHistoricProcessInstances(query model.HistoryAnalyticsQuery) (result []model.HistoryAnalytics, err error) {
+	filter := bson.M{
+		historyNetworkIdKey:   bson.M{"$in": query.NetworkIds},
+		historyPlaceholderKey: bson.M{"$ne": true},
+		historyStartDateKey:   bson.M{"$gte": query.From, "$lt": query.To},
+	}
+	if query.ProcessDefinitionId != "" {
+		filter[historyProcessDefinitionKey] = query.ProcessDefinitionId
+	}
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+	}
+
+	groupKey := "$" + historyProcessDefinitionKey
+	if query.GroupBy == model.HistoryAnalyticsGroupByDeployment || query.DeploymentId != "" {
+		pipeline = append(pipeline,
+			bson.D{{Key: "$lookup", Value: bson.M{
+				"from": this.config.MongoProcessDefinitionCollection,
+				"let":  bson.M{"network": "$" + historyNetworkIdKey, "definition": "$" + historyProcessDefinitionKey},
+				"pipeline": bson.A{
+					bson.M{"$match": bson.M{"$expr": bson.M{"$and": bson.A{
+						bson.M{"$eq": bson.A{"$" + definitionIdKey, "$$definition"}},
+						bson.M{"$eq": bson.A{"$" + definitionNetworkIdKey, "$$network"}},
+					}}}},
+					bson.M{"$project": bson.M{"deployment": "$" + definitionDeploymentKey}},
+				},
+				"as": "_definition",
+			}}},
+			bson.D{{Key: "$addFields", Value: bson.M{"_deployment": bson.M{"$ifNull": bson.A{bson.M{"$arrayElemAt": bson.A{"$_definition.deployment", 0}}, ""}}}}},
+		)
+		if query.DeploymentId != "" {
+			pipeline = append(pipeline, bson.D{{Key: "$match", Value: bson.M{"_deployment": query.DeploymentId}}})
+		}
+		if query.GroupBy == model.HistoryAnalyticsGroupByDeployment {
+			groupKey = "$_deployment"
+		}
+	}
+
+	finished := bson.M{"$ne": bson.A{"$" + historyEndTimeKey, ""}}
+	count := func(condition interface{}) bson.M {
+		return bson.M{"$sum": bson.M{"$cond": bson.A{condition, 1, 0}}}
+	}
+	finishedDuration := bson.M{"$cond": bson.A{finished, "$" + historyDurationKey, nil}}
+	//log scaled buckets keep the group documents small, regardless of the number of runs
+	bucket := bson.M{"$cond": bson.A{finished, bson.M{"$toLong": bson.M{"$floor": bson.M{"$divide": bson.A{
+		bson.M{"$ln": bson.M{"$max": bson.A{"$" + historyDurationKey, 1}}},
+		math.Log(historyDurationBucketGrowth),
+	}}}}, nil}}
+	sum := func(field string) bson.M {
+		return bson.M{"$sum": "$" + field}
+	}
+	pipeline = append(pipeline,
+		bson.D{{Key: "$lookup", Value: bson.M{
+			"from": this.config.MongoIncidentCollection,
+			"let":  bson.M{"network": "$" + historyNetworkIdKey, "instance": "$" + historyIdKey},
+			"pipeline": bson.A{
+				bson.M{"$match": bson.M{"$expr": bson.M{"$and": bson.A{
+					bson.M{"$eq": bson.A{"$" + incidentNetworkIdKey, "$$network"}},
+					bson.M{"$eq": bson.A{"$" + incidentProcessInstanceIdKey, "$$instance"}},
+				}}}},
+				bson.M{"$limit": 1},
+				bson.M{"$project": bson.M{"_id": 1}},
+			},
+			"as": "_incidents",
+		}}},
+		bson.D{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: bson.D{{Key: "network_id", Value: "$" + historyNetworkIdKey}, {Key: "key", Value: groupKey}, {Key: "bucket", Value: bucket}}},
+			{Key: "process_definition_name", Value: bson.M{"$last": "$" + historyNameKey}},
+			{Key: "runs", Value: bson.M{"$sum": 1}},
+			{Key: "finished", Value: count(finished)},
+			{Key: "completed", Value: count(bson.M{"$eq": bson.A{"$" + historyStateKey, model.HistoricProcessInstanceStateCompleted}})},
+			{Key: "terminated", Value: count(bson.M{"$in": bson.A{"$" + historyStateKey, bson.A{model.HistoricProcessInstanceStateExternallyTerminated, model.HistoricProcessInstanceStateInternallyTerminated}}})},
+			{Key: "with_incidents", Value: count(bson.M{"$gt": bson.A{bson.M{"$size": "$_incidents"}, 0}})},
+			{Key: "min_duration", Value: bson.M{"$min": finishedDuration}},
+			{Key: "max_duration", Value: bson.M{"$max": finishedDuration}},
+			{Key: "sum_duration", Value: bson.M{"$sum": finishedDuration}},
+		}}},
+		bson.D{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: bson.D{{Key: "network_id", Value: "$_id.network_id"}, {Key: "key", Value: "$_id.key"}}},
+			{Key: "process_definition_name", Value: bson.M{"$last": "$process_definition_name"}},
+			{Key: "runs", Value: sum("runs")},
+			{Key: "finished", Value: sum("finished")},
+			{Key: "completed", Value: sum("completed")},
+			{Key: "terminated", Value: sum("terminated")},
+			{Key: "with_incidents", Value: sum("with_incidents")},
+			{Key: "min_duration", Value: bson.M{"$min": "$min_duration"}},
+			{Key: "max_duration", Value: bson.M{"$max": "$max_duration"}},
+			{Key: "sum_duration", Value: sum("sum_duration")},
+			{Key: "buckets", Value: bson.M{"$push": bson.M{"bucket": "$_id.bucket", "count": "$finished", "min": "$min_duration", "max": "$max_duration"}}},
+		}}},
+		bson.D{{Key: "$sort", Value: bson.D{{Key: "_id.network_id", Value: 1}, {Key: "_id.key", Value: 1}}}},
+	)
+
+	ctx, _ := this.getTimeoutContext()
+	cursor, err := this.processHistoryCollection().Aggregate(ctx, pipeline, options.Aggregate().SetAllowDiskUse(true))
+	if err != nil {
+		return nil, err
+	}
+	for cursor.Next(ctx) {
+		element := historyAnalyticsAggregation{}
+		err = cursor.Decode(&element)
+		if err != nil {
+			return nil, err
+		}
+		analytics := model.HistoryAnalytics{
+			NetworkId:             element.Id.NetworkId,
+			ProcessDefinitionName: element.ProcessDefinitionName,
+			Runs:                  element.Runs,
+			Finished:              element.Finished,
+			Completed:             element.Completed,
+			Terminated:            element.Terminated,
+			WithIncidents:         element.WithIncidents,
+			DurationMillis:        element.duration(),
+		}
+		if query.GroupBy == model.HistoryAnalyticsGroupByDeployment {
+			analytics.DeploymentId = element.Id.Key
+		} else {
+			analytics.ProcessDefinitionId = element.Id.Key
+		}
+		result = append(result, analytics.WithRates(query.From, query.To))
+	}
+	err = cursor.Err()
+	return result, err
+}
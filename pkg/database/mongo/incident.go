@@ -28,10 +28,20 @@ import (
 )
 
 var incidentIdKey string
+var incidentIncidentKey string
+var incidentSyncInfoKey string
+var incidentPendingCommandKey string
 var incidentTimeKey string
 var incidentNetworkIdKey string
 var incidentProcessInstanceIdKey string
 var incidentProcessDefinitionIdKey string
+var incidentGroupInfoKey string
+var incidentGroupIdKey string
+var incidentActivityIdKey string
+var incidentErrorMessageKey string
+var incidentNormalizedErrorMessageKey string
+var incidentDeploymentNameKey string
+var incidentTenantIdKey string
 
 func init() {
 	prepareCollection(func(config configuration.Config) string {
@@ -39,6 +49,18 @@ func init() {
 	},
 		model.Incident{},
 		[]KeyMapping{
+			{
+				FieldName: "Incident",
+				Key:       &incidentIncidentKey,
+			},
+			{
+				FieldName: "SyncInfo",
+				Key:       &incidentSyncInfoKey,
+			},
+			{
+				FieldName: "PendingCommand",
+				Key:       &incidentPendingCommandKey,
+			},
 			{
 				FieldName: "Incident.ProcessInstanceId",
 				Key:       &incidentProcessInstanceIdKey,
@@ -59,6 +81,34 @@ func init() {
 				FieldName: "SyncInfo.NetworkId",
 				Key:       &incidentNetworkIdKey,
 			},
+			{
+				FieldName: "IncidentGroupInfo",
+				Key:       &incidentGroupInfoKey,
+			},
+			{
+				FieldName: "IncidentGroupInfo.GroupId",
+				Key:       &incidentGroupIdKey,
+			},
+			{
+				FieldName: "IncidentGroupInfo.NormalizedErrorMessage",
+				Key:       &incidentNormalizedErrorMessageKey,
+			},
+			{
+				FieldName: "Incident.ActivityId",
+				Key:       &incidentActivityIdKey,
+			},
+			{
+				FieldName: "Incident.ErrorMessage",
+				Key:       &incidentErrorMessageKey,
+			},
+			{
+				FieldName: "Incident.DeploymentName",
+				Key:       &incidentDeploymentNameKey,
+			},
+			{
+				FieldName: "Incident.TenantId",
+				Key:       &incidentTenantIdKey,
+			},
 		},
 		[]IndexDesc{
 			{
@@ -85,6 +135,18 @@ func init() {
 				Asc:    true,
 				Keys:   []*string{&incidentIdKey, &incidentNetworkIdKey},
 			},
+			{
+				Name:   "incidentbynetworkandgroup",
+				Unique: false,
+				Asc:    true,
+				Keys:   []*string{&incidentNetworkIdKey, &incidentGroupIdKey},
+			},
+			{
+				Name:   "incidentbynetworkandtime",
+				Unique: false,
+				Asc:    true,
+				Keys:   []*string{&incidentNetworkIdKey, &incidentTimeKey},
+			},
 		},
 	)
 }
@@ -93,20 +155,57 @@ func (this *Mongo) incidentCollection() *mongo.Collection {
 	return this.client.Database(this.config.MongoTable).Collection(this.config.MongoIncidentCollection)
 }
 
+// SaveIncident stores the incident, sync info and group info (derived from the incident);
+// a pending command is kept until the incident is removed or SetIncidentPendingCommand is called
 func (this *Mongo) SaveIncident(incident model.Incident) (newDocument bool, err error) {
 	ctx, _ := this.getTimeoutContext()
-	result, err := this.incidentCollection().ReplaceOne(
+	incident.IncidentGroupInfo = model.NewIncidentGroupInfo(incident.ProcessDefinitionId, incident.ActivityId, incident.ErrorMessage)
+	set := bson.M{
+		incidentIncidentKey:  incident.Incident,
+		incidentSyncInfoKey:  incident.SyncInfo,
+		incidentGroupInfoKey: incident.IncidentGroupInfo,
+	}
+	if incident.PendingCommand != nil {
+		set[incidentPendingCommandKey] = incident.PendingCommand
+	}
+	result, err := this.incidentCollection().UpdateOne(
 		ctx,
 		bson.M{
 			incidentIdKey:        incident.Id,
 			incidentNetworkIdKey: incident.NetworkId,
 		},
-		incident,
-		options.Replace().SetUpsert(true))
+		bson.M{"$set": set},
+		options.Update().SetUpsert(true))
+	if err != nil {
+		return false, err
+	}
 	newDocument = result.MatchedCount == 0
 	return newDocument, err
 }
 
+// SetIncidentPendingCommand sets or (with nil) removes the pending command of the incident
+func (this *Mongo) SetIncidentPendingCommand(networkId string, incidentId string, command *model.IncidentCommand) error {
+	ctx, _ := this.getTimeoutContext()
+	update := bson.M{"$set": bson.M{incidentPendingCommandKey: command}}
+	if command == nil {
+		update = bson.M{"$unset": bson.M{incidentPendingCommandKey: ""}}
+	}
+	result, err := this.incidentCollection().UpdateOne(
+		ctx,
+		bson.M{
+			incidentIdKey:        incidentId,
+			incidentNetworkIdKey: networkId,
+		},
+		update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return database.ErrNotFound
+	}
+	return nil
+}
+
 func (this *Mongo) RemoveIncident(networkId string, incidentId string) error {
 	ctx, _ := this.getTimeoutContext()
 	_, err := this.incidentCollection().DeleteOne(
@@ -282,3 +381,109 @@ func (this *Mongo) FindIncidents(query model.IncidentQuery) (result []model.Inci
 	err = cursor.Err()
 	return
 }
+
+func incidentRetentionFilter(scope model.RetentionScope) bson.M {
+	filter := bson.M{incidentNetworkIdKey: retentionNetworkFilter(scope)}
+	if !scope.Before.IsZero() {
+		filter[incidentTimeKey] = bson.M{"$lt": scope.Before}
+	}
+	return filter
+}
+
+// incidentsInScopePipeline matches the incidents of the scope; with scope.SkipRunningInstances,
+// incidents of process instances which are still stored in the process instance collection are excluded
+func (this *Mongo) incidentsInScopePipeline(scope model.RetentionScope, limit int64) mongo.Pipeline {
+	pipeline := mongo.Pipeline{{{Key: "$match", Value: incidentRetentionFilter(scope)}}}
+	if scope.SkipRunningInstances {
+		pipeline = append(pipeline,
+			bson.D{{Key: "$lookup", Value: bson.M{
+				"from": this.config.MongoProcessInstanceCollection,
+				"let":  bson.M{"network_id": "$" + incidentNetworkIdKey, "instance_id": "$" + incidentProcessInstanceIdKey},
+				"pipeline": mongo.Pipeline{
+					{{Key: "$match", Value: bson.M{"$expr": bson.M{"$and": bson.A{
+						bson.M{"$eq": bson.A{"$" + instanceNetworkIdKey, "$$network_id"}},
+						bson.M{"$eq": bson.A{"$" + instanceIdKey, "$$instance_id"}},
+					}}}}},
+					{{Key: "$limit", Value: 1}},
+					{{Key: "$project", Value: bson.M{"_id": 1}}},
+				},
+				"as": "running_instances",
+			}}},
+			bson.D{{Key: "$match", Value: bson.M{"running_instances": bson.M{"$size": 0}}}},
+			bson.D{{Key: "$project", Value: bson.M{"running_instances": 0}}},
+		)
+	}
+	if limit > 0 {
+		pipeline = append(pipeline, bson.D{{Key: "$limit", Value: limit}})
+	}
+	return pipeline
+}
+
+// FindIncidentsInScope returns up to limit incidents of the scope
+func (this *Mongo) FindIncidentsInScope(scope model.RetentionScope, limit int64) (result []model.Incident, err error) {
+	ctx, _ := this.getTimeoutContext()
+	if !scope.SkipRunningInstances {
+		cursor, err := this.incidentCollection().Find(ctx, incidentRetentionFilter(scope), options.Find().SetLimit(limit))
+		if err != nil {
+			return nil, err
+		}
+		err = cursor.All(ctx, &result)
+		return result, err
+	}
+	cursor, err := this.incidentCollection().Aggregate(ctx, this.incidentsInScopePipeline(scope, limit))
+	if err != nil {
+		return nil, err
+	}
+	err = cursor.All(ctx, &result)
+	return result, err
+}
+
+const incidentRetentionBatchSize = 1000
+
+// RemoveIncidentsInScope removes the incidents of the scope
+func (this *Mongo) RemoveIncidentsInScope(scope model.RetentionScope) (removed int64, err error) {
+	if !scope.SkipRunningInstances {
+		ctx, _ := this.getTimeoutContext()
+		result, err := this.incidentCollection().DeleteMany(ctx, incidentRetentionFilter(scope))
+		if err != nil {
+			return 0, err
+		}
+		return result.DeletedCount, nil
+	}
+	for {
+		ids, err := this.findIncidentDocumentIdsInScope(scope, incidentRetentionBatchSize)
+		if err != nil {
+			return removed, err
+		}
+		if len(ids) == 0 {
+			return removed, nil
+		}
+		ctx, _ := this.getTimeoutContext()
+		result, err := this.incidentCollection().DeleteMany(ctx, bson.M{"_id": bson.M{"$in": ids}})
+		if err != nil {
+			return removed, err
+		}
+		removed += result.DeletedCount
+		if len(ids) < incidentRetentionBatchSize {
+			return removed, nil
+		}
+	}
+}
+
+func (this *Mongo) findIncidentDocumentIdsInScope(scope model.RetentionScope, limit int64) (ids []interface{}, err error) {
+	pipeline := append(this.incidentsInScopePipeline(scope, limit), bson.D{{Key: "$project", Value: bson.M{"_id": 1}}})
+	ctx, _ := this.getTimeoutContext()
+	cursor, err := this.incidentCollection().Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	elements := []bson.M{}
+	err = cursor.All(ctx, &elements)
+	if err != nil {
+		return nil, err
+	}
+	for _, element := range elements {
+		ids = append(ids, element["_id"])
+	}
+	return ids, nil
+}
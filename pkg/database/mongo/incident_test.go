@@ -0,0 +1,332 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mongo
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SENERGY-Platform/process-sync/pkg/configuration"
+	"github.com/SENERGY-Platform/process-sync/pkg/database"
+	"github.com/SENERGY-Platform/process-sync/pkg/model"
+	"github.com/SENERGY-Platform/process-sync/pkg/model/camundamodel"
+	"github.com/SENERGY-Platform/process-sync/pkg/tests/docker"
+)
+
+func TestIncidentPendingCommand(t *testing.T) {
+	wg := &sync.WaitGroup{}
+	defer wg.Wait()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mongoPort, _, err := docker.Mongo(ctx, wg)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	db, err := New(configuration.Config{
+		MongoUrl:                          "mongodb://localhost:" + mongoPort,
+		MongoTable:                        "processes",
+		MongoProcessDefinitionCollection:  "process_definition",
+		MongoDeploymentCollection:         "deployments",
+		MongoProcessHistoryCollection:     "histories",
+		MongoIncidentCollection:           "incidents",
+		MongoProcessInstanceCollection:    "instances",
+		MongoDeploymentMetadataCollection: "deployment_metadata",
+		MongoLastNetworkContactCollection: "last_network_collection",
+		MongoWardenCollection:             "warden",
+		MongoDeploymentWardenCollection:   "deployment_warden",
+		MongoScheduleCollection:           "schedules",
+		MongoLeaseCollection:              "leases",
+		MongoWardenDecisionCollection:     "warden_decisions",
+		MongoAuditCollection:              "audit",
+		MongoIncidentGroupCollection:      "incident_groups",
+		MongoWardenCheckCollection:        "warden_checks",
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	incident := model.Incident{
+		Incident: camundamodel.Incident{Id: "i1", ProcessInstanceId: "p1", ErrorMessage: "err"},
+		SyncInfo: model.SyncInfo{NetworkId: "n1"},
+	}
+
+	t.Run("create", func(t *testing.T) {
+		newDocument, err := db.SaveIncident(incident)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if !newDocument {
+			t.Error("expected new document")
+		}
+	})
+
+	command := &model.IncidentCommand{Command: model.IncidentCommandRetry, Time: time.Now().Truncate(time.Millisecond)}
+	t.Run("set pending", func(t *testing.T) {
+		err = db.SetIncidentPendingCommand("n1", "i1", command)
+		if err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("set pending of unknown incident", func(t *testing.T) {
+		err = db.SetIncidentPendingCommand("n1", "unknown", command)
+		if !errors.Is(err, database.ErrNotFound) {
+			t.Error(err)
+		}
+	})
+
+	t.Run("update keeps pending", func(t *testing.T) {
+		incident.ErrorMessage = "err2"
+		newDocument, err := db.SaveIncident(incident)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if newDocument {
+			t.Error("expected existing document")
+		}
+		actual, err := db.ReadIncident("n1", "i1")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if actual.ErrorMessage != "err2" || actual.ProcessInstanceId != "p1" || actual.NetworkId != "n1" {
+			t.Errorf("%#v", actual)
+		}
+		if actual.PendingCommand == nil || actual.PendingCommand.Command != model.IncidentCommandRetry || !actual.PendingCommand.Time.Equal(command.Time) {
+			t.Errorf("%#v", actual.PendingCommand)
+		}
+	})
+
+	t.Run("remove pending", func(t *testing.T) {
+		err = db.SetIncidentPendingCommand("n1", "i1", nil)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		actual, err := db.ReadIncident("n1", "i1")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if actual.PendingCommand != nil {
+			t.Errorf("%#v", actual.PendingCommand)
+		}
+	})
+}
+
+func TestIncidentGroups(t *testing.T) {
+	wg := &sync.WaitGroup{}
+	defer wg.Wait()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mongoPort, _, err := docker.Mongo(ctx, wg)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	db, err := New(configuration.Config{
+		MongoUrl:                          "mongodb://localhost:" + mongoPort,
+		MongoTable:                        "processes",
+		MongoProcessDefinitionCollection:  "process_definition",
+		MongoDeploymentCollection:         "deployments",
+		MongoProcessHistoryCollection:     "histories",
+		MongoIncidentCollection:           "incidents",
+		MongoProcessInstanceCollection:    "instances",
+		MongoDeploymentMetadataCollection: "deployment_metadata",
+		MongoLastNetworkContactCollection: "last_network_collection",
+		MongoWardenCollection:             "warden",
+		MongoDeploymentWardenCollection:   "deployment_warden",
+		MongoScheduleCollection:           "schedules",
+		MongoLeaseCollection:              "leases",
+		MongoWardenDecisionCollection:     "warden_decisions",
+		MongoAuditCollection:              "audit",
+		MongoIncidentGroupCollection:      "incident_groups",
+		MongoWardenCheckCollection:        "warden_checks",
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i, incident := range []camundamodel.Incident{
+		{Id: "i1", ProcessDefinitionId: "d1", ErrorMessage: "value 1 is invalid", Time: start},
+		{Id: "i2", ProcessDefinitionId: "d1", ErrorMessage: "value 2 is invalid", Time: start.Add(30 * time.Minute)},
+		{Id: "i3", ProcessDefinitionId: "d1", ErrorMessage: "value 3 is invalid", Time: start.Add(90 * time.Minute)},
+		{Id: "i4", ProcessDefinitionId: "d1", ErrorMessage: "timeout", Time: start.Add(10 * time.Minute)},
+	} {
+		_, err = db.SaveIncident(model.Incident{Incident: incident, SyncInfo: model.SyncInfo{NetworkId: "n1"}})
+		if err != nil {
+			t.Error(i, err)
+			return
+		}
+	}
+	groupId := model.NewIncidentGroupInfo("d1", "", "value 1 is invalid").GroupId
+
+	t.Run("list groups", func(t *testing.T) {
+		groups, err := db.ListIncidentGroups(model.IncidentGroupQuery{NetworkIds: []string{"n1"}, Sort: "count.desc"})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if len(groups) != 2 {
+			t.Errorf("%#v", groups)
+			return
+		}
+		if groups[0].GroupId != groupId || groups[0].Count != 3 || groups[0].LatestIncidentId != "i3" || !groups[0].FirstSeen.Equal(start) || groups[0].NormalizedErrorMessage != "value <n> is invalid" {
+			t.Errorf("%#v", groups[0])
+		}
+		if groups[1].Count != 1 || groups[1].LatestIncidentId != "i4" {
+			t.Errorf("%#v", groups[1])
+		}
+	})
+
+	t.Run("acknowledge and mute", func(t *testing.T) {
+		ackTime := start.Add(2 * time.Hour)
+		err = db.SetIncidentGroupState(model.IncidentGroupState{NetworkId: "n1", GroupId: groupId, AcknowledgedAt: &ackTime, Muted: true})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		groups, err := db.ListIncidentGroups(model.IncidentGroupQuery{NetworkIds: []string{"n1"}, Sort: "count.desc"})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if len(groups) != 2 || !groups[0].Acknowledged || !groups[0].Muted || groups[1].Acknowledged || groups[1].Muted {
+			t.Errorf("%#v", groups)
+		}
+	})
+
+	t.Run("stats", func(t *testing.T) {
+		buckets, err := db.CountIncidentsPerInterval(model.IncidentStatsQuery{NetworkIds: []string{"n1"}, From: start, To: start.Add(3 * time.Hour), Interval: time.Hour})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if len(buckets) != 2 || !buckets[0].Time.Equal(start) || buckets[0].Count != 3 || buckets[1].Count != 1 {
+			t.Errorf("%#v", buckets)
+		}
+	})
+}
+
+func TestIncidentRetention(t *testing.T) {
+	wg := &sync.WaitGroup{}
+	defer wg.Wait()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mongoPort, _, err := docker.Mongo(ctx, wg)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	db, err := New(configuration.Config{
+		MongoUrl:                          "mongodb://localhost:" + mongoPort,
+		MongoTable:                        "processes",
+		MongoProcessDefinitionCollection:  "process_definition",
+		MongoDeploymentCollection:         "deployments",
+		MongoProcessHistoryCollection:     "histories",
+		MongoIncidentCollection:           "incidents",
+		MongoProcessInstanceCollection:    "instances",
+		MongoDeploymentMetadataCollection: "deployment_metadata",
+		MongoLastNetworkContactCollection: "last_network_collection",
+		MongoWardenCollection:             "warden",
+		MongoDeploymentWardenCollection:   "deployment_warden",
+		MongoScheduleCollection:           "schedules",
+		MongoLeaseCollection:              "leases",
+		MongoWardenDecisionCollection:     "warden_decisions",
+		MongoAuditCollection:              "audit",
+		MongoIncidentGroupCollection:      "incident_groups",
+		MongoWardenCheckCollection:        "warden_checks",
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i, incident := range []model.Incident{
+		{Incident: camundamodel.Incident{Id: "running", ProcessInstanceId: "pi1", Time: start}, SyncInfo: model.SyncInfo{NetworkId: "n1"}},
+		{Incident: camundamodel.Incident{Id: "finished", ProcessInstanceId: "pi2", Time: start}, SyncInfo: model.SyncInfo{NetworkId: "n1"}},
+		{Incident: camundamodel.Incident{Id: "other_network", ProcessInstanceId: "pi1", Time: start}, SyncInfo: model.SyncInfo{NetworkId: "n2"}},
+		{Incident: camundamodel.Incident{Id: "young", ProcessInstanceId: "pi3", Time: start.Add(48 * time.Hour)}, SyncInfo: model.SyncInfo{NetworkId: "n1"}},
+	} {
+		_, err = db.SaveIncident(incident)
+		if err != nil {
+			t.Error(i, err)
+			return
+		}
+	}
+	err = db.SaveProcessInstance(model.ProcessInstance{
+		ProcessInstance: camundamodel.ProcessInstance{Id: "pi1"},
+		SyncInfo:        model.SyncInfo{NetworkId: "n1"},
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	scope := model.RetentionScope{Before: start.Add(time.Hour), SkipRunningInstances: true}
+	found, err := db.FindIncidentsInScope(scope, 10)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	foundIds := []string{}
+	for _, incident := range found {
+		foundIds = append(foundIds, incident.Id)
+	}
+	slices.Sort(foundIds)
+	if !reflect.DeepEqual(foundIds, []string{"finished", "other_network"}) {
+		t.Errorf("%#v", foundIds)
+		return
+	}
+
+	removed, err := db.RemoveIncidentsInScope(scope)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if removed != 2 {
+		t.Errorf("%#v", removed)
+		return
+	}
+	for _, id := range []string{"running", "young"} {
+		_, err = db.ReadIncident("n1", id)
+		if err != nil {
+			t.Error(id, err)
+		}
+	}
+}
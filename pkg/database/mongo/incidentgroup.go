@@ -0,0 +1,284 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mongo
+
+import (
+	"strings"
+	"time"
+
+	"github.com/SENERGY-Platform/process-sync/pkg/configuration"
+	"github.com/SENERGY-Platform/process-sync/pkg/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var incidentGroupStateNetworkIdKey string
+var incidentGroupStateGroupIdKey string
+
+func init() {
+	prepareCollection(func(config configuration.Config) string {
+		return config.MongoIncidentGroupCollection
+	},
+		model.IncidentGroupState{},
+		[]KeyMapping{
+			{
+				FieldName: "NetworkId",
+				Key:       &incidentGroupStateNetworkIdKey,
+			},
+			{
+				FieldName: "GroupId",
+				Key:       &incidentGroupStateGroupIdKey,
+			},
+		},
+		[]IndexDesc{
+			{
+				Name:   "incident_group_state_index",
+				Unique: true,
+				Asc:    true,
+				Keys:   []*string{&incidentGroupStateNetworkIdKey, &incidentGroupStateGroupIdKey},
+			},
+		},
+	)
+}
+
+func (this *Mongo) incidentGroupStateCollection() *mongo.Collection {
+	return this.client.Database(this.config.MongoTable).Collection(this.config.MongoIncidentGroupCollection)
+}
+
+type incidentGroupAggregation struct {
+	Id struct {
+		NetworkId string `bson:"network_id"`
+		GroupId   string `bson:"group_id"`
+	} `bson:"_id"`
+	ProcessDefinitionId    string    `bson:"process_definition_id"`
+	ActivityId             string    `bson:"activity_id"`
+	DeploymentName         string    `bson:"deployment_name"`
+	TenantId               string    `bson:"tenant_id"`
+	NormalizedErrorMessage string    `bson:"normalized_error_message"`
+	LatestErrorMessage     string    `bson:"latest_error_message"`
+	LatestIncidentId       string    `bson:"latest_incident_id"`
+	FirstSeen              time.Time `bson:"first_seen"`
+	LastSeen               time.Time `bson:"last_seen"`
+	Count                  int64     `bson:"count"`
+}
+
+// ListIncidentGroups groups the incidents by network and group id; acknowledgement and mute state are read from the incident group collection
+func (this *Mongo) ListIncidentGroups(query model.IncidentGroupQuery) (result []model.IncidentGroup, err error) {
+	if query.Sort == "" {
+		query.Sort = "last_seen.desc"
+	}
+	parts := strings.Split(query.Sort, ".")
+	sortby := "last_seen"
+	switch parts[0] {
+	case "first_seen", "count":
+		sortby = parts[0]
+	}
+	direction := int32(1)
+	if len(parts) > 1 && parts[1] == "desc" {
+		direction = int32(-1)
+	}
+
+	filter := bson.M{incidentNetworkIdKey: bson.M{"$in": query.NetworkIds}}
+	if query.ProcessDefinitionId != "" {
+		filter[incidentProcessDefinitionIdKey] = query.ProcessDefinitionId
+	}
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$sort", Value: bson.D{{Key: incidentTimeKey, Value: 1}}}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: bson.D{{Key: "network_id", Value: "$" + incidentNetworkIdKey}, {Key: "group_id", Value: "$" + incidentGroupIdKey}}},
+			{Key: "process_definition_id", Value: bson.M{"$first": "$" + incidentProcessDefinitionIdKey}},
+			{Key: "activity_id", Value: bson.M{"$first": "$" + incidentActivityIdKey}},
+			{Key: "deployment_name", Value: bson.M{"$last": "$" + incidentDeploymentNameKey}},
+			{Key: "tenant_id", Value: bson.M{"$last": "$" + incidentTenantIdKey}},
+			{Key: "normalized_error_message", Value: bson.M{"$first": "$" + incidentNormalizedErrorMessageKey}},
+			{Key: "latest_error_message", Value: bson.M{"$last": "$" + incidentErrorMessageKey}},
+			{Key: "latest_incident_id", Value: bson.M{"$last": "$" + incidentIdKey}},
+			{Key: "first_seen", Value: bson.M{"$min": "$" + incidentTimeKey}},
+			{Key: "last_seen", Value: bson.M{"$max": "$" + incidentTimeKey}},
+			{Key: "count", Value: bson.M{"$sum": 1}},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: sortby, Value: direction}, {Key: "_id.network_id", Value: direction}, {Key: "_id.group_id", Value: direction}}}},
+		{{Key: "$skip", Value: query.Offset}},
+	}
+	if query.Limit > 0 {
+		pipeline = append(pipeline, bson.D{{Key: "$limit", Value: query.Limit}})
+	}
+
+	ctx, _ := this.getTimeoutContext()
+	cursor, err := this.incidentCollection().Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	groupIds := []string{}
+	for cursor.Next(ctx) {
+		element := incidentGroupAggregation{}
+		err = cursor.Decode(&element)
+		if err != nil {
+			return nil, err
+		}
+		groupIds = append(groupIds, element.Id.GroupId)
+		result = append(result, model.IncidentGroup{
+			GroupId:                element.Id.GroupId,
+			NetworkId:              element.Id.NetworkId,
+			ProcessDefinitionId:    element.ProcessDefinitionId,
+			ActivityId:             element.ActivityId,
+			DeploymentName:         element.DeploymentName,
+			TenantId:               element.TenantId,
+			NormalizedErrorMessage: element.NormalizedErrorMessage,
+			LatestErrorMessage:     element.LatestErrorMessage,
+			LatestIncidentId:       element.LatestIncidentId,
+			FirstSeen:              element.FirstSeen,
+			LastSeen:               element.LastSeen,
+			Count:                  element.Count,
+		})
+	}
+	err = cursor.Err()
+	if err != nil || len(result) == 0 {
+		return result, err
+	}
+
+	states, err := this.listIncidentGroupStates(query.NetworkIds, groupIds)
+	if err != nil {
+		return nil, err
+	}
+	now := configuration.TimeNow()
+	for i, group := range result {
+		state, ok := states[group.NetworkId+"/"+group.GroupId]
+		if !ok {
+			continue
+		}
+		result[i].State = &state
+		result[i].Acknowledged = state.IsAcknowledged(group.LastSeen)
+		result[i].Muted = state.IsMuted(now)
+	}
+	return result, nil
+}
+
+func (this *Mongo) listIncidentGroupStates(networkIds []string, groupIds []string) (result map[string]model.IncidentGroupState, err error) {
+	ctx, _ := this.getTimeoutContext()
+	cursor, err := this.incidentGroupStateCollection().Find(ctx, bson.M{
+		incidentGroupStateNetworkIdKey: bson.M{"$in": networkIds},
+		incidentGroupStateGroupIdKey:   bson.M{"$in": groupIds},
+	})
+	if err != nil {
+		return nil, err
+	}
+	result = map[string]model.IncidentGroupState{}
+	for cursor.Next(ctx) {
+		element := model.IncidentGroupState{}
+		err = cursor.Decode(&element)
+		if err != nil {
+			return nil, err
+		}
+		result[element.NetworkId+"/"+element.GroupId] = element
+	}
+	err = cursor.Err()
+	return result, err
+}
+
+// CountIncidentsPerInterval counts the incidents in [query.From, query.To) per query.Interval, aligned to query.From; empty buckets are omitted
+func (this *Mongo) CountIncidentsPerInterval(query model.IncidentStatsQuery) (result []model.IncidentStatsBucket, err error) {
+	filter := bson.M{
+		incidentNetworkIdKey: bson.M{"$in": query.NetworkIds},
+		incidentTimeKey:      bson.M{"$gte": query.From, "$lt": query.To},
+	}
+	if query.GroupId != "" {
+		filter[incidentGroupIdKey] = query.GroupId
+	}
+	millis := bson.M{"$toLong": "$" + incidentTimeKey}
+	offset := bson.M{"$mod": bson.A{bson.M{"$subtract": bson.A{millis, query.From.UnixMilli()}}, query.Interval.Milliseconds()}}
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: bson.M{"$toDate": bson.M{"$subtract": bson.A{millis, offset}}}},
+			{Key: "count", Value: bson.M{"$sum": 1}},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "_id", Value: 1}}}},
+	}
+	ctx, _ := this.getTimeoutContext()
+	cursor, err := this.incidentCollection().Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	for cursor.Next(ctx) {
+		element := model.IncidentStatsBucket{}
+		err = cursor.Decode(&element)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, element)
+	}
+	err = cursor.Err()
+	return result, err
+}
+
+func (this *Mongo) ReadIncidentGroupState(networkId string, groupId string) (state model.IncidentGroupState, exists bool, err error) {
+	ctx, _ := this.getTimeoutContext()
+	err = this.incidentGroupStateCollection().FindOne(ctx, bson.M{
+		incidentGroupStateNetworkIdKey: networkId,
+		incidentGroupStateGroupIdKey:   groupId,
+	}).Decode(&state)
+	if err == mongo.ErrNoDocuments {
+		return state, false, nil
+	}
+	if err != nil {
+		return state, false, err
+	}
+	return state, true, nil
+}
+
+func (this *Mongo) SetIncidentGroupState(state model.IncidentGroupState) error {
+	ctx, _ := this.getTimeoutContext()
+	_, err := this.incidentGroupStateCollection().ReplaceOne(ctx, bson.M{
+		incidentGroupStateNetworkIdKey: state.NetworkId,
+		incidentGroupStateGroupIdKey:   state.GroupId,
+	}, state, options.Replace().SetUpsert(true))
+	return err
+}
+
+// BackfillIncidentGroupInfo sets the group info of incidents stored before the introduction of incident groups
+func (this *Mongo) BackfillIncidentGroupInfo() (count int, err error) {
+	for {
+		ctx, _ := this.getTimeoutContext()
+		batch := []model.Incident{}
+		cursor, err := this.incidentCollection().Find(ctx, bson.M{incidentGroupIdKey: bson.M{"$exists": false}}, options.Find().SetLimit(backfillBatchSize))
+		if err != nil {
+			return count, err
+		}
+		err = cursor.All(ctx, &batch)
+		if err != nil {
+			return count, err
+		}
+		if len(batch) == 0 {
+			return count, nil
+		}
+		for _, element := range batch {
+			info := model.NewIncidentGroupInfo(element.ProcessDefinitionId, element.ActivityId, element.ErrorMessage)
+			ctx, _ = this.getTimeoutContext()
+			_, err = this.incidentCollection().UpdateOne(ctx, bson.M{
+				incidentIdKey:        element.Id,
+				incidentNetworkIdKey: element.NetworkId,
+			}, bson.M{"$set": bson.M{incidentGroupInfoKey: info}})
+			if err != nil {
+				return count, err
+			}
+			count++
+		}
+	}
+}
@@ -33,6 +33,7 @@ var instanceNetworkIdKey string
 var instancePlaceholderKey string
 var instanceBusinessKeyKey string
 var instanceDefinitionIdKey string
+var instanceSyncDateKey string
 
 func init() {
 	prepareCollection(func(config configuration.Config) string {
@@ -60,6 +61,10 @@ func init() {
 				FieldName: "ProcessInstance.DefinitionId",
 				Key:       &instanceDefinitionIdKey,
 			},
+			{
+				FieldName: "SyncInfo.SyncDate",
+				Key:       &instanceSyncDateKey,
+			},
 		},
 		[]IndexDesc{
 			{
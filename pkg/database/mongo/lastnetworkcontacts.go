@@ -17,6 +17,7 @@
 package mongo
 
 import (
+	"errors"
 	"time"
 
 	"github.com/SENERGY-Platform/process-sync/pkg/configuration"
@@ -28,6 +29,7 @@ import (
 
 var networkIdKey string
 var networkTimeKey string
+var networkTopicsKey string
 
 func init() {
 	prepareCollection(func(config configuration.Config) string {
@@ -43,6 +45,10 @@ func init() {
 				FieldName: "Time",
 				Key:       &networkTimeKey,
 			},
+			{
+				FieldName: "Topics",
+				Key:       &networkTopicsKey,
+			},
 		},
 		[]IndexDesc{
 			{
@@ -66,18 +72,38 @@ func (this *Mongo) lastNetworkContactCollection() *mongo.Collection {
 	return this.client.Database(this.config.MongoTable).Collection(this.config.MongoLastNetworkContactCollection)
 }
 
+// SaveLastContact updates the contact time of the network and of the topics in lastContact.Topics; contact times of other topics are kept
 func (this *Mongo) SaveLastContact(lastContact model.LastNetworkContact) error {
 	ctx, _ := this.getTimeoutContext()
-	_, err := this.lastNetworkContactCollection().ReplaceOne(
+	set := bson.M{
+		networkIdKey:   lastContact.NetworkId,
+		networkTimeKey: lastContact.Time,
+	}
+	for topic, t := range lastContact.Topics {
+		set[networkTopicsKey+"."+topic] = t
+	}
+	_, err := this.lastNetworkContactCollection().UpdateOne(
 		ctx,
 		bson.M{
 			networkIdKey: lastContact.NetworkId,
 		},
-		lastContact,
-		options.Replace().SetUpsert(true))
+		bson.M{"$set": set},
+		options.Update().SetUpsert(true))
 	return err
 }
 
+func (this *Mongo) ReadLastContact(networkId string) (lastContact model.LastNetworkContact, exists bool, err error) {
+	ctx, _ := this.getTimeoutContext()
+	err = this.lastNetworkContactCollection().FindOne(ctx, bson.M{networkIdKey: networkId}).Decode(&lastContact)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return lastContact, false, nil
+	}
+	if err != nil {
+		return lastContact, false, err
+	}
+	return lastContact, true, nil
+}
+
 func (this *Mongo) FilterNetworkIds(networkIds []string) (result []string, err error) {
 	result = []string{}
 	ctx, _ := this.getTimeoutContext()
@@ -116,6 +142,24 @@ func (this *Mongo) GetOldNetworkIds(maxAge time.Duration) (result []string, err
 	return result, err
 }
 
+func (this *Mongo) ListLastContacts() (result []model.LastNetworkContact, err error) {
+	ctx, _ := this.getTimeoutContext()
+	cursor, err := this.lastNetworkContactCollection().Find(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+	for cursor.Next(ctx) {
+		element := model.LastNetworkContact{}
+		err = cursor.Decode(&element)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, element)
+	}
+	err = cursor.Err()
+	return result, err
+}
+
 func (this *Mongo) ListKnownNetworkIds() (result []string, err error) {
 	ctx, _ := this.getTimeoutContext()
 	cursor, err := this.lastNetworkContactCollection().Find(ctx, bson.M{})
@@ -168,9 +212,87 @@ func (this *Mongo) RemoveOldElements(maxAge time.Duration) (err error) {
 	if err != nil {
 		return err
 	}
+	_, err = this.incidentGroupStateCollection().DeleteMany(ctx, bson.M{incidentGroupStateNetworkIdKey: bson.M{"$in": networkIds}})
+	if err != nil {
+		return err
+	}
+	_, err = this.scheduleCollection().DeleteMany(ctx, bson.M{scheduleNetworkIdKey: bson.M{"$in": networkIds}})
+	if err != nil {
+		return err
+	}
+	_, err = this.wardenDecisionCollection().DeleteMany(ctx, bson.M{wardenDecisionNetworkIdKey: bson.M{"$in": networkIds}})
+	if err != nil {
+		return err
+	}
 	_, err = this.lastNetworkContactCollection().DeleteMany(ctx, bson.M{networkIdKey: bson.M{"$in": networkIds}})
 	if err != nil {
 		return err
 	}
 	return nil
 }
+
+// RemoveNetworkSyncState removes the deployments, metadata, definitions, instances, history, incidents and warden infos of the network
+func (this *Mongo) RemoveNetworkSyncState(networkId string) (err error) {
+	ctx, _ := this.getTimeoutContext()
+	removals := []struct {
+		collection *mongo.Collection
+		key        string
+	}{
+		{collection: this.deploymentCollection(), key: deploymentNetworkIdKey},
+		{collection: this.deploymentMetadataCollection(), key: metadataNetworkIdKey},
+		{collection: this.processDefinitionCollection(), key: definitionNetworkIdKey},
+		{collection: this.processInstanceCollection(), key: instanceNetworkIdKey},
+		{collection: this.processHistoryCollection(), key: historyNetworkIdKey},
+		{collection: this.incidentCollection(), key: incidentNetworkIdKey},
+		{collection: this.deploymentWardenCollection(), key: deploymentWardenNetworkIdKey},
+		{collection: this.wardenCollection(), key: wardenNetworkIdKey},
+	}
+	for _, removal := range removals {
+		_, err = removal.collection.DeleteMany(ctx, bson.M{removal.key: networkId})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RemoveNetworkWardenInfos removes the warden infos and deployment warden infos of the network
+func (this *Mongo) RemoveNetworkWardenInfos(networkId string) (removed int64, err error) {
+	ctx, _ := this.getTimeoutContext()
+	result, err := this.wardenCollection().DeleteMany(ctx, bson.M{wardenNetworkIdKey: networkId})
+	if err != nil {
+		return 0, err
+	}
+	removed = result.DeletedCount
+	result, err = this.deploymentWardenCollection().DeleteMany(ctx, bson.M{deploymentWardenNetworkIdKey: networkId})
+	if err != nil {
+		return removed, err
+	}
+	return removed + result.DeletedCount, nil
+}
+
+// PurgeNetwork removes the sync state (see RemoveNetworkSyncState), incident group states, schedules and warden decisions of the network;
+// the last contact is kept (see RemoveLastContact)
+func (this *Mongo) PurgeNetwork(networkId string) (err error) {
+	err = this.RemoveNetworkSyncState(networkId)
+	if err != nil {
+		return err
+	}
+	ctx, _ := this.getTimeoutContext()
+	_, err = this.incidentGroupStateCollection().DeleteMany(ctx, bson.M{incidentGroupStateNetworkIdKey: networkId})
+	if err != nil {
+		return err
+	}
+	_, err = this.scheduleCollection().DeleteMany(ctx, bson.M{scheduleNetworkIdKey: networkId})
+	if err != nil {
+		return err
+	}
+	_, err = this.wardenDecisionCollection().DeleteMany(ctx, bson.M{wardenDecisionNetworkIdKey: networkId})
+	return err
+}
+
+func (this *Mongo) RemoveLastContact(networkId string) error {
+	ctx, _ := this.getTimeoutContext()
+	_, err := this.lastNetworkContactCollection().DeleteOne(ctx, bson.M{networkIdKey: networkId})
+	return err
+}
@@ -57,6 +57,12 @@ func TestLastNetworkContact(t *testing.T) {
 		MongoLastNetworkContactCollection: "last_network_collection",
 		MongoWardenCollection:             "warden",
 		MongoDeploymentWardenCollection:   "deployment_warden",
+		MongoScheduleCollection:           "schedules",
+		MongoLeaseCollection:              "leases",
+		MongoWardenDecisionCollection:     "warden_decisions",
+		MongoAuditCollection:              "audit",
+		MongoIncidentGroupCollection:      "incident_groups",
+		MongoWardenCheckCollection:        "warden_checks",
 	}
 
 	db, err := New(config)
@@ -446,3 +452,86 @@ func TestLastNetworkContact(t *testing.T) {
 		})
 	})
 }
+
+func TestRemoveNetworkSyncState(t *testing.T) {
+	wg := &sync.WaitGroup{}
+	defer wg.Wait()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mongoPort, _, err := docker.Mongo(ctx, wg)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	db, err := New(configuration.Config{
+		MongoUrl:                          "mongodb://localhost:" + mongoPort,
+		MongoTable:                        "processes",
+		MongoProcessDefinitionCollection:  "process_definition",
+		MongoDeploymentCollection:         "deployments",
+		MongoProcessHistoryCollection:     "histories",
+		MongoIncidentCollection:           "incidents",
+		MongoProcessInstanceCollection:    "instances",
+		MongoDeploymentMetadataCollection: "deployment_metadata",
+		MongoLastNetworkContactCollection: "last_network_collection",
+		MongoWardenCollection:             "warden",
+		MongoDeploymentWardenCollection:   "deployment_warden",
+		MongoScheduleCollection:           "schedules",
+		MongoLeaseCollection:              "leases",
+		MongoWardenDecisionCollection:     "warden_decisions",
+		MongoAuditCollection:              "audit",
+		MongoIncidentGroupCollection:      "incident_groups",
+		MongoWardenCheckCollection:        "warden_checks",
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	for _, networkId := range []string{"n1", "n2"} {
+		err = db.SaveDeployment(model.Deployment{Deployment: camundamodel.Deployment{Id: "d1"}, SyncInfo: model.SyncInfo{NetworkId: networkId}})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		err = db.SaveHistoricProcessInstance(model.HistoricProcessInstance{HistoricProcessInstance: camundamodel.HistoricProcessInstance{Id: "h1"}, SyncInfo: model.SyncInfo{NetworkId: networkId}})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		err = db.SetWardenInfo(model.WardenInfo{NetworkId: networkId, BusinessKey: "bk1"})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+	}
+
+	err = db.RemoveNetworkSyncState("n1")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	for networkId, expected := range map[string]int{"n1": 0, "n2": 1} {
+		deployments, err := db.ListDeployments([]string{networkId}, 10, 0, "id.asc")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		history, _, err := db.ListHistoricProcessInstances([]string{networkId}, model.HistoryQuery{}, 10, 0, "id.asc")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		wardenInfos, err := db.FindWardenInfo(model.WardenInfoQuery{NetworkIds: []string{networkId}})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if len(deployments) != expected || len(history) != expected || len(wardenInfos) != expected {
+			t.Error(networkId, len(deployments), len(history), len(wardenInfos))
+		}
+	}
+}
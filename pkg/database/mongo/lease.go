@@ -0,0 +1,149 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mongo
+
+import (
+	"errors"
+	"time"
+
+	"github.com/SENERGY-Platform/process-sync/pkg/configuration"
+	"github.com/SENERGY-Platform/process-sync/pkg/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var leaseNameKey string
+var leaseHolderKey string
+var leaseExpiresAtKey string
+
+func init() {
+	prepareCollection(func(config configuration.Config) string {
+		return config.MongoLeaseCollection
+	},
+		model.Lease{},
+		[]KeyMapping{
+			{
+				FieldName: "Name",
+				Key:       &leaseNameKey,
+			},
+			{
+				FieldName: "Holder",
+				Key:       &leaseHolderKey,
+			},
+			{
+				FieldName: "ExpiresAt",
+				Key:       &leaseExpiresAtKey,
+			},
+		},
+		[]IndexDesc{
+			{
+				Name:   "lease_name_index",
+				Unique: true,
+				Asc:    true,
+				Keys:   []*string{&leaseNameKey},
+			},
+			{
+				Name:       "lease_expiration_index",
+				Asc:        true,
+				Keys:       []*string{&leaseExpiresAtKey},
+				IsTTLIndex: true,
+			},
+		},
+	)
+}
+
+func (this *Mongo) leaseCollection() *mongo.Collection {
+	return this.client.Database(this.config.MongoTable).Collection(this.config.MongoLeaseCollection)
+}
+
+// TryAcquireLease acquires or renews the lease if it is unknown, expired or already held by holder.
+// if another holder has a valid lease, the current lease is returned with acquired = false.
+func (this *Mongo) TryAcquireLease(name string, holder string, now time.Time, duration time.Duration) (lease model.Lease, acquired bool, err error) {
+	ctx, _ := this.getTimeoutContext()
+	result := this.leaseCollection().FindOneAndUpdate(
+		ctx,
+		bson.M{
+			leaseNameKey: name,
+			"$or": []bson.M{
+				{leaseHolderKey: holder},
+				{leaseExpiresAtKey: bson.M{"$lt": now}},
+			},
+		},
+		bson.M{
+			"$set": model.Lease{
+				Name:      name,
+				Holder:    holder,
+				RenewedAt: now,
+				ExpiresAt: now.Add(duration),
+			},
+		},
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After))
+	err = result.Err()
+	if mongo.IsDuplicateKeyError(err) {
+		//the filter did not match because the lease is held by another holder and the upsert collided with the existing lease
+		lease, err = this.readLease(name)
+		return lease, false, err
+	}
+	if err != nil {
+		return lease, false, err
+	}
+	err = result.Decode(&lease)
+	if err != nil {
+		return lease, false, err
+	}
+	return lease, lease.Holder == holder, nil
+}
+
+func (this *Mongo) readLease(name string) (lease model.Lease, err error) {
+	ctx, _ := this.getTimeoutContext()
+	err = this.leaseCollection().FindOne(ctx, bson.M{leaseNameKey: name}).Decode(&lease)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		//lease expired and was removed in the meantime
+		return lease, nil
+	}
+	return lease, err
+}
+
+func (this *Mongo) ReleaseLease(name string, holder string) error {
+	ctx, _ := this.getTimeoutContext()
+	_, err := this.leaseCollection().DeleteMany(
+		ctx,
+		bson.M{
+			leaseNameKey:   name,
+			leaseHolderKey: holder,
+		})
+	return err
+}
+
+func (this *Mongo) ListLeases() (result []model.Lease, err error) {
+	ctx, _ := this.getTimeoutContext()
+	cursor, err := this.leaseCollection().Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: leaseNameKey, Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	for cursor.Next(ctx) {
+		element := model.Lease{}
+		err = cursor.Decode(&element)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, element)
+	}
+	err = cursor.Err()
+	return
+}
@@ -0,0 +1,113 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mongo
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SENERGY-Platform/process-sync/pkg/configuration"
+	"github.com/SENERGY-Platform/process-sync/pkg/tests/docker"
+)
+
+func TestLease(t *testing.T) {
+	wg := &sync.WaitGroup{}
+	defer wg.Wait()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mongoPort, _, err := docker.Mongo(ctx, wg)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	db, err := New(configuration.Config{
+		MongoUrl:                          "mongodb://localhost:" + mongoPort,
+		MongoTable:                        "processes",
+		MongoProcessDefinitionCollection:  "process_definition",
+		MongoDeploymentCollection:         "deployments",
+		MongoProcessHistoryCollection:     "histories",
+		MongoIncidentCollection:           "incidents",
+		MongoProcessInstanceCollection:    "instances",
+		MongoDeploymentMetadataCollection: "deployment_metadata",
+		MongoLastNetworkContactCollection: "last_network_collection",
+		MongoWardenCollection:             "warden",
+		MongoDeploymentWardenCollection:   "deployment_warden",
+		MongoScheduleCollection:           "schedules",
+		MongoLeaseCollection:              "leases",
+		MongoWardenDecisionCollection:     "warden_decisions",
+		MongoAuditCollection:              "audit",
+		MongoIncidentGroupCollection:      "incident_groups",
+		MongoWardenCheckCollection:        "warden_checks",
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	now := time.Now().Truncate(time.Millisecond)
+
+	check := func(holder string, now time.Time, expectAcquired bool) func(t *testing.T) {
+		return func(t *testing.T) {
+			lease, acquired, err := db.TryAcquireLease("loop", holder, now, time.Minute)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if acquired != expectAcquired {
+				t.Error("unexpected acquired", acquired, lease)
+			}
+		}
+	}
+
+	t.Run("a acquires", check("a", now, true))
+	t.Run("b is rejected", check("b", now.Add(time.Second), false))
+	t.Run("a renews", check("a", now.Add(30*time.Second), true))
+	t.Run("b is rejected after first expiration", check("b", now.Add(70*time.Second), false))
+	t.Run("b takes over expired lease", check("b", now.Add(2*time.Minute), true))
+	t.Run("a is rejected", check("a", now.Add(2*time.Minute), false))
+
+	t.Run("list", func(t *testing.T) {
+		leases, err := db.ListLeases()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if len(leases) != 1 || leases[0].Holder != "b" {
+			t.Error(leases)
+		}
+	})
+
+	t.Run("release by other holder is ignored", func(t *testing.T) {
+		err = db.ReleaseLease("loop", "a")
+		if err != nil {
+			t.Error(err)
+		}
+	})
+	t.Run("a is still rejected", check("a", now.Add(2*time.Minute), false))
+	t.Run("b releases", func(t *testing.T) {
+		err = db.ReleaseLease("loop", "b")
+		if err != nil {
+			t.Error(err)
+		}
+	})
+	t.Run("a acquires released lease", check("a", now.Add(2*time.Minute), true))
+}
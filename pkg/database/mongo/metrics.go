@@ -0,0 +1,56 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mongo
+
+import (
+	"context"
+	"sync"
+
+	"github.com/SENERGY-Platform/process-sync/pkg/metrics"
+	"go.mongodb.org/mongo-driver/event"
+)
+
+// newCommandMonitor records the duration of each command with the command name (find, insert, update, ...) as method
+func newCommandMonitor(m *metrics.Metrics) *event.CommandMonitor {
+	collections := sync.Map{} //request-id -> collection name, to label the finished event
+	finished := func(e event.CommandFinishedEvent, failed bool) {
+		collection, ok := collections.LoadAndDelete(e.RequestID)
+		if !ok {
+			return
+		}
+		m.MongoOperation(e.CommandName, collection.(string), e.Duration, failed)
+	}
+	return &event.CommandMonitor{
+		Started: func(ctx context.Context, e *event.CommandStartedEvent) {
+			key := e.CommandName
+			if e.CommandName == "getMore" {
+				key = "collection"
+			}
+			collection, ok := e.Command.Lookup(key).StringValueOK()
+			if !ok {
+				return //not a collection command (e.g. hello, ping, endSessions)
+			}
+			collections.Store(e.RequestID, collection)
+		},
+		Succeeded: func(ctx context.Context, e *event.CommandSucceededEvent) {
+			finished(e.CommandFinishedEvent, false)
+		},
+		Failed: func(ctx context.Context, e *event.CommandFailedEvent) {
+			finished(e.CommandFinishedEvent, true)
+		},
+	}
+}
@@ -26,6 +26,7 @@ import (
 	"time"
 
 	"github.com/SENERGY-Platform/process-sync/pkg/configuration"
+	"github.com/SENERGY-Platform/process-sync/pkg/metrics"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/bsoncodec"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -40,9 +41,18 @@ type Mongo struct {
 var CreateCollections = []func(db *Mongo) error{}
 
 func New(conf configuration.Config) (*Mongo, error) {
+	return NewWithMetrics(conf, nil)
+}
+
+// NewWithMetrics records the duration of every mongodb operation in m
+func NewWithMetrics(conf configuration.Config, m *metrics.Metrics) (*Mongo, error) {
 	db := &Mongo{config: conf}
 	ctx, _ := db.getTimeoutContext()
-	client, err := mongo.Connect(ctx, options.Client().ApplyURI(conf.MongoUrl))
+	opt := options.Client().ApplyURI(conf.MongoUrl)
+	if m != nil {
+		opt.SetMonitor(newCommandMonitor(m))
+	}
+	client, err := mongo.Connect(ctx, opt)
 	if err != nil {
 		return nil, err
 	}
@@ -79,6 +89,16 @@ func (this *Mongo) ensureTextIndex(collection *mongo.Collection, indexname strin
 	return err
 }
 
+// ensureTTLIndex creates an index which removes documents as soon as the date in indexKey has passed
+func (this *Mongo) ensureTTLIndex(collection *mongo.Collection, indexname string, indexKey string) error {
+	ctx, _ := this.getTimeoutContext()
+	_, err := collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: indexKey, Value: 1}},
+		Options: options.Index().SetName(indexname).SetExpireAfterSeconds(0),
+	})
+	return err
+}
+
 func (this *Mongo) ensureCompoundIndex(collection *mongo.Collection, indexname string, asc bool, unique bool, indexKeys ...string) error {
 	ctx, _ := this.getTimeoutContext()
 	var direction int32 = -1
@@ -152,6 +172,7 @@ type IndexDesc struct {
 	Asc         bool
 	Keys        []*string
 	IsTextIndex bool
+	IsTTLIndex  bool
 }
 
 var emptyConf = &configuration.Config{}
@@ -181,6 +202,8 @@ func prepareCollection(mongoCollectionName func(config configuration.Config) str
 				var err error
 				if index.IsTextIndex {
 					err = db.ensureTextIndex(collection, index.Name, keys[0])
+				} else if index.IsTTLIndex {
+					err = db.ensureTTLIndex(collection, index.Name, keys[0])
 				} else {
 					err = db.ensureIndex(collection, index.Name, keys[0], index.Asc, index.Unique)
 				}
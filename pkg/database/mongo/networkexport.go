@@ -0,0 +1,90 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mongo
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/SENERGY-Platform/process-sync/pkg/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type networkExportSource struct {
+	collection *mongo.Collection
+	key        string
+	decode     func(cursor *mongo.Cursor) (interface{}, error)
+}
+
+func (this *Mongo) networkExportSources() map[string]networkExportSource {
+	return map[string]networkExportSource{
+		model.NetworkExportDeployment:              {collection: this.deploymentCollection(), key: deploymentNetworkIdKey, decode: decodeExportDocument[model.Deployment]},
+		model.NetworkExportDeploymentMetadata:      {collection: this.deploymentMetadataCollection(), key: metadataNetworkIdKey, decode: decodeExportDocument[model.DeploymentMetadata]},
+		model.NetworkExportProcessDefinition:       {collection: this.processDefinitionCollection(), key: definitionNetworkIdKey, decode: decodeExportDocument[model.ProcessDefinition]},
+		model.NetworkExportProcessInstance:         {collection: this.processInstanceCollection(), key: instanceNetworkIdKey, decode: decodeExportDocument[model.ProcessInstance]},
+		model.NetworkExportHistoricProcessInstance: {collection: this.processHistoryCollection(), key: historyNetworkIdKey, decode: decodeExportDocument[model.HistoricProcessInstance]},
+		model.NetworkExportIncident:                {collection: this.incidentCollection(), key: incidentNetworkIdKey, decode: decodeExportDocument[model.Incident]},
+		model.NetworkExportDeploymentWardenInfo:    {collection: this.deploymentWardenCollection(), key: deploymentWardenNetworkIdKey, decode: decodeExportDocument[model.DeploymentWardenInfo]},
+		model.NetworkExportWardenInfo:              {collection: this.wardenCollection(), key: wardenNetworkIdKey, decode: decodeExportDocument[model.WardenInfo]},
+	}
+}
+
+func decodeExportDocument[T any](cursor *mongo.Cursor) (interface{}, error) {
+	var element T
+	err := cursor.Decode(&element)
+	return element, err
+}
+
+// ListNetworkExportDocuments returns up to limit documents of the record type (see model.NetworkExportRecord) of the network, ordered by _id;
+// the returned lastId continues the listing in the next call (afterId), so that documents saved or removed during an export do not shift the pages
+func (this *Mongo) ListNetworkExportDocuments(networkId string, recordType string, afterId string, limit int64) (documents []interface{}, lastId string, err error) {
+	source, ok := this.networkExportSources()[recordType]
+	if !ok {
+		return nil, "", fmt.Errorf("unknown network export record type %q", recordType)
+	}
+	filter := bson.M{source.key: networkId}
+	if afterId != "" {
+		after, err := primitive.ObjectIDFromHex(afterId)
+		if err != nil {
+			return nil, "", err
+		}
+		filter["_id"] = bson.M{"$gt": after}
+	}
+	opt := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}).SetLimit(limit)
+	ctx, _ := this.getTimeoutContext()
+	cursor, err := source.collection.Find(ctx, filter, opt)
+	if err != nil {
+		return nil, "", err
+	}
+	for cursor.Next(ctx) {
+		id, ok := cursor.Current.Lookup("_id").ObjectIDOK()
+		if !ok {
+			return nil, "", errors.New("unexpected _id type in " + source.collection.Name())
+		}
+		element, err := source.decode(cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		documents = append(documents, element)
+		lastId = id.Hex()
+	}
+	err = cursor.Err()
+	return documents, lastId, err
+}
@@ -0,0 +1,62 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mongo
+
+import (
+	"time"
+
+	"github.com/SENERGY-Platform/process-sync/pkg/model"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func (this *Mongo) CountNetworkStatus(networkId string, placeholderSyncedBefore time.Time) (result model.NetworkStatusCounts, err error) {
+	ctx, _ := this.getTimeoutContext()
+	result.WaitingPlaceholderDeployments, err = this.deploymentCollection().CountDocuments(ctx, bson.M{
+		deploymentNetworkIdKey:   networkId,
+		deploymentPlaceholderKey: true,
+		deploymentSyncDateKey:    bson.M{"$lt": placeholderSyncedBefore},
+	})
+	if err != nil {
+		return result, err
+	}
+	result.WaitingPlaceholderProcessInstances, err = this.processInstanceCollection().CountDocuments(ctx, bson.M{
+		instanceNetworkIdKey:   networkId,
+		instancePlaceholderKey: true,
+		instanceSyncDateKey:    bson.M{"$lt": placeholderSyncedBefore},
+	})
+	if err != nil {
+		return result, err
+	}
+	result.MissingDeployments, err = this.deploymentCollection().CountDocuments(ctx, bson.M{
+		deploymentNetworkIdKey:       networkId,
+		deploymentMarkedAsMissingKey: true,
+	})
+	if err != nil {
+		return result, err
+	}
+	result.DeploymentsMarkedForDelete, err = this.deploymentCollection().CountDocuments(ctx, bson.M{
+		deploymentNetworkIdKey:       networkId,
+		deploymentMarkedForDeleteKey: true,
+	})
+	if err != nil {
+		return result, err
+	}
+	result.OpenIncidents, err = this.incidentCollection().CountDocuments(ctx, bson.M{
+		incidentNetworkIdKey: networkId,
+	})
+	return result, err
+}
@@ -0,0 +1,266 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mongo
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/SENERGY-Platform/process-sync/pkg/configuration"
+	"github.com/SENERGY-Platform/process-sync/pkg/database"
+	"github.com/SENERGY-Platform/process-sync/pkg/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var scheduleIdKey string
+var scheduleNetworkIdKey string
+var scheduleDeploymentIdKey string
+var scheduleDisabledKey string
+var scheduleNextRunKey string
+var scheduleLastRunKey string
+var scheduleLockedUntilKey string
+var scheduleLockOwnerKey string
+
+func init() {
+	prepareCollection(func(config configuration.Config) string {
+		return config.MongoScheduleCollection
+	},
+		model.Schedule{},
+		[]KeyMapping{
+			{
+				FieldName: "Id",
+				Key:       &scheduleIdKey,
+			},
+			{
+				FieldName: "NetworkId",
+				Key:       &scheduleNetworkIdKey,
+			},
+			{
+				FieldName: "DeploymentId",
+				Key:       &scheduleDeploymentIdKey,
+			},
+			{
+				FieldName: "Disabled",
+				Key:       &scheduleDisabledKey,
+			},
+			{
+				FieldName: "NextRun",
+				Key:       &scheduleNextRunKey,
+			},
+			{
+				FieldName: "LastRun",
+				Key:       &scheduleLastRunKey,
+			},
+			{
+				FieldName: "LockedUntil",
+				Key:       &scheduleLockedUntilKey,
+			},
+			{
+				FieldName: "LockOwner",
+				Key:       &scheduleLockOwnerKey,
+			},
+		},
+		[]IndexDesc{
+			{
+				Name:   "schedule_networkid_id_index",
+				Unique: true,
+				Asc:    true,
+				Keys:   []*string{&scheduleNetworkIdKey, &scheduleIdKey},
+			},
+			{
+				Name:   "schedule_networkid_deploymentid_index",
+				Unique: false,
+				Asc:    true,
+				Keys:   []*string{&scheduleNetworkIdKey, &scheduleDeploymentIdKey},
+			},
+			{
+				Name:   "schedule_nextrun_index",
+				Unique: false,
+				Asc:    true,
+				Keys:   []*string{&scheduleNextRunKey},
+			},
+		},
+	)
+}
+
+func (this *Mongo) scheduleCollection() *mongo.Collection {
+	return this.client.Database(this.config.MongoTable).Collection(this.config.MongoScheduleCollection)
+}
+
+func (this *Mongo) SaveSchedule(schedule model.Schedule) error {
+	ctx, _ := this.getTimeoutContext()
+	_, err := this.scheduleCollection().ReplaceOne(
+		ctx,
+		bson.M{
+			scheduleNetworkIdKey: schedule.NetworkId,
+			scheduleIdKey:        schedule.Id,
+		},
+		schedule,
+		options.Replace().SetUpsert(true))
+	return err
+}
+
+func (this *Mongo) RemoveSchedule(networkId string, id string) error {
+	ctx, _ := this.getTimeoutContext()
+	_, err := this.scheduleCollection().DeleteMany(
+		ctx,
+		bson.M{
+			scheduleNetworkIdKey: networkId,
+			scheduleIdKey:        id,
+		})
+	return err
+}
+
+func (this *Mongo) RemoveSchedulesOfDeployment(networkId string, deploymentId string) error {
+	ctx, _ := this.getTimeoutContext()
+	_, err := this.scheduleCollection().DeleteMany(
+		ctx,
+		bson.M{
+			scheduleNetworkIdKey:    networkId,
+			scheduleDeploymentIdKey: deploymentId,
+		})
+	return err
+}
+
+func (this *Mongo) ReadSchedule(networkId string, id string) (schedule model.Schedule, err error) {
+	ctx, _ := this.getTimeoutContext()
+	result := this.scheduleCollection().FindOne(
+		ctx,
+		bson.M{
+			scheduleNetworkIdKey: networkId,
+			scheduleIdKey:        id,
+		})
+	err = result.Err()
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return schedule, database.ErrNotFound
+	}
+	if err != nil {
+		return
+	}
+	err = result.Decode(&schedule)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return schedule, database.ErrNotFound
+	}
+	return schedule, err
+}
+
+func (this *Mongo) ListSchedules(query model.ScheduleQuery) (result []model.Schedule, err error) {
+	opt := options.Find()
+	opt.SetLimit(query.Limit)
+	opt.SetSkip(query.Offset)
+
+	if query.Sort == "" {
+		query.Sort = "id"
+	}
+	parts := strings.Split(query.Sort, ".")
+	sortby := scheduleIdKey
+	switch parts[0] {
+	case "id":
+		sortby = scheduleIdKey
+	case "deployment_id":
+		sortby = scheduleDeploymentIdKey
+	case "next_run":
+		sortby = scheduleNextRunKey
+	}
+	direction := int32(1)
+	if len(parts) > 1 && parts[1] == "desc" {
+		direction = int32(-1)
+	}
+	opt.SetSort(bson.D{{Key: sortby, Value: direction}})
+
+	filter := bson.M{}
+	if query.NetworkIds != nil {
+		filter[scheduleNetworkIdKey] = bson.M{"$in": query.NetworkIds}
+	}
+	if query.DeploymentIds != nil {
+		filter[scheduleDeploymentIdKey] = bson.M{"$in": query.DeploymentIds}
+	}
+
+	ctx, _ := this.getTimeoutContext()
+	cursor, err := this.scheduleCollection().Find(ctx, filter, opt)
+	if err != nil {
+		return nil, err
+	}
+	for cursor.Next(ctx) {
+		element := model.Schedule{}
+		err = cursor.Decode(&element)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, element)
+	}
+	err = cursor.Err()
+	return
+}
+
+// ClaimDueSchedule atomically locks one enabled schedule with next_run <= now which is not locked by another owner.
+// the lock expires after lockDuration to allow other replicas to take over if the owner dies while handling the schedule.
+func (this *Mongo) ClaimDueSchedule(now time.Time, lockDuration time.Duration, owner string) (schedule model.Schedule, found bool, err error) {
+	ctx, _ := this.getTimeoutContext()
+	result := this.scheduleCollection().FindOneAndUpdate(
+		ctx,
+		bson.M{
+			scheduleDisabledKey:    false,
+			scheduleNextRunKey:     bson.M{"$lte": now},
+			scheduleLockedUntilKey: bson.M{"$lt": now},
+		},
+		bson.M{
+			"$set": bson.M{
+				scheduleLockedUntilKey: now.Add(lockDuration),
+				scheduleLockOwnerKey:   owner,
+			},
+		},
+		options.FindOneAndUpdate().
+			SetReturnDocument(options.After).
+			SetSort(bson.D{{Key: scheduleNextRunKey, Value: 1}}))
+	err = result.Err()
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return schedule, false, nil
+	}
+	if err != nil {
+		return schedule, false, err
+	}
+	err = result.Decode(&schedule)
+	if err != nil {
+		return schedule, false, err
+	}
+	return schedule, true, nil
+}
+
+// FinishScheduleRun stores the run result and the next run time and releases the lock; updates are ignored if the lock is held by another owner.
+func (this *Mongo) FinishScheduleRun(networkId string, id string, owner string, nextRun time.Time, run model.ScheduleRun) error {
+	ctx, _ := this.getTimeoutContext()
+	_, err := this.scheduleCollection().UpdateOne(
+		ctx,
+		bson.M{
+			scheduleNetworkIdKey: networkId,
+			scheduleIdKey:        id,
+			scheduleLockOwnerKey: owner,
+		},
+		bson.M{
+			"$set": bson.M{
+				scheduleNextRunKey:     nextRun,
+				scheduleLastRunKey:     run,
+				scheduleLockedUntilKey: time.Time{},
+				scheduleLockOwnerKey:   "",
+			},
+		})
+	return err
+}
@@ -0,0 +1,165 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mongo
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SENERGY-Platform/process-sync/pkg/configuration"
+	"github.com/SENERGY-Platform/process-sync/pkg/model"
+	"github.com/SENERGY-Platform/process-sync/pkg/tests/docker"
+)
+
+func TestClaimDueSchedule(t *testing.T) {
+	wg := &sync.WaitGroup{}
+	defer wg.Wait()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mongoPort, _, err := docker.Mongo(ctx, wg)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	db, err := New(configuration.Config{
+		MongoUrl:                          "mongodb://localhost:" + mongoPort,
+		MongoTable:                        "processes",
+		MongoProcessDefinitionCollection:  "process_definition",
+		MongoDeploymentCollection:         "deployments",
+		MongoProcessHistoryCollection:     "histories",
+		MongoIncidentCollection:           "incidents",
+		MongoProcessInstanceCollection:    "instances",
+		MongoDeploymentMetadataCollection: "deployment_metadata",
+		MongoLastNetworkContactCollection: "last_network_collection",
+		MongoWardenCollection:             "warden",
+		MongoDeploymentWardenCollection:   "deployment_warden",
+		MongoScheduleCollection:           "schedules",
+		MongoLeaseCollection:              "leases",
+		MongoWardenDecisionCollection:     "warden_decisions",
+		MongoAuditCollection:              "audit",
+		MongoIncidentGroupCollection:      "incident_groups",
+		MongoWardenCheckCollection:        "warden_checks",
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	now := time.Now().Truncate(time.Millisecond)
+
+	for _, schedule := range []model.Schedule{
+		{Id: "due", NetworkId: "n1", DeploymentId: "d1", Cron: "@daily", NextRun: now.Add(-time.Minute)},
+		{Id: "future", NetworkId: "n1", DeploymentId: "d1", Cron: "@daily", NextRun: now.Add(time.Hour)},
+		{Id: "disabled", NetworkId: "n1", DeploymentId: "d1", Cron: "@daily", NextRun: now.Add(-time.Minute), Disabled: true},
+	} {
+		err = db.SaveSchedule(schedule)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+	}
+
+	check := func(owner string, now time.Time, expectedId string) func(t *testing.T) {
+		return func(t *testing.T) {
+			schedule, found, err := db.ClaimDueSchedule(now, time.Minute, owner)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if expectedId == "" {
+				if found {
+					t.Error("unexpected claim", schedule.Id, schedule.LockOwner)
+				}
+				return
+			}
+			if !found || schedule.Id != expectedId || schedule.LockOwner != owner {
+				t.Error("unexpected claim", found, schedule.Id, schedule.LockOwner)
+			}
+		}
+	}
+
+	t.Run("a claims due schedule", check("a", now, "due"))
+	t.Run("b finds nothing while locked", check("b", now.Add(time.Second), ""))
+	t.Run("finish by other owner is ignored", func(t *testing.T) {
+		err = db.FinishScheduleRun("n1", "due", "b", now.Add(24*time.Hour), model.ScheduleRun{Time: now, Status: model.ScheduleRunStatusStarted})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		schedule, err := db.ReadSchedule("n1", "due")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if schedule.LastRun != nil || schedule.LockOwner != "a" {
+			t.Error(schedule.LastRun, schedule.LockOwner)
+		}
+	})
+	t.Run("b takes over expired lock", check("b", now.Add(2*time.Minute), "due"))
+	t.Run("b finishes run", func(t *testing.T) {
+		err = db.FinishScheduleRun("n1", "due", "b", now.Add(24*time.Hour), model.ScheduleRun{Time: now, Status: model.ScheduleRunStatusStarted})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		schedule, err := db.ReadSchedule("n1", "due")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if schedule.LastRun == nil || schedule.LockOwner != "" || !schedule.NextRun.Equal(now.Add(24*time.Hour)) {
+			t.Error(schedule.LastRun, schedule.LockOwner, schedule.NextRun)
+		}
+	})
+	t.Run("nothing due after run", check("a", now.Add(2*time.Minute), ""))
+
+	t.Run("concurrent claims", func(t *testing.T) {
+		err = db.SaveSchedule(model.Schedule{Id: "concurrent", NetworkId: "n1", DeploymentId: "d1", Cron: "@daily", NextRun: now})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		mux := sync.Mutex{}
+		claims := 0
+		claimWg := sync.WaitGroup{}
+		for _, owner := range []string{"a", "b", "c", "d"} {
+			claimWg.Add(1)
+			go func() {
+				defer claimWg.Done()
+				_, found, err := db.ClaimDueSchedule(now.Add(3*time.Minute), time.Minute, owner)
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				if found {
+					mux.Lock()
+					claims++
+					mux.Unlock()
+				}
+			}()
+		}
+		claimWg.Wait()
+		if claims != 1 {
+			t.Error("expect exactly one claim", claims)
+		}
+	})
+}
@@ -30,9 +30,11 @@ import (
 var wardenDeploymentIdKey string
 var wardenNetworkIdKey string
 var wardenBusinessKeyKey string
+var wardenPausedKey string
 
 var deploymentWardenDeploymentIdKey string
 var deploymentWardenNetworkIdKey string
+var deploymentWardenPausedKey string
 
 func init() {
 	prepareCollection(func(config configuration.Config) string {
@@ -52,6 +54,10 @@ func init() {
 				FieldName: "BusinessKey",
 				Key:       &wardenBusinessKeyKey,
 			},
+			{
+				FieldName: "Paused",
+				Key:       &wardenPausedKey,
+			},
 		},
 		[]IndexDesc{
 			{
@@ -82,6 +88,10 @@ func init() {
 				FieldName: "NetworkId",
 				Key:       &deploymentWardenNetworkIdKey,
 			},
+			{
+				FieldName: "Paused",
+				Key:       &deploymentWardenPausedKey,
+			},
 		},
 		[]IndexDesc{
 			{
@@ -264,3 +274,26 @@ func (this *Mongo) FindWardenInfo(query model.WardenInfoQuery) (result []model.W
 	err = cursor.Err()
 	return
 }
+
+// SetWardenPaused sets the paused flag of the deployment warden info and of all warden infos of the deployment
+func (this *Mongo) SetWardenPaused(networkId string, deploymentId string, paused bool) error {
+	ctx, _ := this.getTimeoutContext()
+	_, err := this.deploymentWardenCollection().UpdateMany(
+		ctx,
+		bson.M{
+			deploymentWardenNetworkIdKey:    networkId,
+			deploymentWardenDeploymentIdKey: deploymentId,
+		},
+		bson.M{"$set": bson.M{deploymentWardenPausedKey: paused}})
+	if err != nil {
+		return err
+	}
+	_, err = this.wardenCollection().UpdateMany(
+		ctx,
+		bson.M{
+			wardenNetworkIdKey:    networkId,
+			wardenDeploymentIdKey: deploymentId,
+		},
+		bson.M{"$set": bson.M{wardenPausedKey: paused}})
+	return err
+}
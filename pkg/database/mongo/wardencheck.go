@@ -0,0 +1,124 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mongo
+
+import (
+	"time"
+
+	"github.com/SENERGY-Platform/process-sync/pkg/configuration"
+	"github.com/SENERGY-Platform/process-sync/pkg/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var wardenCheckNetworkIdKey string
+var wardenCheckBusinessKeyKey string
+var wardenCheckDeploymentIdKey string
+var wardenCheckDueKey string
+
+func init() {
+	prepareCollection(func(config configuration.Config) string {
+		return config.MongoWardenCheckCollection
+	},
+		model.WardenCheckRequest{},
+		[]KeyMapping{
+			{
+				FieldName: "NetworkId",
+				Key:       &wardenCheckNetworkIdKey,
+			},
+			{
+				FieldName: "BusinessKey",
+				Key:       &wardenCheckBusinessKeyKey,
+			},
+			{
+				FieldName: "DeploymentId",
+				Key:       &wardenCheckDeploymentIdKey,
+			},
+			{
+				FieldName: "Due",
+				Key:       &wardenCheckDueKey,
+			},
+		},
+		[]IndexDesc{
+			{
+				Name:   "warden_check_target_index",
+				Unique: true,
+				Asc:    true,
+				Keys:   []*string{&wardenCheckNetworkIdKey, &wardenCheckBusinessKeyKey, &wardenCheckDeploymentIdKey},
+			},
+			{
+				Name:   "warden_check_due_index",
+				Unique: false,
+				Asc:    true,
+				Keys:   []*string{&wardenCheckDueKey},
+			},
+		},
+	)
+}
+
+func (this *Mongo) wardenCheckCollection() *mongo.Collection {
+	return this.client.Database(this.config.MongoTable).Collection(this.config.MongoWardenCheckCollection)
+}
+
+// PushWardenCheck stores the request; a pending request for the same target is postponed to the new due time
+func (this *Mongo) PushWardenCheck(request model.WardenCheckRequest) error {
+	ctx, _ := this.getTimeoutContext()
+	_, err := this.wardenCheckCollection().ReplaceOne(
+		ctx,
+		bson.M{
+			wardenCheckNetworkIdKey:    request.NetworkId,
+			wardenCheckBusinessKeyKey:  request.BusinessKey,
+			wardenCheckDeploymentIdKey: request.DeploymentId,
+		},
+		request,
+		options.Replace().SetUpsert(true))
+	return err
+}
+
+// PopDueWardenChecks removes and returns up to limit requests with due <= now;
+// requests postponed while they are read are not removed and not returned
+func (this *Mongo) PopDueWardenChecks(now time.Time, limit int64) (result []model.WardenCheckRequest, err error) {
+	ctx, _ := this.getTimeoutContext()
+	cursor, err := this.wardenCheckCollection().Find(
+		ctx,
+		bson.M{wardenCheckDueKey: bson.M{"$lte": now}},
+		options.Find().SetLimit(limit).SetSort(bson.D{{Key: wardenCheckDueKey, Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	due := []model.WardenCheckRequest{}
+	err = cursor.All(ctx, &due)
+	if err != nil {
+		return nil, err
+	}
+	for _, request := range due {
+		deleted, err := this.wardenCheckCollection().DeleteOne(ctx, bson.M{
+			wardenCheckNetworkIdKey:    request.NetworkId,
+			wardenCheckBusinessKeyKey:  request.BusinessKey,
+			wardenCheckDeploymentIdKey: request.DeploymentId,
+			wardenCheckDueKey:          request.Due,
+		})
+		if err != nil {
+			return result, err
+		}
+		if deleted.DeletedCount > 0 {
+			result = append(result, request)
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,167 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mongo
+
+import (
+	"strings"
+
+	"github.com/SENERGY-Platform/process-sync/pkg/configuration"
+	"github.com/SENERGY-Platform/process-sync/pkg/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var wardenDecisionIdKey string
+var wardenDecisionTimeKey string
+var wardenDecisionNetworkIdKey string
+var wardenDecisionEntityIdKey string
+var wardenDecisionBusinessKeyKey string
+var wardenDecisionDecisionKey string
+var wardenDecisionExpiresAtKey string
+
+func init() {
+	prepareCollection(func(config configuration.Config) string {
+		return config.MongoWardenDecisionCollection
+	},
+		model.WardenDecision{},
+		[]KeyMapping{
+			{
+				FieldName: "Id",
+				Key:       &wardenDecisionIdKey,
+			},
+			{
+				FieldName: "Time",
+				Key:       &wardenDecisionTimeKey,
+			},
+			{
+				FieldName: "NetworkId",
+				Key:       &wardenDecisionNetworkIdKey,
+			},
+			{
+				FieldName: "EntityId",
+				Key:       &wardenDecisionEntityIdKey,
+			},
+			{
+				FieldName: "BusinessKey",
+				Key:       &wardenDecisionBusinessKeyKey,
+			},
+			{
+				FieldName: "Decision",
+				Key:       &wardenDecisionDecisionKey,
+			},
+			{
+				FieldName: "ExpiresAt",
+				Key:       &wardenDecisionExpiresAtKey,
+			},
+		},
+		[]IndexDesc{
+			{
+				Name:   "warden_decision_id_index",
+				Unique: true,
+				Asc:    true,
+				Keys:   []*string{&wardenDecisionIdKey},
+			},
+			{
+				Name:   "warden_decision_networkid_time_index",
+				Unique: false,
+				Asc:    true,
+				Keys:   []*string{&wardenDecisionNetworkIdKey, &wardenDecisionTimeKey},
+			},
+			{
+				Name:   "warden_decision_networkid_entityid_index",
+				Unique: false,
+				Asc:    true,
+				Keys:   []*string{&wardenDecisionNetworkIdKey, &wardenDecisionEntityIdKey},
+			},
+			{
+				Name:       "warden_decision_expiration_index",
+				Asc:        true,
+				Keys:       []*string{&wardenDecisionExpiresAtKey},
+				IsTTLIndex: true,
+			},
+		},
+	)
+}
+
+func (this *Mongo) wardenDecisionCollection() *mongo.Collection {
+	return this.client.Database(this.config.MongoTable).Collection(this.config.MongoWardenDecisionCollection)
+}
+
+func (this *Mongo) SaveWardenDecision(decision model.WardenDecision) error {
+	ctx, _ := this.getTimeoutContext()
+	_, err := this.wardenDecisionCollection().InsertOne(ctx, decision)
+	return err
+}
+
+func (this *Mongo) ListWardenDecisions(query model.WardenDecisionQuery) (result []model.WardenDecision, err error) {
+	opt := options.Find()
+	opt.SetLimit(query.Limit)
+	opt.SetSkip(query.Offset)
+
+	if query.Sort == "" {
+		query.Sort = "time.desc"
+	}
+	parts := strings.Split(query.Sort, ".")
+	sortby := wardenDecisionTimeKey
+	switch parts[0] {
+	case "time":
+		sortby = wardenDecisionTimeKey
+	case "entity_id":
+		sortby = wardenDecisionEntityIdKey
+	case "decision":
+		sortby = wardenDecisionDecisionKey
+	}
+	direction := int32(1)
+	if len(parts) > 1 && parts[1] == "desc" {
+		direction = int32(-1)
+	}
+	opt.SetSort(bson.D{{Key: sortby, Value: direction}, {Key: wardenDecisionIdKey, Value: direction}})
+
+	filter := bson.M{}
+	if query.NetworkIds != nil {
+		filter[wardenDecisionNetworkIdKey] = bson.M{"$in": query.NetworkIds}
+	}
+	if query.EntityIds != nil {
+		filter[wardenDecisionEntityIdKey] = bson.M{"$in": query.EntityIds}
+	}
+	if query.BusinessKeys != nil {
+		filter[wardenDecisionBusinessKeyKey] = bson.M{"$in": query.BusinessKeys}
+	}
+	if query.Decisions != nil {
+		filter[wardenDecisionDecisionKey] = bson.M{"$in": query.Decisions}
+	}
+	if !query.Since.IsZero() {
+		filter[wardenDecisionTimeKey] = bson.M{"$gte": query.Since}
+	}
+
+	ctx, _ := this.getTimeoutContext()
+	cursor, err := this.wardenDecisionCollection().Find(ctx, filter, opt)
+	if err != nil {
+		return nil, err
+	}
+	for cursor.Next(ctx) {
+		element := model.WardenDecision{}
+		err = cursor.Decode(&element)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, element)
+	}
+	err = cursor.Err()
+	return
+}
@@ -0,0 +1,260 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package envelope signs and optionally encrypts mqtt messages between process-sync and the edge clients.
+// The payload is wrapped in a json envelope with a detached Ed25519 signature over the topic, timestamp, nonce, key id, encryption and payload;
+// encrypted payloads use AES-256-GCM with the nonce of the envelope and the topic as additional data.
+package envelope
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/SENERGY-Platform/process-sync/pkg/configuration"
+)
+
+const Version = 1
+
+const EncryptionAes256Gcm = "A256GCM"
+
+const nonceSize = 12 //AES-GCM standard nonce size
+
+var ErrInvalidEnvelope = errors.New("invalid envelope")
+var ErrInvalidSignature = errors.New("invalid envelope signature")
+var ErrExpired = errors.New("envelope timestamp outside of the accepted time window")
+var ErrReplay = errors.New("envelope nonce already used")
+
+type Envelope struct {
+	Version    int    `json:"version"`
+	Topic      string `json:"topic"`
+	Timestamp  int64  `json:"timestamp"` //unix milliseconds
+	Nonce      string `json:"nonce"`     //base64 of 12 random bytes
+	KeyId      string `json:"key_id"`    //id of the signing key
+	Encryption string `json:"encryption,omitempty"`
+	Payload    string `json:"payload"`   //base64 of the payload or the encrypted payload
+	Signature  string `json:"signature"` //base64 of the Ed25519 signature of SigningInput()
+
+	TraceContext map[string]string `json:"trace_context,omitempty"` //w3c trace context; not signed, only used to continue traces
+}
+
+// SigningInput returns the signed bytes: the fields of the envelope separated by new lines, starting with the version
+func (this Envelope) SigningInput() []byte {
+	buf := bytes.Buffer{}
+	for _, field := range []string{strconv.Itoa(this.Version), this.Topic, strconv.FormatInt(this.Timestamp, 10), this.Nonce, this.KeyId, this.Encryption, this.Payload} {
+		buf.WriteString(field)
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes()
+}
+
+// Parse returns ErrInvalidEnvelope if message is no envelope (e.g. a plain state message)
+func Parse(message []byte) (result Envelope, err error) {
+	if len(message) == 0 || message[0] != '{' {
+		return result, ErrInvalidEnvelope
+	}
+	err = json.Unmarshal(message, &result)
+	if err != nil || result.Version == 0 || result.Signature == "" {
+		return result, ErrInvalidEnvelope
+	}
+	if result.Version != Version {
+		return result, fmt.Errorf("%w: unsupported version %v", ErrInvalidEnvelope, result.Version)
+	}
+	return result, nil
+}
+
+type Signer struct {
+	key   ed25519.PrivateKey
+	keyId string
+}
+
+// NewSigner uses KeyId(key.Public()) if keyId is empty
+func NewSigner(key ed25519.PrivateKey, keyId string) *Signer {
+	if keyId == "" {
+		keyId = KeyId(key.Public().(ed25519.PublicKey))
+	}
+	return &Signer{key: key, keyId: keyId}
+}
+
+// LoadSigner reads a PKCS#8 PEM Ed25519 private key (e.g. 'openssl genpkey -algorithm ed25519')
+func LoadSigner(file string, keyId string) (*Signer, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(content)
+	if block == nil {
+		return nil, fmt.Errorf("no pem data found in %v", file)
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	edKey, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("expect ed25519 private key in %v", file)
+	}
+	return NewSigner(edKey, keyId), nil
+}
+
+// KeyId returns the hex encoded first 8 bytes of the sha256 hash of the public key
+func KeyId(key ed25519.PublicKey) string {
+	hash := sha256.Sum256(key)
+	return hex.EncodeToString(hash[:8])
+}
+
+func (this *Signer) PublicKey() ed25519.PublicKey {
+	return this.key.Public().(ed25519.PublicKey)
+}
+
+// Seal wraps payload in a signed envelope for topic; payload is encrypted if encryptionKey (32 bytes) is not empty.
+// traceContext is optional.
+func (this *Signer) Seal(topic string, payload []byte, encryptionKey []byte, traceContext map[string]string) ([]byte, error) {
+	nonce := make([]byte, nonceSize)
+	_, err := rand.Read(nonce)
+	if err != nil {
+		return nil, err
+	}
+	result := Envelope{
+		Version:   Version,
+		Topic:     topic,
+		Timestamp: configuration.TimeNow().UnixMilli(),
+		Nonce:     base64.StdEncoding.EncodeToString(nonce),
+		KeyId:     this.keyId,
+
+		TraceContext: traceContext,
+	}
+	if len(encryptionKey) > 0 {
+		aead, err := newAead(encryptionKey)
+		if err != nil {
+			return nil, err
+		}
+		payload = aead.Seal(nil, nonce, payload, []byte(topic))
+		result.Encryption = EncryptionAes256Gcm
+	}
+	result.Payload = base64.StdEncoding.EncodeToString(payload)
+	result.Signature = base64.StdEncoding.EncodeToString(ed25519.Sign(this.key, result.SigningInput()))
+	return json.Marshal(result)
+}
+
+func newAead(key []byte) (cipher.AEAD, error) {
+	if len(key) != 32 {
+		return nil, errors.New("expect 32 byte encryption key for " + EncryptionAes256Gcm)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// Verifier checks signature, topic, timestamp and nonce of received envelopes;
+// nonces are remembered for the accepted time window to reject replayed messages
+type Verifier struct {
+	maxAge time.Duration
+
+	mux       sync.Mutex
+	nonces    map[string]time.Time //nonce --> expiration
+	lastSweep time.Time
+}
+
+// NewVerifier accepts envelopes with timestamps differing less than maxAge from the current time
+func NewVerifier(maxAge time.Duration) *Verifier {
+	return &Verifier{maxAge: maxAge, nonces: map[string]time.Time{}}
+}
+
+// Open verifies the envelope, received on topic, with publicKey and returns the (decrypted) payload;
+// encrypted payloads require encryptionKey
+func (this *Verifier) Open(topic string, envelope Envelope, publicKey ed25519.PublicKey, encryptionKey []byte) (payload []byte, err error) {
+	if len(publicKey) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("%w: missing public key", ErrInvalidSignature)
+	}
+	signature, err := base64.StdEncoding.DecodeString(envelope.Signature)
+	if err != nil || !ed25519.Verify(publicKey, envelope.SigningInput(), signature) {
+		return nil, ErrInvalidSignature
+	}
+	if envelope.Topic != topic {
+		return nil, fmt.Errorf("%w: envelope for topic %v received on %v", ErrInvalidEnvelope, envelope.Topic, topic)
+	}
+	nonce, err := base64.StdEncoding.DecodeString(envelope.Nonce)
+	if err != nil || len(nonce) != nonceSize {
+		return nil, fmt.Errorf("%w: invalid nonce", ErrInvalidEnvelope)
+	}
+	payload, err = base64.StdEncoding.DecodeString(envelope.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid payload encoding", ErrInvalidEnvelope)
+	}
+	switch envelope.Encryption {
+	case "":
+	case EncryptionAes256Gcm:
+		if len(encryptionKey) == 0 {
+			return nil, fmt.Errorf("%w: missing encryption key", ErrInvalidEnvelope)
+		}
+		aead, err := newAead(encryptionKey)
+		if err != nil {
+			return nil, err
+		}
+		payload, err = aead.Open(nil, nonce, payload, []byte(envelope.Topic))
+		if err != nil {
+			return nil, fmt.Errorf("%w: unable to decrypt payload", ErrInvalidEnvelope)
+		}
+	default:
+		return nil, fmt.Errorf("%w: unsupported encryption %q", ErrInvalidEnvelope, envelope.Encryption)
+	}
+	err = this.useNonce(envelope)
+	if err != nil {
+		return nil, err
+	}
+	return payload, nil
+}
+
+// useNonce checks the timestamp and remembers the nonce until the timestamp leaves the accepted time window
+func (this *Verifier) useNonce(envelope Envelope) error {
+	now := configuration.TimeNow()
+	timestamp := time.UnixMilli(envelope.Timestamp)
+	if timestamp.Before(now.Add(-this.maxAge)) || timestamp.After(now.Add(this.maxAge)) {
+		return ErrExpired
+	}
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	if now.Sub(this.lastSweep) > this.maxAge {
+		this.lastSweep = now
+		for nonce, expiration := range this.nonces {
+			if now.After(expiration) {
+				delete(this.nonces, nonce)
+			}
+		}
+	}
+	if _, used := this.nonces[envelope.Nonce]; used {
+		return ErrReplay
+	}
+	this.nonces[envelope.Nonce] = timestamp.Add(this.maxAge)
+	return nil
+}
@@ -0,0 +1,240 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package envelope
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/SENERGY-Platform/process-sync/pkg/configuration"
+)
+
+func TestEnvelope(t *testing.T) {
+	now := time.Now()
+	configuration.TimeNow = func() time.Time { return now }
+	defer func() { configuration.TimeNow = time.Now }()
+
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer := NewSigner(key, "")
+	encryptionKey := make([]byte, 32)
+	_, err = rand.Read(encryptionKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	topic := "processes/n1/cmd/deployment"
+	payload := []byte(`{"id":"d1"}`)
+
+	seal := func(t *testing.T, encryptionKey []byte) Envelope {
+		t.Helper()
+		msg, err := signer.Seal(topic, payload, encryptionKey, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		result, err := Parse(msg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return result
+	}
+
+	t.Run("plain message", func(t *testing.T) {
+		_, err := Parse(payload)
+		if !errors.Is(err, ErrInvalidEnvelope) {
+			t.Error(err)
+		}
+	})
+
+	t.Run("signed", func(t *testing.T) {
+		env := seal(t, nil)
+		if env.KeyId != KeyId(signer.PublicKey()) || env.Encryption != "" {
+			t.Error(env)
+		}
+		result, err := NewVerifier(time.Minute).Open(topic, env, signer.PublicKey(), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(result, payload) {
+			t.Error(string(result))
+		}
+	})
+
+	t.Run("encrypted", func(t *testing.T) {
+		env := seal(t, encryptionKey)
+		if env.Encryption != EncryptionAes256Gcm || bytes.Contains([]byte(env.Payload), []byte("d1")) {
+			t.Error(env)
+		}
+		verifier := NewVerifier(time.Minute)
+		_, err := verifier.Open(topic, env, signer.PublicKey(), nil)
+		if !errors.Is(err, ErrInvalidEnvelope) {
+			t.Error(err)
+		}
+		result, err := verifier.Open(topic, env, signer.PublicKey(), encryptionKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(result, payload) {
+			t.Error(string(result))
+		}
+	})
+
+	t.Run("tampered", func(t *testing.T) {
+		env := seal(t, nil)
+		env.Payload = "e30="
+		_, err := NewVerifier(time.Minute).Open(topic, env, signer.PublicKey(), nil)
+		if !errors.Is(err, ErrInvalidSignature) {
+			t.Error(err)
+		}
+		otherPub, _, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = NewVerifier(time.Minute).Open(topic, seal(t, nil), otherPub, nil)
+		if !errors.Is(err, ErrInvalidSignature) {
+			t.Error(err)
+		}
+	})
+
+	t.Run("wrong topic", func(t *testing.T) {
+		_, err := NewVerifier(time.Minute).Open("processes/n2/cmd/deployment", seal(t, nil), signer.PublicKey(), nil)
+		if !errors.Is(err, ErrInvalidEnvelope) {
+			t.Error(err)
+		}
+	})
+
+	t.Run("replay", func(t *testing.T) {
+		env := seal(t, nil)
+		verifier := NewVerifier(time.Minute)
+		_, err := verifier.Open(topic, env, signer.PublicKey(), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = verifier.Open(topic, env, signer.PublicKey(), nil)
+		if !errors.Is(err, ErrReplay) {
+			t.Error(err)
+		}
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		env := seal(t, nil)
+		verifier := NewVerifier(time.Minute)
+		now = now.Add(2 * time.Minute)
+		defer func() { now = now.Add(-2 * time.Minute) }()
+		_, err := verifier.Open(topic, env, signer.PublicKey(), nil)
+		if !errors.Is(err, ErrExpired) {
+			t.Error(err)
+		}
+	})
+
+	t.Run("load signer", func(t *testing.T) {
+		der, err := x509.MarshalPKCS8PrivateKey(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		file := filepath.Join(t.TempDir(), "key.pem")
+		err = os.WriteFile(file, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0600)
+		if err != nil {
+			t.Fatal(err)
+		}
+		loaded, err := LoadSigner(file, "k1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		msg, err := loaded.Seal(topic, payload, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		env, err := Parse(msg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if env.KeyId != "k1" {
+			t.Error(env.KeyId)
+		}
+		_, err = NewVerifier(time.Minute).Open(topic, env, signer.PublicKey(), nil)
+		if err != nil {
+			t.Error(err)
+		}
+	})
+}
+
+func TestFileRegistry(t *testing.T) {
+	now := time.Now()
+	configuration.TimeNow = func() time.Time { return now }
+	defer func() { configuration.TimeNow = time.Now }()
+
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file := filepath.Join(t.TempDir(), "keys.json")
+	write := func(content interface{}) {
+		t.Helper()
+		temp, err := json.Marshal(content)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = os.WriteFile(file, temp, 0600)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(map[string]NetworkKeys{"n1": {PublicKey: pub}})
+
+	registry, err := NewFileRegistry(file, slog.Default())
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys, found := registry.Get("n1")
+	if !found || !bytes.Equal(keys.PublicKey, pub) || keys.EncryptionKey != nil {
+		t.Error(found, keys)
+	}
+
+	write(map[string]NetworkKeys{"n1": {PublicKey: pub, EncryptionKey: make([]byte, 32)}, "n2": {PublicKey: pub}})
+	if _, found = registry.Get("n2"); found {
+		t.Error("expect no reload before check interval")
+	}
+	now = now.Add(registryCheckInterval)
+	keys, found = registry.Get("n1")
+	if !found || len(keys.EncryptionKey) != 32 {
+		t.Error(found, keys)
+	}
+	if _, found = registry.Get("n2"); !found {
+		t.Error("expect reloaded n2")
+	}
+
+	write(map[string]NetworkKeys{"n3": {EncryptionKey: make([]byte, 16)}})
+	now = now.Add(registryCheckInterval)
+	if _, found = registry.Get("n2"); !found {
+		t.Error("expect previous keys after invalid file")
+	}
+	if _, found = registry.Get("n3"); found {
+		t.Error("expect invalid n3 to be ignored")
+	}
+}
@@ -0,0 +1,117 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package envelope
+
+import (
+	"crypto/ed25519"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/SENERGY-Platform/process-sync/pkg/configuration"
+)
+
+// registryCheckInterval limits how often the registry file is checked for changes
+const registryCheckInterval = 10 * time.Second
+
+// NetworkKeys are base64 encoded in the registry file
+type NetworkKeys struct {
+	EncryptionKey []byte            `json:"encryption_key,omitempty"` //32 byte AES-256-GCM key; commands to the network are encrypted if set
+	PublicKey     ed25519.PublicKey `json:"public_key,omitempty"`     //Ed25519 key of the edge client to verify its state messages
+}
+
+// FileRegistry reads the NetworkKeys per network id from a json file and reloads it if the file changes;
+// a file with invalid content is ignored and the previous keys are kept
+type FileRegistry struct {
+	file   string
+	logger *slog.Logger
+
+	mux       sync.Mutex
+	keys      map[string]NetworkKeys
+	modTime   time.Time
+	size      int64
+	checkedAt time.Time
+}
+
+func NewFileRegistry(file string, logger *slog.Logger) (*FileRegistry, error) {
+	result := &FileRegistry{file: file, logger: logger}
+	info, err := os.Stat(file)
+	if err != nil {
+		return nil, err
+	}
+	result.keys, err = readRegistryFile(file)
+	if err != nil {
+		return nil, err
+	}
+	result.modTime, result.size, result.checkedAt = info.ModTime(), info.Size(), configuration.TimeNow()
+	return result, nil
+}
+
+func (this *FileRegistry) Get(networkId string) (keys NetworkKeys, found bool) {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	this.reload()
+	keys, found = this.keys[networkId]
+	return keys, found
+}
+
+// reload expects a locked mux
+func (this *FileRegistry) reload() {
+	now := configuration.TimeNow()
+	if now.Sub(this.checkedAt) < registryCheckInterval {
+		return
+	}
+	this.checkedAt = now
+	info, err := os.Stat(this.file)
+	if err != nil {
+		this.logger.Warn("unable to check network key registry --> keep previous keys", "error", err, "file", this.file)
+		return
+	}
+	if info.ModTime().Equal(this.modTime) && info.Size() == this.size {
+		return
+	}
+	keys, err := readRegistryFile(this.file)
+	if err != nil {
+		this.logger.Error("unable to reload network key registry --> keep previous keys", "error", err, "file", this.file)
+		return
+	}
+	this.keys, this.modTime, this.size = keys, info.ModTime(), info.Size()
+	this.logger.Info("reloaded network key registry", "file", this.file, "networks", len(keys))
+}
+
+func readRegistryFile(file string) (keys map[string]NetworkKeys, err error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(content, &keys)
+	if err != nil {
+		return nil, err
+	}
+	for networkId, networkKeys := range keys {
+		if len(networkKeys.EncryptionKey) != 0 && len(networkKeys.EncryptionKey) != 32 {
+			return nil, fmt.Errorf("expect 32 byte encryption_key for network %v", networkId)
+		}
+		if len(networkKeys.PublicKey) != 0 && len(networkKeys.PublicKey) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("expect %v byte public_key for network %v", ed25519.PublicKeySize, networkId)
+		}
+	}
+	return keys, nil
+}
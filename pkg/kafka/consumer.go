@@ -25,6 +25,7 @@ import (
 	"time"
 
 	"github.com/SENERGY-Platform/process-sync/pkg/configuration"
+	"github.com/SENERGY-Platform/process-sync/pkg/metrics"
 	"github.com/segmentio/kafka-go"
 )
 
@@ -32,72 +33,150 @@ var FetchError = errors.New("unable to fetch from kafka")
 var HandlerError = errors.New("unable to handle kafka message")
 var CommitError = errors.New("unable to commit to kafka")
 
-func NewConsumer(ctx context.Context, config configuration.Config, topic string, listener func(delivery []byte) error, errorhandler func(err error) (fatal bool)) (err error) {
-	broker, err := GetBroker(config.KafkaUrl)
+const lagReportInterval = 15 * time.Second
+
+func NewConsumer(ctx context.Context, config configuration.Config, topic string, m *metrics.Metrics, listener func(delivery []byte) error, errorhandler func(err error) (fatal bool)) (err error) {
+	return newConsumer(ctx, config, topic, config.KafkaConsumerGroup, kafka.FirstOffset, m, listener, errorhandler)
+}
+
+// NewBroadcastConsumer delivers every message of topic to every instance of the service by reading all partitions without consumer group;
+// no offsets are committed, so that restarted instances leave no consumer groups behind. only messages produced after the start of the consumer are received
+func NewBroadcastConsumer(ctx context.Context, config configuration.Config, topic string, m *metrics.Metrics, listener func(delivery []byte) error, errorhandler func(err error) (fatal bool)) (err error) {
+	broker, err := initConsumerTopic(config, topic)
 	if err != nil {
-		config.GetLogger().Error("unable to get broker list", "error", err)
 		return err
 	}
-	if config.InitTopics {
-		err = InitTopic(config.KafkaUrl, topic)
+	conn, err := kafka.Dial("tcp", config.KafkaUrl)
+	if err != nil {
+		return err
+	}
+	partitions, err := conn.ReadPartitions(topic)
+	conn.Close()
+	if err != nil {
+		return err
+	}
+	readers := []*kafka.Reader{}
+	for _, partition := range partitions {
+		r := kafka.NewReader(kafka.ReaderConfig{
+			Brokers:     broker,
+			Topic:       topic,
+			Partition:   partition.ID,
+			MaxWait:     1 * time.Second,
+			Logger:      log.New(io.Discard, "", 0),
+			ErrorLogger: log.New(io.Discard, "", 0),
+		})
+		err = r.SetOffset(kafka.LastOffset)
 		if err != nil {
-			config.GetLogger().Error("unable to create topic", "error", err)
+			r.Close()
+			for _, reader := range readers {
+				reader.Close()
+			}
 			return err
 		}
+		readers = append(readers, r)
+	}
+	consume(ctx, config, topic, readers, false, m, listener, errorhandler)
+	return nil
+}
+
+func newConsumer(ctx context.Context, config configuration.Config, topic string, groupId string, startOffset int64, m *metrics.Metrics, listener func(delivery []byte) error, errorhandler func(err error) (fatal bool)) (err error) {
+	broker, err := initConsumerTopic(config, topic)
+	if err != nil {
+		return err
 	}
 	r := kafka.NewReader(kafka.ReaderConfig{
 		CommitInterval: 0, //synchronous commits
 		Brokers:        broker,
-		GroupID:        config.KafkaConsumerGroup,
+		GroupID:        groupId,
+		StartOffset:    startOffset,
 		Topic:          topic,
 		MaxWait:        1 * time.Second,
 		Logger:         log.New(io.Discard, "", 0),
 		ErrorLogger:    log.New(io.Discard, "", 0),
 	})
+	consume(ctx, config, topic, []*kafka.Reader{r}, true, m, listener, errorhandler)
+	return nil
+}
+
+func initConsumerTopic(config configuration.Config, topic string) (broker []string, err error) {
+	broker, err = GetBroker(config.KafkaUrl)
+	if err != nil {
+		config.GetLogger().Error("unable to get broker list", "error", err)
+		return broker, err
+	}
+	if config.InitTopics {
+		err = InitTopic(config.KafkaUrl, topic)
+		if err != nil {
+			config.GetLogger().Error("unable to create topic", "error", err)
+			return broker, err
+		}
+	}
+	return broker, nil
+}
+
+// consume passes the messages of the readers to listener until ctx is done; commit must be false for readers without consumer group
+func consume(ctx context.Context, config configuration.Config, topic string, readers []*kafka.Reader, commit bool, m *metrics.Metrics, listener func(delivery []byte) error, errorhandler func(err error) (fatal bool)) {
 	go func() {
-		defer r.Close()
-		defer func() { config.GetLogger().Info("close consumer", "topic", topic) }()
+		ticker := time.NewTicker(lagReportInterval)
+		defer ticker.Stop()
 		for {
 			select {
 			case <-ctx.Done():
 				return
-			default:
-				m, err := r.FetchMessage(ctx)
-				if errors.Is(err, io.EOF) || errors.Is(err, context.Canceled) {
-					return
+			case <-ticker.C:
+				var lag int64
+				for _, r := range readers {
+					lag += r.Stats().Lag
 				}
-				if err != nil {
-					fatal := errorhandler(fmt.Errorf("%w: %v", FetchError, err.Error()))
-					if fatal {
+				m.KafkaConsumerLag(topic, lag)
+			}
+		}
+	}()
+	for _, r := range readers {
+		go func() {
+			defer r.Close()
+			defer func() { config.GetLogger().Info("close consumer", "topic", topic) }()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				default:
+					m, err := r.FetchMessage(ctx)
+					if errors.Is(err, io.EOF) || errors.Is(err, context.Canceled) {
 						return
 					}
-				}
+					if err != nil {
+						fatal := errorhandler(fmt.Errorf("%w: %v", FetchError, err.Error()))
+						if fatal {
+							return
+						}
+					}
 
-				err = retry(func() error {
-					return listener(m.Value)
-				}, func(n int64) time.Duration {
-					return time.Duration(n) * time.Second
-				}, 10*time.Minute)
+					err = retry(func() error {
+						return listener(m.Value)
+					}, func(n int64) time.Duration {
+						return time.Duration(n) * time.Second
+					}, 10*time.Minute)
 
-				if err != nil {
-					fatal := errorhandler(fmt.Errorf("%w: %v", HandlerError, err.Error()))
-					if fatal {
-						return
-					}
-				} else {
-					err = r.CommitMessages(ctx, m)
 					if err != nil {
-						fatal := errorhandler(fmt.Errorf("%w: %v", CommitError, err.Error()))
+						fatal := errorhandler(fmt.Errorf("%w: %v", HandlerError, err.Error()))
 						if fatal {
 							return
 						}
+					} else if commit {
+						err = r.CommitMessages(ctx, m)
+						if err != nil {
+							fatal := errorhandler(fmt.Errorf("%w: %v", CommitError, err.Error()))
+							if fatal {
+								return
+							}
+						}
 					}
-				}
 
+				}
 			}
-		}
-	}()
-	return nil
+		}()
+	}
 }
 
 func retry(f func() error, waitProvider func(n int64) time.Duration, timeout time.Duration) (err error) {
@@ -0,0 +1,68 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lease
+
+import (
+	"os"
+	"time"
+
+	"github.com/SENERGY-Platform/process-sync/pkg/configuration"
+	"github.com/SENERGY-Platform/process-sync/pkg/database"
+)
+
+// Elector acquires named leases in the database to ensure that work is done by only one replica at a time.
+// a lease is renewed by calling TryAcquire again before it expires; if the holder dies, another replica may acquire the lease after it expired.
+type Elector struct {
+	db       database.Database
+	holder   string
+	duration time.Duration
+}
+
+func New(db database.Database, duration time.Duration) *Elector {
+	return &Elector{
+		db:       db,
+		holder:   NewHolderId(),
+		duration: duration,
+	}
+}
+
+// NewHolderId returns a unique id for this process, prefixed with the hostname (pod name) to be recognisable in the lease list
+func NewHolderId() string {
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		return configuration.Id()
+	}
+	return hostname + "/" + configuration.Id()
+}
+
+func (this *Elector) Holder() string {
+	return this.holder
+}
+
+// Duration is the time a lease stays valid after TryAcquire
+func (this *Elector) Duration() time.Duration {
+	return this.duration
+}
+
+func (this *Elector) TryAcquire(name string) (acquired bool, err error) {
+	_, acquired, err = this.db.TryAcquireLease(name, this.holder, configuration.TimeNow(), this.duration)
+	return acquired, err
+}
+
+func (this *Elector) Release(name string) error {
+	return this.db.ReleaseLease(name, this.holder)
+}
@@ -0,0 +1,268 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package metrics
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Metrics holds the prometheus metrics of the service; all methods may be called on a nil *Metrics, which records nothing
+type Metrics struct {
+	registry    *prometheus.Registry
+	httphandler http.Handler
+
+	wardenDecisions    *prometheus.CounterVec
+	wardenLoopDuration *prometheus.HistogramVec
+
+	mqttMessagesReceived *prometheus.CounterVec
+	mqttCommandsSent     *prometheus.CounterVec
+	mqttHandlerDuration  *prometheus.HistogramVec
+	mqttHandlerErrors    *prometheus.CounterVec
+
+	mongoOperationDuration *prometheus.HistogramVec
+
+	apiRequests        *prometheus.CounterVec
+	apiRequestDuration *prometheus.HistogramVec
+
+	kafkaConsumerLag *prometheus.GaugeVec
+
+	notifications *prometheus.CounterVec
+
+	permissionCache *prometheus.CounterVec
+}
+
+func New() *Metrics {
+	reg := prometheus.NewRegistry()
+	result := &Metrics{
+		registry:    reg,
+		httphandler: promhttp.HandlerFor(reg, promhttp.HandlerOpts{Registry: reg}),
+		wardenDecisions: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "process_sync_warden_decisions_total",
+			Help: "count of decisions made by the warden",
+		}, []string{"decision", "dry_run", "failed"}),
+		wardenLoopDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "process_sync_warden_loop_duration_seconds",
+			Help:    "duration of warden loops and event driven warden checks",
+			Buckets: prometheus.ExponentialBuckets(0.01, 4, 10),
+		}, []string{"loop"}),
+		mqttMessagesReceived: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "process_sync_mqtt_messages_received_total",
+			Help: "count of mqtt messages received from process networks",
+		}, []string{"topic_type"}),
+		mqttCommandsSent: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "process_sync_mqtt_commands_sent_total",
+			Help: "count of mqtt commands sent to process networks",
+		}, []string{"topic_type", "failed"}),
+		mqttHandlerDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "process_sync_mqtt_handler_duration_seconds",
+			Help:    "duration of the handling of received mqtt messages",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"topic_type"}),
+		mqttHandlerErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "process_sync_mqtt_handler_errors_total",
+			Help: "count of errors while handling received mqtt messages",
+		}, []string{"topic_type"}),
+		mongoOperationDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "process_sync_mongo_operation_duration_seconds",
+			Help:    "duration of mongodb operations",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"method", "collection", "failed"}),
+		apiRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "process_sync_api_requests_total",
+			Help: "count of api requests",
+		}, []string{"endpoint", "status"}),
+		apiRequestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "process_sync_api_request_duration_seconds",
+			Help:    "duration of api requests",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"endpoint"}),
+		kafkaConsumerLag: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "process_sync_kafka_consumer_lag",
+			Help: "count of messages the kafka consumer is behind the latest offset",
+		}, []string{"topic"}),
+		notifications: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "process_sync_notifications_total",
+			Help: "count of user notifications per channel and result (sent, failed, deduplicated, rate_limited)",
+		}, []string{"channel", "result"}),
+		permissionCache: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "process_sync_permission_cache_total",
+			Help: "count of permission checks answered by the cache (hit) or by permissions-v2 (miss)",
+		}, []string{"result"}),
+	}
+	reg.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		result.wardenDecisions,
+		result.wardenLoopDuration,
+		result.mqttMessagesReceived,
+		result.mqttCommandsSent,
+		result.mqttHandlerDuration,
+		result.mqttHandlerErrors,
+		result.mongoOperationDuration,
+		result.apiRequests,
+		result.apiRequestDuration,
+		result.kafkaConsumerLag,
+		result.notifications,
+		result.permissionCache,
+	)
+	return result
+}
+
+func (this *Metrics) WardenDecision(decision string, dryRun bool, failed bool) {
+	if this == nil {
+		return
+	}
+	this.wardenDecisions.WithLabelValues(decision, strconv.FormatBool(dryRun), strconv.FormatBool(failed)).Inc()
+}
+
+func (this *Metrics) WardenLoop(loop string, duration time.Duration) {
+	if this == nil {
+		return
+	}
+	this.wardenLoopDuration.WithLabelValues(loop).Observe(duration.Seconds())
+}
+
+func (this *Metrics) MqttMessageReceived(topicType string) {
+	if this == nil {
+		return
+	}
+	this.mqttMessagesReceived.WithLabelValues(topicType).Inc()
+}
+
+func (this *Metrics) MqttMessageHandled(topicType string, duration time.Duration) {
+	if this == nil {
+		return
+	}
+	this.mqttHandlerDuration.WithLabelValues(topicType).Observe(duration.Seconds())
+}
+
+func (this *Metrics) MqttHandlerError(topicType string) {
+	if this == nil {
+		return
+	}
+	this.mqttHandlerErrors.WithLabelValues(topicType).Inc()
+}
+
+func (this *Metrics) MqttCommandSent(topicType string, failed bool) {
+	if this == nil {
+		return
+	}
+	this.mqttCommandsSent.WithLabelValues(topicType, strconv.FormatBool(failed)).Inc()
+}
+
+func (this *Metrics) MongoOperation(method string, collection string, duration time.Duration, failed bool) {
+	if this == nil {
+		return
+	}
+	this.mongoOperationDuration.WithLabelValues(method, collection, strconv.FormatBool(failed)).Observe(duration.Seconds())
+}
+
+// ApiRequest records a handled request; endpoint is the pattern of the matched route (e.g. "GET /deployments/{networkId}")
+func (this *Metrics) ApiRequest(endpoint string, status int, duration time.Duration) {
+	if this == nil {
+		return
+	}
+	this.apiRequests.WithLabelValues(endpoint, strconv.Itoa(status)).Inc()
+	this.apiRequestDuration.WithLabelValues(endpoint).Observe(duration.Seconds())
+}
+
+func (this *Metrics) KafkaConsumerLag(topic string, lag int64) {
+	if this == nil {
+		return
+	}
+	this.kafkaConsumerLag.WithLabelValues(topic).Set(float64(lag))
+}
+
+func (this *Metrics) Notification(channel string, result string) {
+	if this == nil {
+		return
+	}
+	this.notifications.WithLabelValues(channel, result).Inc()
+}
+
+// PermissionCache records count permission checks with the result "hit" or "miss"
+func (this *Metrics) PermissionCache(result string, count int) {
+	if this == nil || count == 0 {
+		return
+	}
+	this.permissionCache.WithLabelValues(result).Add(float64(count))
+}
+
+// RegisterNetworkContacts adds a gauge with the age of the last contact per network; provider is called on every scrape
+func (this *Metrics) RegisterNetworkContacts(provider func() (map[string]time.Time, error), logger *slog.Logger) {
+	if this == nil {
+		return
+	}
+	this.registry.MustRegister(&networkContactCollector{provider: provider, logger: logger})
+}
+
+func (this *Metrics) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	this.httphandler.ServeHTTP(writer, request)
+}
+
+// Start serves the metrics on /metrics of the given port until ctx is done
+func (this *Metrics) Start(ctx context.Context, port string, logger *slog.Logger) {
+	router := http.NewServeMux()
+	router.Handle("GET /metrics", this)
+	server := &http.Server{Addr: ":" + port, Handler: router, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		logger.Info("listening for metrics", "address", server.Addr)
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Error("unable to serve metrics", "error", err)
+		}
+	}()
+	go func() {
+		<-ctx.Done()
+		logger.Debug("metrics shutdown", "result", server.Shutdown(context.Background()))
+	}()
+}
+
+var networkContactAgeDesc = prometheus.NewDesc(
+	"process_sync_network_last_contact_age_seconds",
+	"seconds since the last message of the process network",
+	[]string{"network_id"}, nil,
+)
+
+type networkContactCollector struct {
+	provider func() (map[string]time.Time, error)
+	logger   *slog.Logger
+}
+
+func (this *networkContactCollector) Describe(descs chan<- *prometheus.Desc) {
+	descs <- networkContactAgeDesc
+}
+
+func (this *networkContactCollector) Collect(metrics chan<- prometheus.Metric) {
+	contacts, err := this.provider()
+	if err != nil {
+		this.logger.Error("unable to collect last network contacts for metrics", "error", err)
+		return
+	}
+	now := time.Now()
+	for networkId, lastContact := range contacts {
+		metrics <- prometheus.MustNewConstMetric(networkContactAgeDesc, prometheus.GaugeValue, now.Sub(lastContact).Seconds(), networkId)
+	}
+}
@@ -0,0 +1,78 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package metrics
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNilMetrics(t *testing.T) {
+	var m *Metrics
+	m.WardenDecision("start", false, false)
+	m.WardenLoop("db", time.Second)
+	m.MqttMessageReceived("state/incident")
+	m.MqttMessageHandled("state/incident", time.Second)
+	m.MqttHandlerError("state/incident")
+	m.MqttCommandSent("cmd/deployment", false)
+	m.MongoOperation("find", "warden", time.Second, false)
+	m.ApiRequest("GET /warden/{networkId}", http.StatusOK, time.Second)
+	m.KafkaConsumerLag("device-groups", 1)
+	m.Notification("webhook", "sent")
+	m.PermissionCache("hit", 1)
+	m.RegisterNetworkContacts(nil, nil)
+}
+
+func TestMetricsHandler(t *testing.T) {
+	m := New()
+	m.WardenDecision("start", true, false)
+	m.MqttMessageReceived("state/incident")
+	m.ApiRequest("GET /warden/{networkId}", http.StatusOK, time.Millisecond)
+	m.PermissionCache("miss", 2)
+	m.RegisterNetworkContacts(func() (map[string]time.Time, error) {
+		return map[string]time.Time{"n1": time.Now().Add(-time.Minute)}, nil
+	}, nil)
+
+	server := httptest.NewServer(m)
+	defer server.Close()
+	resp, err := http.Get(server.URL)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	for _, expected := range []string{
+		`process_sync_warden_decisions_total{decision="start",dry_run="true",failed="false"} 1`,
+		`process_sync_mqtt_messages_received_total{topic_type="state/incident"} 1`,
+		`process_sync_api_requests_total{endpoint="GET /warden/{networkId}",status="200"} 1`,
+		`process_sync_network_last_contact_age_seconds{network_id="n1"}`,
+		`process_sync_permission_cache_total{result="miss"} 2`,
+	} {
+		if !strings.Contains(string(body), expected) {
+			t.Error("missing", expected, "\n", string(body))
+		}
+	}
+}
@@ -17,8 +17,8 @@
 package mgw
 
 import (
+	"context"
 	"encoding/json"
-	"runtime/debug"
 
 	model2 "github.com/SENERGY-Platform/event-worker/pkg/model"
 	"github.com/SENERGY-Platform/process-sync/pkg/model"
@@ -26,63 +26,63 @@ import (
 	paho "github.com/eclipse/paho.mqtt.golang"
 )
 
-func (this *Mgw) handleDeploymentUpdate(message paho.Message) {
+func (this *Mgw) handleDeploymentUpdate(ctx context.Context, message paho.Message) {
 	deployment := camundamodel.Deployment{}
 	err := json.Unmarshal(message.Payload(), &deployment)
 	if err != nil {
-		this.config.GetLogger().Error("error", "error", err, "stack", debug.Stack())
+		this.handleError(message, err)
 	}
 	networkId, err := this.getNetworkId(message.Topic())
 	if err != nil {
-		this.config.GetLogger().Error("error", "error", err, "stack", debug.Stack())
+		this.handleError(message, err)
 	}
-	this.handler.LogNetworkInteraction(networkId)
-	this.handler.UpdateDeployment(networkId, deployment)
+	this.handler.LogNetworkInteraction(ctx, networkId, deploymentTopic)
+	this.handler.UpdateDeployment(ctx, networkId, deployment)
 }
 
-func (this *Mgw) handleDeploymentMetadata(message paho.Message) {
+func (this *Mgw) handleDeploymentMetadata(ctx context.Context, message paho.Message) {
 	metadata := model.Metadata{}
 	err := json.Unmarshal(message.Payload(), &metadata)
 	if err != nil {
-		this.config.GetLogger().Error("error", "error", err, "stack", debug.Stack())
+		this.handleError(message, err)
 	}
 	networkId, err := this.getNetworkId(message.Topic())
 	if err != nil {
-		this.config.GetLogger().Error("error", "error", err, "stack", debug.Stack())
+		this.handleError(message, err)
 	}
-	this.handler.LogNetworkInteraction(networkId)
-	this.handler.UpdateDeploymentMetadata(networkId, metadata)
+	this.handler.LogNetworkInteraction(ctx, networkId, deploymentTopic)
+	this.handler.UpdateDeploymentMetadata(ctx, networkId, metadata)
 }
 
-func (this *Mgw) handleDeploymentDelete(message paho.Message) {
+func (this *Mgw) handleDeploymentDelete(ctx context.Context, message paho.Message) {
 	networkId, err := this.getNetworkId(message.Topic())
 	if err != nil {
-		this.config.GetLogger().Error("error", "error", err, "stack", debug.Stack())
+		this.handleError(message, err)
 	}
-	this.handler.LogNetworkInteraction(networkId)
-	this.handler.DeleteDeployment(networkId, string(message.Payload()))
+	this.handler.LogNetworkInteraction(ctx, networkId, deploymentTopic)
+	this.handler.DeleteDeployment(ctx, networkId, string(message.Payload()))
 }
 
-func (this *Mgw) handleDeploymentKnown(message paho.Message) {
+func (this *Mgw) handleDeploymentKnown(ctx context.Context, message paho.Message) {
 	knownIds := []string{}
 	err := json.Unmarshal(message.Payload(), &knownIds)
 	if err != nil {
-		this.config.GetLogger().Error("error", "error", err, "stack", debug.Stack())
+		this.handleError(message, err)
 	}
 	networkId, err := this.getNetworkId(message.Topic())
 	if err != nil {
-		this.config.GetLogger().Error("error", "error", err, "stack", debug.Stack())
+		this.handleError(message, err)
 	}
-	this.handler.LogNetworkInteraction(networkId)
-	this.handler.DeleteUnknownDeployments(networkId, knownIds)
+	this.handler.LogNetworkInteraction(ctx, networkId, deploymentTopic)
+	this.handler.DeleteUnknownDeployments(ctx, networkId, knownIds)
 }
 
-func (this *Mgw) SendDeploymentCommand(networkId string, deployment model.DeploymentWithEventDesc) error {
-	return this.sendObj(this.getCommandTopic(networkId, deploymentTopic), deployment)
+func (this *Mgw) SendDeploymentCommand(ctx context.Context, networkId string, deployment model.DeploymentWithEventDesc) error {
+	return this.sendObj(ctx, this.getCommandTopic(networkId, deploymentTopic), deployment)
 }
 
-func (this *Mgw) SendDeploymentEventUpdateCommand(networkId string, camundaDeploymentId string, eventDescriptions []model2.EventDesc, deviceMapping map[string]string, serviceMapping map[string]string) error {
-	return this.sendObj(this.getCommandTopic(networkId, deploymentTopic, "event-descriptions"), EventDescriptionsUpdate{
+func (this *Mgw) SendDeploymentEventUpdateCommand(ctx context.Context, networkId string, camundaDeploymentId string, eventDescriptions []model2.EventDesc, deviceMapping map[string]string, serviceMapping map[string]string) error {
+	return this.sendObj(ctx, this.getCommandTopic(networkId, deploymentTopic, "event-descriptions"), EventDescriptionsUpdate{
 		CamundaDeploymentId: camundaDeploymentId,
 		EventDescriptions:   eventDescriptions,
 		DeviceIdToLocalId:   deviceMapping,
@@ -90,12 +90,12 @@ func (this *Mgw) SendDeploymentEventUpdateCommand(networkId string, camundaDeplo
 	})
 }
 
-func (this *Mgw) SendDeploymentDeleteCommand(networkId string, deploymentId string) error {
-	return this.sendStr(this.getCommandTopic(networkId, deploymentTopic, "delete"), deploymentId)
+func (this *Mgw) SendDeploymentDeleteCommand(ctx context.Context, networkId string, deploymentId string) error {
+	return this.sendStr(ctx, this.getCommandTopic(networkId, deploymentTopic, "delete"), deploymentId)
 }
 
-func (this *Mgw) SendDeploymentStartCommand(networkId string, deploymentId string, businessKey string, parameter map[string]interface{}) error {
-	return this.sendObj(this.getCommandTopic(networkId, deploymentTopic, "start"), model.StartMessage{
+func (this *Mgw) SendDeploymentStartCommand(ctx context.Context, networkId string, deploymentId string, businessKey string, parameter map[string]interface{}) (err error) {
+	return this.sendObj(ctx, this.getCommandTopic(networkId, deploymentTopic, "start"), model.StartMessage{
 		DeploymentId: deploymentId,
 		Parameter:    parameter,
 		BusinessKey:  businessKey,
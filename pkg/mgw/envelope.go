@@ -0,0 +1,117 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mgw
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/SENERGY-Platform/process-sync/pkg/envelope"
+	paho "github.com/eclipse/paho.mqtt.golang"
+)
+
+const stateVerificationOff = "off"
+const stateVerificationOptional = "optional"
+const stateVerificationRequired = "required"
+
+// initEnvelopes prepares signing of commands and verification of state messages as configured
+func (this *Mgw) initEnvelopes() (err error) {
+	if this.config.MqttSigningKeyFile != "" && this.config.MqttSigningKeyFile != "-" {
+		this.signer, err = envelope.LoadSigner(this.config.MqttSigningKeyFile, this.config.MqttSigningKeyId)
+		if err != nil {
+			return fmt.Errorf("unable to load mqtt signing key: %w", err)
+		}
+	}
+	if this.config.MqttNetworkKeyFile != "" && this.config.MqttNetworkKeyFile != "-" {
+		this.networkKeys, err = envelope.NewFileRegistry(this.config.MqttNetworkKeyFile, this.config.GetLogger())
+		if err != nil {
+			return fmt.Errorf("unable to load mqtt network keys: %w", err)
+		}
+	}
+	this.stateVerification = this.config.MqttStateVerification
+	switch this.stateVerification {
+	case "", stateVerificationOff:
+		this.stateVerification = stateVerificationOff
+		return nil
+	case stateVerificationOptional, stateVerificationRequired:
+	default:
+		return fmt.Errorf("unknown mqtt_state_verification %q", this.stateVerification)
+	}
+	if this.networkKeys == nil {
+		return errors.New("mqtt_state_verification needs the public keys of the networks in mqtt_network_key_file")
+	}
+	maxAge, err := time.ParseDuration(this.config.MqttEnvelopeMaxAge)
+	if err != nil {
+		return fmt.Errorf("invalid mqtt_envelope_max_age: %w", err)
+	}
+	this.verifier = envelope.NewVerifier(maxAge)
+	return nil
+}
+
+// seal wraps the command payload in a signed envelope, encrypted if the network has an encryption key;
+// the payload is returned unchanged if no signing key is configured
+func (this *Mgw) seal(topic string, payload []byte, traceContext map[string]string) ([]byte, error) {
+	if this.signer == nil {
+		return payload, nil
+	}
+	var encryptionKey []byte
+	if this.networkKeys != nil {
+		networkId, err := this.getNetworkId(topic)
+		if err != nil {
+			return nil, err
+		}
+		keys, _ := this.networkKeys.Get(networkId)
+		encryptionKey = keys.EncryptionKey
+	}
+	return this.signer.Seal(topic, payload, encryptionKey, traceContext)
+}
+
+// open replaces the payload of signed state messages with the verified payload;
+// plain messages are rejected if mqtt_state_verification is required
+func (this *Mgw) open(message paho.Message) (paho.Message, error) {
+	if this.verifier == nil {
+		return message, nil
+	}
+	env, err := envelope.Parse(message.Payload())
+	if errors.Is(err, envelope.ErrInvalidEnvelope) && this.stateVerification == stateVerificationOptional {
+		return message, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to verify state message: %w", err)
+	}
+	networkId, err := this.getNetworkId(message.Topic())
+	if err != nil {
+		return nil, err
+	}
+	keys, _ := this.networkKeys.Get(networkId)
+	payload, err := this.verifier.Open(message.Topic(), env, keys.PublicKey, keys.EncryptionKey)
+	if err != nil {
+		return nil, fmt.Errorf("unable to verify state message of network %v: %w", networkId, err)
+	}
+	return openedMessage{Message: message, payload: payload, traceContext: env.TraceContext}, nil
+}
+
+type openedMessage struct {
+	paho.Message
+	payload      []byte
+	traceContext map[string]string
+}
+
+func (this openedMessage) Payload() []byte {
+	return this.payload
+}
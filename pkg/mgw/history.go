@@ -17,50 +17,50 @@
 package mgw
 
 import (
+	"context"
 	"encoding/json"
-	"runtime/debug"
 
 	"github.com/SENERGY-Platform/process-sync/pkg/model/camundamodel"
 	paho "github.com/eclipse/paho.mqtt.golang"
 )
 
-func (this *Mgw) handleHistoricProcessInstanceUpdate(message paho.Message) {
+func (this *Mgw) handleHistoricProcessInstanceUpdate(ctx context.Context, message paho.Message) {
 	historicProcessInstance := camundamodel.HistoricProcessInstance{}
 	err := json.Unmarshal(message.Payload(), &historicProcessInstance)
 	if err != nil {
-		this.config.GetLogger().Error("error", "error", err, "stack", debug.Stack())
+		this.handleError(message, err)
 	}
 	networkId, err := this.getNetworkId(message.Topic())
 	if err != nil {
-		this.config.GetLogger().Error("error", "error", err, "stack", debug.Stack())
+		this.handleError(message, err)
 	}
-	this.handler.LogNetworkInteraction(networkId)
-	this.handler.UpdateHistoricProcessInstance(networkId, historicProcessInstance)
+	this.handler.LogNetworkInteraction(ctx, networkId, processInstanceHistoryTopic)
+	this.handler.UpdateHistoricProcessInstance(ctx, networkId, historicProcessInstance)
 }
 
-func (this *Mgw) handleHistoricProcessInstanceDelete(message paho.Message) {
+func (this *Mgw) handleHistoricProcessInstanceDelete(ctx context.Context, message paho.Message) {
 	networkId, err := this.getNetworkId(message.Topic())
 	if err != nil {
-		this.config.GetLogger().Error("error", "error", err, "stack", debug.Stack())
+		this.handleError(message, err)
 	}
-	this.handler.LogNetworkInteraction(networkId)
-	this.handler.DeleteHistoricProcessInstance(networkId, string(message.Payload()))
+	this.handler.LogNetworkInteraction(ctx, networkId, processInstanceHistoryTopic)
+	this.handler.DeleteHistoricProcessInstance(ctx, networkId, string(message.Payload()))
 }
 
-func (this *Mgw) handleHistoricProcessInstanceKnown(message paho.Message) {
+func (this *Mgw) handleHistoricProcessInstanceKnown(ctx context.Context, message paho.Message) {
 	knownIds := []string{}
 	err := json.Unmarshal(message.Payload(), &knownIds)
 	if err != nil {
-		this.config.GetLogger().Error("error", "error", err, "stack", debug.Stack())
+		this.handleError(message, err)
 	}
 	networkId, err := this.getNetworkId(message.Topic())
 	if err != nil {
-		this.config.GetLogger().Error("error", "error", err, "stack", debug.Stack())
+		this.handleError(message, err)
 	}
-	this.handler.LogNetworkInteraction(networkId)
-	this.handler.DeleteUnknownHistoricProcessInstances(networkId, knownIds)
+	this.handler.LogNetworkInteraction(ctx, networkId, processInstanceHistoryTopic)
+	this.handler.DeleteUnknownHistoricProcessInstances(ctx, networkId, knownIds)
 }
 
-func (this *Mgw) SendProcessHistoryDeleteCommand(networkId string, processInstanceHistoryId string) error {
-	return this.sendStr(this.getCommandTopic(networkId, processInstanceHistoryTopic, "delete"), processInstanceHistoryId)
+func (this *Mgw) SendProcessHistoryDeleteCommand(ctx context.Context, networkId string, processInstanceHistoryId string) error {
+	return this.sendStr(ctx, this.getCommandTopic(networkId, processInstanceHistoryTopic, "delete"), processInstanceHistoryId)
 }
@@ -17,46 +17,52 @@
 package mgw
 
 import (
+	"context"
 	"encoding/json"
-	"runtime/debug"
 
+	"github.com/SENERGY-Platform/process-sync/pkg/model"
 	"github.com/SENERGY-Platform/process-sync/pkg/model/camundamodel"
 	paho "github.com/eclipse/paho.mqtt.golang"
 )
 
-func (this *Mgw) handleIncidentUpdate(message paho.Message) {
+func (this *Mgw) handleIncidentUpdate(ctx context.Context, message paho.Message) {
 	incident := camundamodel.Incident{}
 	err := json.Unmarshal(message.Payload(), &incident)
 	if err != nil {
-		this.config.GetLogger().Error("error", "error", err, "stack", debug.Stack())
+		this.handleError(message, err)
 	}
 	networkId, err := this.getNetworkId(message.Topic())
 	if err != nil {
-		this.config.GetLogger().Error("error", "error", err, "stack", debug.Stack())
+		this.handleError(message, err)
 	}
-	this.handler.LogNetworkInteraction(networkId)
-	this.handler.UpdateIncident(networkId, incident)
+	this.handler.LogNetworkInteraction(ctx, networkId, incidentTopic)
+	this.handler.UpdateIncident(ctx, networkId, incident)
 }
 
-func (this *Mgw) handleIncidentDelete(message paho.Message) {
+func (this *Mgw) handleIncidentDelete(ctx context.Context, message paho.Message) {
 	networkId, err := this.getNetworkId(message.Topic())
 	if err != nil {
-		this.config.GetLogger().Error("error", "error", err, "stack", debug.Stack())
+		this.handleError(message, err)
 	}
-	this.handler.LogNetworkInteraction(networkId)
-	this.handler.DeleteIncident(networkId, string(message.Payload()))
+	this.handler.LogNetworkInteraction(ctx, networkId, incidentTopic)
+	this.handler.DeleteIncident(ctx, networkId, string(message.Payload()))
 }
 
-func (this *Mgw) handleIncidentKnown(message paho.Message) {
+func (this *Mgw) handleIncidentKnown(ctx context.Context, message paho.Message) {
 	knownIds := []string{}
 	err := json.Unmarshal(message.Payload(), &knownIds)
 	if err != nil {
-		this.config.GetLogger().Error("error", "error", err, "stack", debug.Stack())
+		this.handleError(message, err)
 	}
 	networkId, err := this.getNetworkId(message.Topic())
 	if err != nil {
-		this.config.GetLogger().Error("error", "error", err, "stack", debug.Stack())
+		this.handleError(message, err)
 	}
-	this.handler.LogNetworkInteraction(networkId)
-	this.handler.DeleteUnknownIncidents(networkId, knownIds)
+	this.handler.LogNetworkInteraction(ctx, networkId, incidentTopic)
+	this.handler.DeleteUnknownIncidents(ctx, networkId, knownIds)
+}
+
+// SendIncidentCommand sends one of model.IncidentCommandRetry, model.IncidentCommandResolve or model.IncidentCommandRestart to the mgw
+func (this *Mgw) SendIncidentCommand(ctx context.Context, networkId string, command string, message model.IncidentCommandMessage) (err error) {
+	return this.sendObj(ctx, this.getCommandTopic(networkId, incidentTopic, command), message)
 }
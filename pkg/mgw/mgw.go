@@ -17,51 +17,70 @@
 package mgw
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
+	"runtime/debug"
 	"strings"
+	"time"
 
 	"github.com/SENERGY-Platform/process-sync/pkg/configuration"
+	"github.com/SENERGY-Platform/process-sync/pkg/envelope"
+	"github.com/SENERGY-Platform/process-sync/pkg/metrics"
 	"github.com/SENERGY-Platform/process-sync/pkg/model"
 	"github.com/SENERGY-Platform/process-sync/pkg/model/camundamodel"
 	"github.com/SENERGY-Platform/process-sync/pkg/multimqtt"
+	"github.com/SENERGY-Platform/process-sync/pkg/tracing"
 	paho "github.com/eclipse/paho.mqtt.golang"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type Mgw struct {
 	mqtt    paho.Client
 	config  configuration.Config
 	handler Handler
+	metrics *metrics.Metrics
+
+	signer            *envelope.Signer
+	networkKeys       *envelope.FileRegistry
+	verifier          *envelope.Verifier
+	stateVerification string
 }
 
 type Handler interface {
-	UpdateDeployment(networkId string, deployment camundamodel.Deployment)
-	DeleteDeployment(networkId string, deploymentId string)
-	DeleteUnknownDeployments(networkId string, knownIds []string)
-	UpdateIncident(networkId string, incident camundamodel.Incident)
-	DeleteIncident(networkId string, incidentId string)
-	DeleteUnknownIncidents(networkId string, knownIds []string)
-	UpdateHistoricProcessInstance(networkId string, historicProcessInstance camundamodel.HistoricProcessInstance)
-	DeleteHistoricProcessInstance(networkId string, historicInstanceId string)
-	DeleteUnknownHistoricProcessInstances(networkId string, knownIds []string)
-	UpdateProcessDefinition(networkId string, processDefinition camundamodel.ProcessDefinition)
-	DeleteProcessDefinition(networkId string, definitionId string)
-	DeleteUnknownProcessDefinitions(networkId string, knownIds []string)
-	UpdateProcessInstance(networkId string, instance camundamodel.ProcessInstance)
-	DeleteProcessInstance(networkId string, instanceId string)
-	DeleteUnknownProcessInstances(networkId string, knownIds []string)
-	UpdateDeploymentMetadata(networkId string, metadata model.Metadata)
-	LogNetworkInteraction(networkId string)
-}
-
-func New(config configuration.Config, ctx context.Context, handler Handler) (*Mgw, error) {
+	UpdateDeployment(ctx context.Context, networkId string, deployment camundamodel.Deployment)
+	DeleteDeployment(ctx context.Context, networkId string, deploymentId string)
+	DeleteUnknownDeployments(ctx context.Context, networkId string, knownIds []string)
+	UpdateIncident(ctx context.Context, networkId string, incident camundamodel.Incident)
+	DeleteIncident(ctx context.Context, networkId string, incidentId string)
+	DeleteUnknownIncidents(ctx context.Context, networkId string, knownIds []string)
+	UpdateHistoricProcessInstance(ctx context.Context, networkId string, historicProcessInstance camundamodel.HistoricProcessInstance)
+	DeleteHistoricProcessInstance(ctx context.Context, networkId string, historicInstanceId string)
+	DeleteUnknownHistoricProcessInstances(ctx context.Context, networkId string, knownIds []string)
+	UpdateProcessDefinition(ctx context.Context, networkId string, processDefinition camundamodel.ProcessDefinition)
+	DeleteProcessDefinition(ctx context.Context, networkId string, definitionId string)
+	DeleteUnknownProcessDefinitions(ctx context.Context, networkId string, knownIds []string)
+	UpdateProcessInstance(ctx context.Context, networkId string, instance camundamodel.ProcessInstance)
+	DeleteProcessInstance(ctx context.Context, networkId string, instanceId string)
+	DeleteUnknownProcessInstances(ctx context.Context, networkId string, knownIds []string)
+	UpdateDeploymentMetadata(ctx context.Context, networkId string, metadata model.Metadata)
+	LogNetworkInteraction(ctx context.Context, networkId string, topic string)
+}
+
+func New(config configuration.Config, ctx context.Context, handler Handler, m *metrics.Metrics) (*Mgw, error) {
 	client := &Mgw{
 		config:  config,
 		handler: handler,
+		metrics: m,
 	}
 
-	client.mqtt = multimqtt.NewClient(config.Mqtt, func(options *paho.ClientOptions) {
+	err := client.initEnvelopes()
+	if err != nil {
+		return nil, err
+	}
+	client.mqtt, err = multimqtt.NewClient(config.Mqtt, func(options *paho.ClientOptions) {
 		options.SetResumeSubs(true).
 			SetCleanSession(config.MqttCleanSession).
 			SetConnectionLostHandler(func(c paho.Client, err error) {
@@ -74,6 +93,9 @@ func New(config configuration.Config, ctx context.Context, handler Handler) (*Mg
 				client.subscribe(c)
 			})
 	})
+	if err != nil {
+		return nil, err
+	}
 	if token := client.mqtt.Connect(); token.Wait() && token.Error() != nil {
 		config.GetLogger().Error("unable to connect to mqtt broker", "error", token.Error())
 		return nil, token.Error()
@@ -99,70 +121,96 @@ func (this *Mgw) subscribe(client paho.Client) {
 		sharedSubscriptionPrefix = "$share/" + this.config.MqttGroupId + "/"
 	}
 
-	client.Subscribe(sharedSubscriptionPrefix+this.getStateTopic("+", deploymentTopic), 2, func(client paho.Client, message paho.Message) {
-		this.config.GetLogger().Debug("receive", "topic", message.Topic(), "payload", string(message.Payload()))
-		this.handleDeploymentUpdate(message)
-	})
-	client.Subscribe(sharedSubscriptionPrefix+this.getStateTopic("+", deploymentTopic, "delete"), 2, func(client paho.Client, message paho.Message) {
-		this.config.GetLogger().Debug("receive", "topic", message.Topic(), "payload", string(message.Payload()))
-		this.handleDeploymentDelete(message)
-	})
-	client.Subscribe(sharedSubscriptionPrefix+this.getStateTopic("+", deploymentTopic, "known"), 2, func(client paho.Client, message paho.Message) {
-		this.config.GetLogger().Debug("receive", "topic", message.Topic(), "payload", string(message.Payload()))
-		this.handleDeploymentKnown(message)
-	})
-	client.Subscribe(sharedSubscriptionPrefix+this.getStateTopic("+", deploymentTopic, "metadata"), 2, func(client paho.Client, message paho.Message) {
-		this.config.GetLogger().Debug("receive", "topic", message.Topic(), "payload", string(message.Payload()))
-		this.handleDeploymentMetadata(message)
-	})
-	client.Subscribe(sharedSubscriptionPrefix+this.getStateTopic("+", incidentTopic), 2, func(client paho.Client, message paho.Message) {
-		this.config.GetLogger().Debug("receive", "topic", message.Topic(), "payload", string(message.Payload()))
-		this.handleIncidentUpdate(message)
-	})
-	client.Subscribe(sharedSubscriptionPrefix+this.getStateTopic("+", incidentTopic, "delete"), 2, func(client paho.Client, message paho.Message) {
-		this.config.GetLogger().Debug("receive", "topic", message.Topic(), "payload", string(message.Payload()))
-		this.handleIncidentDelete(message)
-	})
-	client.Subscribe(sharedSubscriptionPrefix+this.getStateTopic("+", incidentTopic, "known"), 2, func(client paho.Client, message paho.Message) {
-		this.config.GetLogger().Debug("receive", "topic", message.Topic(), "payload", string(message.Payload()))
-		this.handleIncidentKnown(message)
-	})
-	client.Subscribe(sharedSubscriptionPrefix+this.getStateTopic("+", processDefinitionTopic), 2, func(client paho.Client, message paho.Message) {
-		this.config.GetLogger().Debug("receive", "topic", message.Topic(), "payload", string(message.Payload()))
-		this.handleProcessDefinitionUpdate(message)
-	})
-	client.Subscribe(sharedSubscriptionPrefix+this.getStateTopic("+", processDefinitionTopic, "delete"), 2, func(client paho.Client, message paho.Message) {
-		this.config.GetLogger().Debug("receive", "topic", message.Topic(), "payload", string(message.Payload()))
-		this.handleProcessDefinitionDelete(message)
-	})
-	client.Subscribe(sharedSubscriptionPrefix+this.getStateTopic("+", processDefinitionTopic, "known"), 2, func(client paho.Client, message paho.Message) {
-		this.config.GetLogger().Debug("receive", "topic", message.Topic(), "payload", string(message.Payload()))
-		this.handleProcessDefinitionKnown(message)
-	})
-	client.Subscribe(sharedSubscriptionPrefix+this.getStateTopic("+", processInstanceTopic), 2, func(client paho.Client, message paho.Message) {
-		this.config.GetLogger().Debug("receive", "topic", message.Topic(), "payload", string(message.Payload()))
-		this.handleProcessInstanceUpdate(message)
-	})
-	client.Subscribe(sharedSubscriptionPrefix+this.getStateTopic("+", processInstanceTopic, "delete"), 2, func(client paho.Client, message paho.Message) {
-		this.config.GetLogger().Debug("receive", "topic", message.Topic(), "payload", string(message.Payload()))
-		this.handleProcessInstanceDelete(message)
-	})
-	client.Subscribe(sharedSubscriptionPrefix+this.getStateTopic("+", processInstanceTopic, "known"), 2, func(client paho.Client, message paho.Message) {
-		this.config.GetLogger().Debug("receive", "topic", message.Topic(), "payload", string(message.Payload()))
-		this.handleProcessInstanceKnown(message)
-	})
-	client.Subscribe(sharedSubscriptionPrefix+this.getStateTopic("+", processInstanceHistoryTopic), 2, func(client paho.Client, message paho.Message) {
-		this.config.GetLogger().Debug("receive", "topic", message.Topic(), "payload", string(message.Payload()))
-		this.handleHistoricProcessInstanceUpdate(message)
-	})
-	client.Subscribe(sharedSubscriptionPrefix+this.getStateTopic("+", processInstanceHistoryTopic, "delete"), 2, func(client paho.Client, message paho.Message) {
-		this.config.GetLogger().Debug("receive", "topic", message.Topic(), "payload", string(message.Payload()))
-		this.handleHistoricProcessInstanceDelete(message)
-	})
-	client.Subscribe(sharedSubscriptionPrefix+this.getStateTopic("+", processInstanceHistoryTopic, "known"), 2, func(client paho.Client, message paho.Message) {
+	client.Subscribe(sharedSubscriptionPrefix+this.getStateTopic("+", deploymentTopic), 2, this.observe(this.handleDeploymentUpdate))
+	client.Subscribe(sharedSubscriptionPrefix+this.getStateTopic("+", deploymentTopic, "delete"), 2, this.observe(this.handleDeploymentDelete))
+	client.Subscribe(sharedSubscriptionPrefix+this.getStateTopic("+", deploymentTopic, "known"), 2, this.observe(this.handleDeploymentKnown))
+	client.Subscribe(sharedSubscriptionPrefix+this.getStateTopic("+", deploymentTopic, "metadata"), 2, this.observe(this.handleDeploymentMetadata))
+	client.Subscribe(sharedSubscriptionPrefix+this.getStateTopic("+", incidentTopic), 2, this.observe(this.handleIncidentUpdate))
+	client.Subscribe(sharedSubscriptionPrefix+this.getStateTopic("+", incidentTopic, "delete"), 2, this.observe(this.handleIncidentDelete))
+	client.Subscribe(sharedSubscriptionPrefix+this.getStateTopic("+", incidentTopic, "known"), 2, this.observe(this.handleIncidentKnown))
+	client.Subscribe(sharedSubscriptionPrefix+this.getStateTopic("+", processDefinitionTopic), 2, this.observe(this.handleProcessDefinitionUpdate))
+	client.Subscribe(sharedSubscriptionPrefix+this.getStateTopic("+", processDefinitionTopic, "delete"), 2, this.observe(this.handleProcessDefinitionDelete))
+	client.Subscribe(sharedSubscriptionPrefix+this.getStateTopic("+", processDefinitionTopic, "known"), 2, this.observe(this.handleProcessDefinitionKnown))
+	client.Subscribe(sharedSubscriptionPrefix+this.getStateTopic("+", processInstanceTopic), 2, this.observe(this.handleProcessInstanceUpdate))
+	client.Subscribe(sharedSubscriptionPrefix+this.getStateTopic("+", processInstanceTopic, "delete"), 2, this.observe(this.handleProcessInstanceDelete))
+	client.Subscribe(sharedSubscriptionPrefix+this.getStateTopic("+", processInstanceTopic, "known"), 2, this.observe(this.handleProcessInstanceKnown))
+	client.Subscribe(sharedSubscriptionPrefix+this.getStateTopic("+", processInstanceHistoryTopic), 2, this.observe(this.handleHistoricProcessInstanceUpdate))
+	client.Subscribe(sharedSubscriptionPrefix+this.getStateTopic("+", processInstanceHistoryTopic, "delete"), 2, this.observe(this.handleHistoricProcessInstanceDelete))
+	client.Subscribe(sharedSubscriptionPrefix+this.getStateTopic("+", processInstanceHistoryTopic, "known"), 2, this.observe(this.handleHistoricProcessInstanceKnown))
+}
+
+// observe logs and measures the handling of received messages; handlers receive the context of the receive span
+func (this *Mgw) observe(handler func(ctx context.Context, message paho.Message)) paho.MessageHandler {
+	return func(client paho.Client, message paho.Message) {
 		this.config.GetLogger().Debug("receive", "topic", message.Topic(), "payload", string(message.Payload()))
-		this.handleHistoricProcessInstanceKnown(message)
-	})
+		topicType := getTopicType(message.Topic())
+		this.metrics.MqttMessageReceived(topicType)
+		opened, err := this.open(message)
+		if err != nil {
+			this.handleError(message, err)
+			return
+		}
+		message = opened
+		ctx, span := tracing.StartWithKind(extractTraceContext(message), "mqtt receive "+topicType, trace.SpanKindConsumer, attribute.String("messaging.system", "mqtt"), attribute.String("messaging.destination.name", message.Topic()))
+		start := time.Now()
+		handler(ctx, message)
+		this.metrics.MqttMessageHandled(topicType, time.Since(start))
+		span.End()
+	}
+}
+
+// extractTraceContext continues the trace of updated edge clients, which send their trace context in the trace_context field
+// of the payload or of the envelope
+func extractTraceContext(message paho.Message) context.Context {
+	ctx := context.Background()
+	if opened, ok := message.(openedMessage); ok && len(opened.traceContext) > 0 {
+		return tracing.Extract(ctx, opened.traceContext)
+	}
+	payload := message.Payload()
+	if len(payload) == 0 || payload[0] != '{' || !bytes.Contains(payload, []byte(`"trace_context"`)) {
+		return ctx
+	}
+	wrapper := struct {
+		TraceContext map[string]string `json:"trace_context"`
+	}{}
+	if json.Unmarshal(payload, &wrapper) != nil {
+		return ctx
+	}
+	return tracing.Extract(ctx, wrapper.TraceContext)
+}
+
+func (this *Mgw) startPublishSpan(ctx context.Context, topic string) (context.Context, trace.Span) {
+	return tracing.StartWithKind(ctx, "mqtt publish "+getTopicType(topic), trace.SpanKindProducer, attribute.String("messaging.system", "mqtt"), attribute.String("messaging.destination.name", topic))
+}
+
+// injectTraceContext adds traceContext as trace_context field to json object payloads; other payloads (e.g. plain ids) are returned unchanged
+func injectTraceContext(payload []byte, traceContext map[string]string) []byte {
+	if len(traceContext) == 0 || len(payload) < 2 || payload[0] != '{' {
+		return payload
+	}
+	field, err := json.Marshal(traceContext)
+	if err != nil {
+		return payload
+	}
+	result := append([]byte(`{"trace_context":`), field...)
+	if payload[1] != '}' {
+		result = append(result, ',')
+	}
+	return append(result, payload[1:]...)
+}
+
+func (this *Mgw) handleError(message paho.Message, err error) {
+	this.config.GetLogger().Error("error", "error", err, "stack", debug.Stack())
+	this.metrics.MqttHandlerError(getTopicType(message.Topic()))
+}
+
+// getTopicType removes the network from the topic (e.g. processes/{networkId}/state/incident/delete --> state/incident/delete)
+func getTopicType(topic string) string {
+	parts := strings.SplitN(topic, "/", 3)
+	if len(parts) < 3 {
+		return topic
+	}
+	return parts[2]
 }
 
 func (this *Mgw) getNetworkId(topic string) (networkId string, err error) {
@@ -196,20 +244,36 @@ func (this *Mgw) getStateTopic(networkId string, entity string, substate ...stri
 	return
 }
 
-func (this *Mgw) sendObj(topic string, message interface{}) error {
+func (this *Mgw) sendObj(ctx context.Context, topic string, message interface{}) error {
 	msg, err := json.Marshal(message)
 	if err != nil {
 		return err
 	}
-	this.config.GetLogger().Debug("send", "topic", topic, "payload", string(msg))
-	token := this.mqtt.Publish(topic, 2, false, msg)
-	token.Wait()
-	return token.Error()
+	return this.send(ctx, topic, msg)
+}
+
+func (this *Mgw) sendStr(ctx context.Context, topic string, message string) error {
+	return this.send(ctx, topic, []byte(message))
 }
 
-func (this *Mgw) sendStr(topic string, message string) error {
-	this.config.GetLogger().Debug("send", "topic", topic, "payload", message)
-	token := this.mqtt.Publish(topic, 2, false, message)
+// send publishes the command in a producer span. the trace context is sent in the trace_context field of json object commands
+// and of the envelope of signed commands, so that the mgw is able to continue the trace.
+func (this *Mgw) send(ctx context.Context, topic string, payload []byte) (err error) {
+	ctx, span := this.startPublishSpan(ctx, topic)
+	defer func() {
+		tracing.End(span, err)
+	}()
+	traceContext := tracing.Inject(ctx)
+	payload = injectTraceContext(payload, traceContext)
+	payload, err = this.seal(topic, payload, traceContext)
+	if err != nil {
+		this.metrics.MqttCommandSent(getTopicType(topic), true)
+		return err
+	}
+	//logged after sealing, like received messages before opening, to never log plaintext of encrypted commands
+	this.config.GetLogger().Debug("send", "topic", topic, "payload", string(payload))
+	token := this.mqtt.Publish(topic, 2, false, payload)
 	token.Wait()
+	this.metrics.MqttCommandSent(getTopicType(topic), token.Error() != nil)
 	return token.Error()
 }
@@ -0,0 +1,53 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mgw
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestInjectTraceContext(t *testing.T) {
+	traceContext := map[string]string{"traceparent": "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01"}
+	tests := []struct {
+		name     string
+		payload  string
+		expected string
+	}{
+		{name: "empty object", payload: `{}`, expected: `{"trace_context":{"traceparent":"00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01"}}`},
+		{name: "object", payload: `{"id":"foo"}`, expected: `{"trace_context":{"traceparent":"00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01"},"id":"foo"}`},
+		{name: "string", payload: `foo`, expected: `foo`},
+		{name: "array", payload: `["foo"]`, expected: `["foo"]`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result := injectTraceContext([]byte(test.payload), traceContext)
+			if string(result) != test.expected {
+				t.Errorf("%v != %v", string(result), test.expected)
+			}
+			if test.payload[0] == '{' && !json.Valid(result) {
+				t.Errorf("invalid json %v", string(result))
+			}
+		})
+	}
+	t.Run("without trace context", func(t *testing.T) {
+		result := injectTraceContext([]byte(`{"id":"foo"}`), nil)
+		if string(result) != `{"id":"foo"}` {
+			t.Error(string(result))
+		}
+	})
+}
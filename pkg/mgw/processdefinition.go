@@ -17,46 +17,46 @@
 package mgw
 
 import (
+	"context"
 	"encoding/json"
-	"runtime/debug"
 
 	"github.com/SENERGY-Platform/process-sync/pkg/model/camundamodel"
 	paho "github.com/eclipse/paho.mqtt.golang"
 )
 
-func (this *Mgw) handleProcessDefinitionUpdate(message paho.Message) {
+func (this *Mgw) handleProcessDefinitionUpdate(ctx context.Context, message paho.Message) {
 	processDefinition := camundamodel.ProcessDefinition{}
 	err := json.Unmarshal(message.Payload(), &processDefinition)
 	if err != nil {
-		this.config.GetLogger().Error("error", "error", err, "stack", debug.Stack())
+		this.handleError(message, err)
 	}
 	networkId, err := this.getNetworkId(message.Topic())
 	if err != nil {
-		this.config.GetLogger().Error("error", "error", err, "stack", debug.Stack())
+		this.handleError(message, err)
 	}
-	this.handler.LogNetworkInteraction(networkId)
-	this.handler.UpdateProcessDefinition(networkId, processDefinition)
+	this.handler.LogNetworkInteraction(ctx, networkId, processDefinitionTopic)
+	this.handler.UpdateProcessDefinition(ctx, networkId, processDefinition)
 }
 
-func (this *Mgw) handleProcessDefinitionDelete(message paho.Message) {
+func (this *Mgw) handleProcessDefinitionDelete(ctx context.Context, message paho.Message) {
 	networkId, err := this.getNetworkId(message.Topic())
 	if err != nil {
-		this.config.GetLogger().Error("error", "error", err, "stack", debug.Stack())
+		this.handleError(message, err)
 	}
-	this.handler.LogNetworkInteraction(networkId)
-	this.handler.DeleteProcessDefinition(networkId, string(message.Payload()))
+	this.handler.LogNetworkInteraction(ctx, networkId, processDefinitionTopic)
+	this.handler.DeleteProcessDefinition(ctx, networkId, string(message.Payload()))
 }
 
-func (this *Mgw) handleProcessDefinitionKnown(message paho.Message) {
+func (this *Mgw) handleProcessDefinitionKnown(ctx context.Context, message paho.Message) {
 	knownIds := []string{}
 	err := json.Unmarshal(message.Payload(), &knownIds)
 	if err != nil {
-		this.config.GetLogger().Error("error", "error", err, "stack", debug.Stack())
+		this.handleError(message, err)
 	}
 	networkId, err := this.getNetworkId(message.Topic())
 	if err != nil {
-		this.config.GetLogger().Error("error", "error", err, "stack", debug.Stack())
+		this.handleError(message, err)
 	}
-	this.handler.LogNetworkInteraction(networkId)
-	this.handler.DeleteUnknownProcessDefinitions(networkId, knownIds)
+	this.handler.LogNetworkInteraction(ctx, networkId, processDefinitionTopic)
+	this.handler.DeleteUnknownProcessDefinitions(ctx, networkId, knownIds)
 }
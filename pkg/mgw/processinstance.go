@@ -17,50 +17,50 @@
 package mgw
 
 import (
+	"context"
 	"encoding/json"
-	"runtime/debug"
 
 	"github.com/SENERGY-Platform/process-sync/pkg/model/camundamodel"
 	paho "github.com/eclipse/paho.mqtt.golang"
 )
 
-func (this *Mgw) handleProcessInstanceUpdate(message paho.Message) {
+func (this *Mgw) handleProcessInstanceUpdate(ctx context.Context, message paho.Message) {
 	processInstance := camundamodel.ProcessInstance{}
 	err := json.Unmarshal(message.Payload(), &processInstance)
 	if err != nil {
-		this.config.GetLogger().Error("error", "error", err, "stack", debug.Stack())
+		this.handleError(message, err)
 	}
 	networkId, err := this.getNetworkId(message.Topic())
 	if err != nil {
-		this.config.GetLogger().Error("error", "error", err, "stack", debug.Stack())
+		this.handleError(message, err)
 	}
-	this.handler.LogNetworkInteraction(networkId)
-	this.handler.UpdateProcessInstance(networkId, processInstance)
+	this.handler.LogNetworkInteraction(ctx, networkId, processInstanceTopic)
+	this.handler.UpdateProcessInstance(ctx, networkId, processInstance)
 }
 
-func (this *Mgw) handleProcessInstanceDelete(message paho.Message) {
+func (this *Mgw) handleProcessInstanceDelete(ctx context.Context, message paho.Message) {
 	networkId, err := this.getNetworkId(message.Topic())
 	if err != nil {
-		this.config.GetLogger().Error("error", "error", err, "stack", debug.Stack())
+		this.handleError(message, err)
 	}
-	this.handler.LogNetworkInteraction(networkId)
-	this.handler.DeleteProcessInstance(networkId, string(message.Payload()))
+	this.handler.LogNetworkInteraction(ctx, networkId, processInstanceTopic)
+	this.handler.DeleteProcessInstance(ctx, networkId, string(message.Payload()))
 }
 
-func (this *Mgw) handleProcessInstanceKnown(message paho.Message) {
+func (this *Mgw) handleProcessInstanceKnown(ctx context.Context, message paho.Message) {
 	knownIds := []string{}
 	err := json.Unmarshal(message.Payload(), &knownIds)
 	if err != nil {
-		this.config.GetLogger().Error("error", "error", err, "stack", debug.Stack())
+		this.handleError(message, err)
 	}
 	networkId, err := this.getNetworkId(message.Topic())
 	if err != nil {
-		this.config.GetLogger().Error("error", "error", err, "stack", debug.Stack())
+		this.handleError(message, err)
 	}
-	this.handler.LogNetworkInteraction(networkId)
-	this.handler.DeleteUnknownProcessInstances(networkId, knownIds)
+	this.handler.LogNetworkInteraction(ctx, networkId, processInstanceTopic)
+	this.handler.DeleteUnknownProcessInstances(ctx, networkId, knownIds)
 }
 
-func (this *Mgw) SendProcessStopCommand(networkId string, processInstanceId string) error {
-	return this.sendStr(this.getCommandTopic(networkId, processInstanceTopic, "delete"), processInstanceId)
+func (this *Mgw) SendProcessStopCommand(ctx context.Context, networkId string, processInstanceId string) error {
+	return this.sendStr(ctx, this.getCommandTopic(networkId, processInstanceTopic, "delete"), processInstanceId)
 }
@@ -0,0 +1,49 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+import "time"
+
+// AuditEntry documents a mutating api call
+type AuditEntry struct {
+	Id         string                 `json:"id" bson:"id"`
+	Time       time.Time              `json:"time" bson:"time"`
+	UserId     string                 `json:"user_id" bson:"user_id"` //subject of the jwt; empty if the token is missing or invalid
+	NetworkId  string                 `json:"network_id,omitempty" bson:"network_id,omitempty"`
+	Entity     string                 `json:"entity" bson:"entity"` //first path segment of the endpoint (e.g. deployments, process-instances, incidents)
+	EntityId   string                 `json:"entity_id,omitempty" bson:"entity_id,omitempty"`
+	Action     string                 `json:"action" bson:"action"`         //method and pattern of the endpoint (e.g. "DELETE /deployments/{networkId}/{deploymentId}")
+	Parameters map[string]interface{} `json:"parameters" bson:"parameters"` //path values, query parameters and request body
+	StatusCode int                    `json:"status_code" bson:"status_code"`
+	Success    bool                   `json:"success" bson:"success"`
+	Error      string                 `json:"error,omitempty" bson:"error,omitempty"`
+	ExpiresAt  *time.Time             `json:"-" bson:"expires_at,omitempty"`
+}
+
+type AuditQuery struct {
+	NetworkIds []string
+	UserIds    []string
+	Entities   []string
+	EntityIds  []string
+	Actions    []string
+	Success    *bool
+	Since      time.Time
+	Until      time.Time
+	Sort       string
+	Limit      int64
+	Offset     int64
+}
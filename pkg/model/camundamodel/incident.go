@@ -30,4 +30,5 @@ type Incident struct {
 	TenantId            string    `json:"tenant_id" bson:"tenant_id"`
 	DeploymentName      string    `json:"deployment_name" bson:"deployment_name"`
 	BusinessKey         string    `json:"business_key" bson:"business_key"`
+	ActivityId          string    `json:"activity_id,omitempty" bson:"activity_id,omitempty"` //optional; sent by mgw versions with incident grouping support
 }
@@ -0,0 +1,38 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SENERGY-Platform/process-sync/pkg/model/camundamodel"
+)
+
+func TestHistoricProcessInstanceWithParsedDates(t *testing.T) {
+	actual := HistoricProcessInstance{HistoricProcessInstance: camundamodel.HistoricProcessInstance{
+		StartTime: "2026-01-01T10:00:00.000+0200",
+		EndTime:   "",
+	}}.WithParsedDates()
+	if actual.StartDate == nil || !actual.StartDate.Equal(time.Date(2026, 1, 1, 8, 0, 0, 0, time.UTC)) || actual.EndDate != nil {
+		t.Errorf("%#v", actual)
+	}
+	actual = HistoricProcessInstance{HistoricProcessInstance: camundamodel.HistoricProcessInstance{StartTime: "invalid"}}.WithParsedDates()
+	if actual.StartDate != nil {
+		t.Errorf("%#v", actual)
+	}
+}
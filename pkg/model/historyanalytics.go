@@ -0,0 +1,91 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+import "time"
+
+const (
+	HistoryAnalyticsGroupByDefinition = "definition"
+	HistoryAnalyticsGroupByDeployment = "deployment"
+)
+
+const (
+	HistoricProcessInstanceStateCompleted            = "COMPLETED"
+	HistoricProcessInstanceStateExternallyTerminated = "EXTERNALLY_TERMINATED"
+	HistoricProcessInstanceStateInternallyTerminated = "INTERNALLY_TERMINATED"
+)
+
+type HistoryAnalyticsQuery struct {
+	NetworkIds          []string
+	GroupBy             string //definition (default) or deployment
+	ProcessDefinitionId string
+	DeploymentId        string
+	From                time.Time //process instances started in [From, To) are analysed
+	To                  time.Time
+}
+
+// HistoryAnalytics describes the process instances of a process definition or deployment started in [From, To)
+type HistoryAnalytics struct {
+	NetworkId             string                   `json:"network_id"`
+	ProcessDefinitionId   string                   `json:"process_definition_id,omitempty"` //only if grouped by definition
+	ProcessDefinitionName string                   `json:"process_definition_name"`
+	DeploymentId          string                   `json:"deployment_id,omitempty"` //only if grouped by deployment
+	From                  time.Time                `json:"from"`
+	To                    time.Time                `json:"to"`
+	Runs                  int64                    `json:"runs"`
+	Finished              int64                    `json:"finished"`
+	Running               int64                    `json:"running"`
+	Completed             int64                    `json:"completed"`
+	Terminated            int64                    `json:"terminated"`          //externally or internally terminated
+	WithIncidents         int64                    `json:"with_incidents"`      //runs with incidents which are still stored; incidents are removed when they are resolved, so runs with only resolved incidents are not counted
+	SuccessRate           float64                  `json:"success_rate"`        //completed / finished
+	TerminationRate       float64                  `json:"termination_rate"`    //terminated / finished
+	IncidentRate          float64                  `json:"incident_rate"`       //with_incidents / runs
+	ThroughputPerHour     float64                  `json:"throughput_per_hour"` //finished runs per hour of the time window
+	DurationMillis        HistoryDurationAnalytics `json:"duration_millis"`     //of finished runs; percentiles are approximated with a deviation below 2%
+}
+
+type HistoryDurationAnalytics struct {
+	Min float64 `json:"min" bson:"min"`
+	Max float64 `json:"max" bson:"max"`
+	Avg float64 `json:"avg" bson:"avg"`
+	P50 float64 `json:"p50" bson:"p50"`
+	P90 float64 `json:"p90" bson:"p90"`
+	P95 float64 `json:"p95" bson:"p95"`
+	P99 float64 `json:"p99" bson:"p99"`
+}
+
+// WithRates sets the time window and the rates derived from the counts
+func (this HistoryAnalytics) WithRates(from time.Time, to time.Time) HistoryAnalytics {
+	this.From = from
+	this.To = to
+	this.Running = this.Runs - this.Finished
+	this.SuccessRate = ratio(this.Completed, this.Finished)
+	this.TerminationRate = ratio(this.Terminated, this.Finished)
+	this.IncidentRate = ratio(this.WithIncidents, this.Runs)
+	if hours := to.Sub(from).Hours(); hours > 0 {
+		this.ThroughputPerHour = float64(this.Finished) / hours
+	}
+	return this
+}
+
+func ratio(a int64, b int64) float64 {
+	if b == 0 {
+		return 0
+	}
+	return float64(a) / float64(b)
+}
@@ -0,0 +1,34 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHistoryAnalyticsWithRates(t *testing.T) {
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	actual := HistoryAnalytics{Runs: 10, Finished: 8, Completed: 6, Terminated: 2, WithIncidents: 1}.WithRates(from, from.Add(4*time.Hour))
+	if actual.Running != 2 || actual.SuccessRate != 0.75 || actual.TerminationRate != 0.25 || actual.IncidentRate != 0.1 || actual.ThroughputPerHour != 2 {
+		t.Errorf("%#v", actual)
+	}
+	empty := HistoryAnalytics{}.WithRates(from, from)
+	if empty.SuccessRate != 0 || empty.IncidentRate != 0 || empty.ThroughputPerHour != 0 {
+		t.Errorf("%#v", empty)
+	}
+}
@@ -0,0 +1,133 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// IncidentGroupInfo is derived from the incident on save; incidents with equal GroupId belong to the same IncidentGroup
+type IncidentGroupInfo struct {
+	GroupId                string `json:"group_id,omitempty" bson:"group_id,omitempty"`
+	NormalizedErrorMessage string `json:"normalized_error_message,omitempty" bson:"normalized_error_message,omitempty"`
+}
+
+// IncidentGroup aggregates the incidents of a network with equal process definition, activity and normalized error message
+type IncidentGroup struct {
+	GroupId                string              `json:"group_id"`
+	NetworkId              string              `json:"network_id"`
+	ProcessDefinitionId    string              `json:"process_definition_id"`
+	ActivityId             string              `json:"activity_id,omitempty"`
+	DeploymentName         string              `json:"deployment_name"`
+	TenantId               string              `json:"tenant_id"`
+	NormalizedErrorMessage string              `json:"normalized_error_message"`
+	LatestErrorMessage     string              `json:"latest_error_message"`
+	LatestIncidentId       string              `json:"latest_incident_id"`
+	FirstSeen              time.Time           `json:"first_seen"`
+	LastSeen               time.Time           `json:"last_seen"`
+	Count                  int64               `json:"count"`
+	Acknowledged           bool                `json:"acknowledged"` //true if no incident of the group occurred after the acknowledgement
+	Muted                  bool                `json:"muted"`        //muted groups send no notifications
+	State                  *IncidentGroupState `json:"state,omitempty"`
+}
+
+// IncidentGroupState is stored independent of the incidents, so that acknowledgements and mutes survive resolved incidents
+type IncidentGroupState struct {
+	NetworkId      string     `json:"network_id" bson:"network_id"`
+	GroupId        string     `json:"group_id" bson:"group_id"`
+	AcknowledgedAt *time.Time `json:"acknowledged_at,omitempty" bson:"acknowledged_at,omitempty"`
+	AcknowledgedBy string     `json:"acknowledged_by,omitempty" bson:"acknowledged_by,omitempty"`
+	Muted          bool       `json:"muted" bson:"muted"`
+	MutedUntil     *time.Time `json:"muted_until,omitempty" bson:"muted_until,omitempty"` //nil mutes until unmuted
+	MutedBy        string     `json:"muted_by,omitempty" bson:"muted_by,omitempty"`
+}
+
+func (this IncidentGroupState) IsMuted(now time.Time) bool {
+	return this.Muted && (this.MutedUntil == nil || now.Before(*this.MutedUntil))
+}
+
+func (this IncidentGroupState) IsAcknowledged(lastSeen time.Time) bool {
+	return this.AcknowledgedAt != nil && !lastSeen.After(*this.AcknowledgedAt)
+}
+
+type IncidentGroupQuery struct {
+	NetworkIds          []string
+	ProcessDefinitionId string
+	Sort                string //last_seen, first_seen or count; with optional .asc or .desc suffix
+	Limit               int64
+	Offset              int64
+}
+
+type IncidentStatsQuery struct {
+	NetworkIds []string
+	GroupId    string
+	From       time.Time
+	To         time.Time
+	Interval   time.Duration
+}
+
+// IncidentStats counts the stored (not yet resolved or deleted) incidents per time bucket
+type IncidentStats struct {
+	From     time.Time             `json:"from"`
+	To       time.Time             `json:"to"`
+	Interval string                `json:"interval"`
+	Total    int64                 `json:"total"`
+	Buckets  []IncidentStatsBucket `json:"buckets"`
+}
+
+type IncidentStatsBucket struct {
+	Time  time.Time `json:"time" bson:"_id"`
+	Count int64     `json:"count" bson:"count"`
+}
+
+var incidentErrorNormalizer = []struct {
+	pattern     *regexp.Regexp
+	replacement string
+}{
+	{pattern: regexp.MustCompile(`(?i)[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}`), replacement: "<uuid>"},
+	{pattern: regexp.MustCompile(`\d{4}-\d{2}-\d{2}[T ]\d{2}:\d{2}:\d{2}(\.\d+)?(Z|[+-]\d{2}:?\d{2})?`), replacement: "<time>"},
+	{pattern: regexp.MustCompile(`(?i)\b(0x)?[0-9a-f]{8,}\b`), replacement: "<hex>"},
+	{pattern: regexp.MustCompile(`\d+(\.\d+)?`), replacement: "<n>"},
+	{pattern: regexp.MustCompile(`\s+`), replacement: " "},
+}
+
+const maxNormalizedIncidentErrorLength = 500
+
+// NormalizeIncidentErrorMessage replaces ids, timestamps and numbers, so that repeated failures of the same cause produce equal messages
+func NormalizeIncidentErrorMessage(msg string) string {
+	for _, n := range incidentErrorNormalizer {
+		msg = n.pattern.ReplaceAllString(msg, n.replacement)
+	}
+	msg = strings.TrimSpace(msg)
+	if len(msg) > maxNormalizedIncidentErrorLength {
+		msg = strings.ToValidUTF8(msg[:maxNormalizedIncidentErrorLength], "")
+	}
+	return msg
+}
+
+func NewIncidentGroupInfo(processDefinitionId string, activityId string, errorMessage string) IncidentGroupInfo {
+	normalized := NormalizeIncidentErrorMessage(errorMessage)
+	hash := sha256.Sum256([]byte(processDefinitionId + "\x00" + activityId + "\x00" + normalized))
+	return IncidentGroupInfo{
+		GroupId:                hex.EncodeToString(hash[:16]),
+		NormalizedErrorMessage: normalized,
+	}
+}
@@ -0,0 +1,47 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+import "testing"
+
+func TestNormalizeIncidentErrorMessage(t *testing.T) {
+	cases := map[string]string{
+		"device 5b7e5c56-2a1f-4d8b-9d0e-2f0c7b3a1e44 not reachable":             "device <uuid> not reachable",
+		"timeout after 30s at 2026-01-02T03:04:05.123Z":                         "timeout after <n>s at <time>",
+		"unexpected value 12.5 for service  urn:infai:ses:service:a1b2c3d4e5f6": "unexpected value <n> for service urn:infai:ses:service:<hex>",
+		"  connection refused \n":                                               "connection refused",
+	}
+	for input, expected := range cases {
+		if actual := NormalizeIncidentErrorMessage(input); actual != expected {
+			t.Errorf("%q: expected %q, got %q", input, expected, actual)
+		}
+	}
+}
+
+func TestIncidentGroupInfo(t *testing.T) {
+	a := NewIncidentGroupInfo("def", "task", "value 1 is invalid")
+	b := NewIncidentGroupInfo("def", "task", "value 2 is invalid")
+	if a != b {
+		t.Errorf("%#v != %#v", a, b)
+	}
+	if c := NewIncidentGroupInfo("def", "other-task", "value 1 is invalid"); c.GroupId == a.GroupId {
+		t.Error("expected different group for different activity")
+	}
+	if c := NewIncidentGroupInfo("other-def", "task", "value 1 is invalid"); c.GroupId == a.GroupId {
+		t.Error("expected different group for different definition")
+	}
+}
@@ -0,0 +1,27 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+import "time"
+
+// Lease is held by at most one replica at a time and is used to elect the replica which runs a loop (e.g. the warden loops)
+type Lease struct {
+	Name      string    `json:"name" bson:"name"`
+	Holder    string    `json:"holder" bson:"holder"`
+	RenewedAt time.Time `json:"renewed_at" bson:"renewed_at"`
+	ExpiresAt time.Time `json:"expires_at" bson:"expires_at"`
+}
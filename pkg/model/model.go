@@ -36,8 +36,9 @@ type SyncInfo struct {
 }
 
 type LastNetworkContact struct {
-	NetworkId string    `json:"network_id"`
-	Time      time.Time `json:"time"`
+	NetworkId string               `json:"network_id"`
+	Time      time.Time            `json:"time"`
+	Topics    map[string]time.Time `json:"topics,omitempty"` //last contact per entity topic
 }
 
 type Metadata struct {
@@ -59,11 +60,55 @@ type Deployment struct {
 type HistoricProcessInstance struct {
 	camundamodel.HistoricProcessInstance
 	SyncInfo
+	StartDate *time.Time `json:"start_date,omitempty" bson:"start_date"` //parsed StartTime
+	EndDate   *time.Time `json:"end_date,omitempty" bson:"end_date"`     //parsed EndTime; nil while unfinished
+}
+
+// WithParsedDates sets StartDate and EndDate from the camunda formatted StartTime and EndTime; unparsable times result in nil
+func (this HistoricProcessInstance) WithParsedDates() HistoricProcessInstance {
+	this.StartDate = parseOptionalCamundaTime(this.StartTime)
+	this.EndDate = parseOptionalCamundaTime(this.EndTime)
+	return this
+}
+
+func parseOptionalCamundaTime(value string) *time.Time {
+	if value == "" {
+		return nil
+	}
+	result, err := camundamodel.ParseCamundaTime(value)
+	if err != nil {
+		return nil
+	}
+	result = result.UTC()
+	return &result
 }
 
 type Incident struct {
 	camundamodel.Incident
 	SyncInfo
+	PendingCommand *IncidentCommand `json:"pending_command,omitempty" bson:"pending_command,omitempty"` //command sent to the mgw; pending until the mgw removes the incident
+	IncidentGroupInfo
+}
+
+const (
+	IncidentCommandRetry   = "retry"   //increments the retries of the failed job/external task
+	IncidentCommandResolve = "resolve" //resolves the incident without retry
+	IncidentCommandRestart = "restart" //stops the process instance and restarts it with its initial variables
+)
+
+type IncidentCommand struct {
+	Command string    `json:"command" bson:"command"`
+	Time    time.Time `json:"time" bson:"time"`
+}
+
+// IncidentCommandMessage is sent to the mgw on processes/{networkId}/cmd/incident/{command}
+type IncidentCommandMessage struct {
+	IncidentId          string `json:"incident_id"`
+	ExternalTaskId      string `json:"external_task_id"`
+	ProcessInstanceId   string `json:"process_instance_id"`
+	ProcessDefinitionId string `json:"process_definition_id"`
+	BusinessKey         string `json:"business_key,omitempty"`
+	Retries             int    `json:"retries,omitempty"` //only for retry
 }
 
 type IncidentQuery struct {
@@ -107,10 +152,17 @@ type ExtendedDeployment struct {
 }
 
 type HistoryQuery struct {
-	State               string
+	State               string //finished or unfinished
 	ProcessDefinitionId string
 	Search              string
 	BusinessKeys        []string
+	StartedAfter        time.Time     //inclusive
+	StartedBefore       time.Time     //exclusive
+	EndedAfter          time.Time     //inclusive
+	EndedBefore         time.Time     //exclusive
+	MinDuration         time.Duration //finished instances by duration; unfinished instances by the time since their start
+	MaxDuration         time.Duration
+	Now                 time.Time //reference of the duration of unfinished instances
 }
 
 type DeploymentWithEventDesc struct {
@@ -132,6 +184,15 @@ type WardenInfo struct {
 	BusinessKey         string                 `json:"business_key" bson:"business_key"`                   //must be the same as the process-instance business-key and start with WardenBusinessKeyPrefix; the prefix may be set by Warden.MarkInstanceBusinessKeyAsWardenHandled
 	ProcessDeploymentId string                 `json:"process_deployment_id" bson:"process_deployment_id"` //must be the same as the process-instance process-deployment-id
 	StartParameters     map[string]interface{} `json:"start_parameters" bson:"start_parameters"`
+
+	RestartPolicy       *RestartPolicy `json:"restart_policy,omitempty" bson:"restart_policy,omitempty"`
+	RestartCount        int            `json:"restart_count" bson:"restart_count"`
+	ConsecutiveFailures int            `json:"consecutive_failures" bson:"consecutive_failures"` //restarts after incidents since the last successful finished instance
+	LastRestart         int64          `json:"last_restart" bson:"last_restart"`                 //unix timestamp
+	LastRestartReason   string         `json:"last_restart_reason" bson:"last_restart_reason"`
+	GaveUp              bool           `json:"gave_up" bson:"gave_up"` //restart policy allows no further restarts
+
+	Paused bool `json:"paused" bson:"paused"` //paused infos are ignored by the warden loops
 }
 
 const WardenBusinessKeyPrefix = "wardened:"
@@ -149,6 +210,9 @@ func (this WardenInfo) Validate() error {
 	if this.ProcessDeploymentId == "" {
 		return errors.New("process-deployment-id must not be empty")
 	}
+	if this.RestartPolicy != nil {
+		return this.RestartPolicy.Validate()
+	}
 	return nil
 }
 
@@ -156,6 +220,15 @@ func (this WardenInfo) IsOlderThen(duration time.Duration) bool {
 	return time.Unix(this.CreationTime, 0).Add(duration).After(time.Now())
 }
 
+func (this WardenInfo) IsPaused() bool {
+	return this.Paused
+}
+
+type WardenInfoWithStatus struct {
+	WardenInfo
+	Status string `json:"status"` //computed status; one of healthy, missing_instance, duplicate, restarting, waiting_for_age_gate, finished, gave_up, paused
+}
+
 type WardenInfoQuery struct {
 	NetworkIds           []string
 	BusinessKeys         []string
@@ -166,9 +239,12 @@ type WardenInfoQuery struct {
 }
 
 type DeploymentWardenInfo struct {
-	DeploymentId string                  `json:"deployment_id" bson:"deployment_id"`
-	NetworkId    string                  `json:"network_id" bson:"network_id"`
-	Deployment   DeploymentWithEventDesc `json:"deployment" bson:"deployment"`
+	DeploymentId  string                  `json:"deployment_id" bson:"deployment_id"`
+	NetworkId     string                  `json:"network_id" bson:"network_id"`
+	Deployment    DeploymentWithEventDesc `json:"deployment" bson:"deployment"`
+	RestartPolicy *RestartPolicy          `json:"restart_policy,omitempty" bson:"restart_policy,omitempty"` //default for instances started without own restart policy
+	Paused        bool                    `json:"paused" bson:"paused"`                                     //paused deployments are not redeployed; new instances inherit the paused state
+	Owner         string                  `json:"owner,omitempty" bson:"owner,omitempty"`                   //user id (jwt subject) of the user who deployed the process; empty if unknown
 }
 
 type DeploymentWardenInfoQuery struct {
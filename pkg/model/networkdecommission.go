@@ -0,0 +1,39 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+// steps of a network decommission, in order of execution
+const (
+	NetworkDecommissionRemoveWardenInfos = "remove_warden_infos"
+	NetworkDecommissionDeleteOnEdge      = "delete_deployments_on_edge" //only with NetworkDecommissionOptions.DeleteOnEdge
+	NetworkDecommissionArchive           = "archive"                    //only with configured archive store
+	NetworkDecommissionPurge             = "purge"
+	NetworkDecommissionRemoveLastContact = "remove_last_contact"
+	NetworkDecommissionDone              = "done"
+)
+
+type NetworkDecommissionOptions struct {
+	DeleteOnEdge bool //sends delete commands for all deployments to the mgw
+}
+
+// NetworkDecommissionProgress reports a finished (or failed) step of a network decommission
+type NetworkDecommissionProgress struct {
+	NetworkId string `json:"network_id"`
+	Step      string `json:"step"`
+	Count     int64  `json:"count"` //affected elements, if known
+	Error     string `json:"error,omitempty"`
+}
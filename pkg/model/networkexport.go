@@ -0,0 +1,67 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// NetworkExportVersion is the version of the network export format; imports of other versions are rejected
+const NetworkExportVersion = 1
+
+// record types of a network export
+const (
+	NetworkExportDeployment              = "deployment"
+	NetworkExportDeploymentMetadata      = "deployment_metadata"
+	NetworkExportProcessDefinition       = "process_definition"
+	NetworkExportProcessInstance         = "process_instance"
+	NetworkExportHistoricProcessInstance = "historic_process_instance"
+	NetworkExportIncident                = "incident"
+	NetworkExportDeploymentWardenInfo    = "deployment_warden_info"
+	NetworkExportWardenInfo              = "warden_info"
+	NetworkExportEnd                     = "end" //last record; document is a NetworkExportEndDocument
+)
+
+// NetworkExportHeader is the first line of a network export; every following line is a NetworkExportRecord
+type NetworkExportHeader struct {
+	Version    int       `json:"version"`
+	NetworkId  string    `json:"network_id"`
+	ExportedAt time.Time `json:"exported_at"`
+}
+
+type NetworkExportRecord struct {
+	Type     string          `json:"type"`
+	Document json.RawMessage `json:"document"`
+}
+
+// NetworkExportEndDocument marks a complete export
+type NetworkExportEndDocument struct {
+	Counts map[string]int `json:"counts"` //records per type
+}
+
+type NetworkImportOptions struct {
+	RewriteNetworkId bool //allows imports of exports of other networks; all documents are moved to the target network
+	SkipPlaceholders bool
+	Replace          bool //removes the current sync state of the target network before the import; otherwise imported documents are merged into it
+}
+
+type NetworkImportResult struct {
+	SourceNetworkId string         `json:"source_network_id"`
+	Imported        map[string]int `json:"imported"` //documents per type
+	Skipped         int            `json:"skipped"`  //placeholders
+}
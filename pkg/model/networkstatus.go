@@ -0,0 +1,59 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+import "time"
+
+const (
+	NetworkHealthOnline   = "online"
+	NetworkHealthDegraded = "degraded"
+	NetworkHealthOffline  = "offline"
+)
+
+// NetworkStatusCounts counts sync problems of a network
+type NetworkStatusCounts struct {
+	WaitingPlaceholderDeployments      int64 `json:"waiting_placeholder_deployments"`       //placeholder deployments, waiting longer than the placeholder wait time for the mgw
+	WaitingPlaceholderProcessInstances int64 `json:"waiting_placeholder_process_instances"` //placeholder process instances, waiting longer than the placeholder wait time for the mgw
+	MissingDeployments                 int64 `json:"missing_deployments"`                   //deployments marked as missing
+	DeploymentsMarkedForDelete         int64 `json:"deployments_marked_for_delete"`
+	OpenIncidents                      int64 `json:"open_incidents"`
+}
+
+type NetworkStatus struct {
+	NetworkId         string               `json:"network_id"`
+	Health            string               `json:"health"` //online, degraded or offline
+	LastContact       *time.Time           `json:"last_contact"`
+	LastTopicContacts map[string]time.Time `json:"last_topic_contacts"` //last contact per entity topic (deployment, incident, process-definition, process-instance, process-instance-history)
+	NetworkStatusCounts
+}
+
+// NetworkHealth derives the health of a network:
+// offline if the last contact is unknown or older than offlineAfter,
+// degraded if placeholders wait for the mgw or deployments are marked as missing or for delete,
+// online otherwise. open incidents are problems of the processes, not of the sync, and do not degrade the health.
+func NetworkHealth(now time.Time, lastContact *time.Time, offlineAfter time.Duration, counts NetworkStatusCounts) string {
+	if lastContact == nil || now.Sub(*lastContact) > offlineAfter {
+		return NetworkHealthOffline
+	}
+	if counts.WaitingPlaceholderDeployments > 0 ||
+		counts.WaitingPlaceholderProcessInstances > 0 ||
+		counts.MissingDeployments > 0 ||
+		counts.DeploymentsMarkedForDelete > 0 {
+		return NetworkHealthDegraded
+	}
+	return NetworkHealthOnline
+}
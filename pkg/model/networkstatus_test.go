@@ -0,0 +1,52 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNetworkHealth(t *testing.T) {
+	now := time.Now()
+	recent := now.Add(-time.Minute)
+	old := now.Add(-time.Hour)
+	tests := []struct {
+		name        string
+		lastContact *time.Time
+		counts      NetworkStatusCounts
+		expected    string
+	}{
+		{name: "unknown", lastContact: nil, expected: NetworkHealthOffline},
+		{name: "old contact", lastContact: &old, expected: NetworkHealthOffline},
+		{name: "old contact with problems", lastContact: &old, counts: NetworkStatusCounts{MissingDeployments: 1}, expected: NetworkHealthOffline},
+		{name: "online", lastContact: &recent, expected: NetworkHealthOnline},
+		{name: "online with incidents", lastContact: &recent, counts: NetworkStatusCounts{OpenIncidents: 3}, expected: NetworkHealthOnline},
+		{name: "waiting deployment", lastContact: &recent, counts: NetworkStatusCounts{WaitingPlaceholderDeployments: 1}, expected: NetworkHealthDegraded},
+		{name: "waiting instance", lastContact: &recent, counts: NetworkStatusCounts{WaitingPlaceholderProcessInstances: 1}, expected: NetworkHealthDegraded},
+		{name: "missing", lastContact: &recent, counts: NetworkStatusCounts{MissingDeployments: 1}, expected: NetworkHealthDegraded},
+		{name: "marked for delete", lastContact: &recent, counts: NetworkStatusCounts{DeploymentsMarkedForDelete: 1}, expected: NetworkHealthDegraded},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual := NetworkHealth(now, test.lastContact, 15*time.Minute, test.counts)
+			if actual != test.expected {
+				t.Errorf("expected %v, got %v", test.expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	// RestartGiveUpNotify sends a notification and keeps the warden info without further restarts
+	RestartGiveUpNotify = "notify"
+	// RestartGiveUpStopWardening sends a notification and removes the warden info
+	RestartGiveUpStopWardening = "stop_wardening"
+)
+
+// RestartPolicy configures how the warden restarts a process instance; without policy, instances are restarted after every incident.
+type RestartPolicy struct {
+	MaxRestarts      int    `json:"max_restarts" bson:"max_restarts"`             //maximum number of consecutive restarts after incidents; 0 = unlimited
+	Backoff          string `json:"backoff" bson:"backoff"`                       //duration (e.g. "1m") to wait after a failed instance ended, before it is restarted; doubled with every consecutive restart; empty = no backoff
+	MaxBackoff       string `json:"max_backoff" bson:"max_backoff"`               //upper limit for the backoff; empty = unlimited
+	GiveUpAction     string `json:"give_up_action" bson:"give_up_action"`         //action if max_restarts is reached: "notify" (default) or "stop_wardening"
+	RestartOnSuccess bool   `json:"restart_on_success" bson:"restart_on_success"` //restart instances which finished without incident (for always running processes)
+}
+
+var ErrInvalidRestartPolicy = errors.New("invalid restart policy")
+
+func (this RestartPolicy) Validate() error {
+	if this.MaxRestarts < 0 {
+		return fmt.Errorf("%w: max_restarts must not be negative", ErrInvalidRestartPolicy)
+	}
+	if this.Backoff != "" {
+		if _, err := time.ParseDuration(this.Backoff); err != nil {
+			return fmt.Errorf("%w: backoff: %w", ErrInvalidRestartPolicy, err)
+		}
+	}
+	if this.MaxBackoff != "" {
+		if _, err := time.ParseDuration(this.MaxBackoff); err != nil {
+			return fmt.Errorf("%w: max_backoff: %w", ErrInvalidRestartPolicy, err)
+		}
+	}
+	switch this.GiveUpAction {
+	case "", RestartGiveUpNotify, RestartGiveUpStopWardening:
+	default:
+		return fmt.Errorf("%w: unknown give_up_action '%v'", ErrInvalidRestartPolicy, this.GiveUpAction)
+	}
+	return nil
+}
+
+// backoff returns the delay before the next restart after the given number of consecutive failures
+func (this RestartPolicy) backoff(consecutiveFailures int) time.Duration {
+	if this.Backoff == "" {
+		return 0
+	}
+	delay, err := time.ParseDuration(this.Backoff)
+	if err != nil {
+		return 0
+	}
+	maxDelay := time.Duration(-1)
+	if this.MaxBackoff != "" {
+		maxDelay, err = time.ParseDuration(this.MaxBackoff)
+		if err != nil {
+			maxDelay = -1
+		}
+	}
+	for i := 0; i < consecutiveFailures && i < 32; i++ {
+		if maxDelay >= 0 && delay >= maxDelay {
+			break
+		}
+		delay = delay * 2
+	}
+	if maxDelay >= 0 && delay > maxDelay {
+		delay = maxDelay
+	}
+	return delay
+}
+
+func (this WardenInfo) RestartOnSuccess() bool {
+	return this.RestartPolicy != nil && this.RestartPolicy.RestartOnSuccess
+}
+
+func (this WardenInfo) HasGivenUp() bool {
+	return this.GaveUp
+}
+
+// NextRestartDelay returns the delay between the end of a failed instance and its restart.
+// giveUp is true if the restart policy allows no further restart.
+func (this WardenInfo) NextRestartDelay() (delay time.Duration, giveUp bool) {
+	if this.RestartPolicy == nil {
+		return 0, false
+	}
+	if this.RestartPolicy.MaxRestarts > 0 && this.ConsecutiveFailures >= this.RestartPolicy.MaxRestarts {
+		return 0, true
+	}
+	return this.RestartPolicy.backoff(this.ConsecutiveFailures), false
+}
+
+// RegisterRestart returns a copy of the info with updated restart statistics
+func (this WardenInfo) RegisterRestart(now time.Time, reason string, afterIncident bool) WardenInfo {
+	this.RestartCount++
+	if afterIncident {
+		this.ConsecutiveFailures++
+	} else {
+		this.ConsecutiveFailures = 0
+	}
+	this.LastRestart = now.Unix()
+	this.LastRestartReason = reason
+	return this
+}
+
+// RegisterGiveUp returns a copy of the info marked as given up and whether the info should be removed from the warden
+func (this WardenInfo) RegisterGiveUp(reason string) (updated WardenInfo, stopWardening bool) {
+	this.GaveUp = true
+	this.LastRestartReason = reason
+	return this, this.RestartPolicy != nil && this.RestartPolicy.GiveUpAction == RestartGiveUpStopWardening
+}
@@ -0,0 +1,86 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestWardenInfoRestartPolicy(t *testing.T) {
+	info := WardenInfo{RestartPolicy: &RestartPolicy{
+		MaxRestarts:  3,
+		Backoff:      "1m",
+		MaxBackoff:   "3m",
+		GiveUpAction: RestartGiveUpStopWardening,
+	}}
+	expectedDelays := []time.Duration{time.Minute, 2 * time.Minute, 3 * time.Minute}
+	now := time.Now()
+	for i, expected := range expectedDelays {
+		delay, giveUp := info.NextRestartDelay()
+		if giveUp {
+			t.Fatal("unexpected give up", i)
+		}
+		if delay != expected {
+			t.Error(i, delay, expected)
+		}
+		info = info.RegisterRestart(now, "finished with incident", true)
+	}
+	if info.RestartCount != 3 || info.ConsecutiveFailures != 3 || info.LastRestart != now.Unix() || info.LastRestartReason != "finished with incident" {
+		t.Errorf("%+v", info)
+	}
+	_, giveUp := info.NextRestartDelay()
+	if !giveUp {
+		t.Error("expected give up")
+	}
+	updated, stopWardening := info.RegisterGiveUp("max restarts reached")
+	if !stopWardening || !updated.HasGivenUp() {
+		t.Errorf("%v %+v", stopWardening, updated)
+	}
+
+	info = info.RegisterRestart(now, "finished without incident", false)
+	if info.RestartCount != 4 || info.ConsecutiveFailures != 0 {
+		t.Errorf("%+v", info)
+	}
+}
+
+func TestWardenInfoWithoutRestartPolicy(t *testing.T) {
+	info := WardenInfo{ConsecutiveFailures: 100}
+	delay, giveUp := info.NextRestartDelay()
+	if delay != 0 || giveUp {
+		t.Error(delay, giveUp)
+	}
+	if info.RestartOnSuccess() {
+		t.Error("unexpected restart on success")
+	}
+	_, stopWardening := info.RegisterGiveUp("")
+	if stopWardening {
+		t.Error("unexpected stop wardening")
+	}
+}
+
+func TestRestartPolicyValidate(t *testing.T) {
+	if err := (RestartPolicy{MaxRestarts: 1, Backoff: "1s", MaxBackoff: "1h", GiveUpAction: RestartGiveUpNotify}).Validate(); err != nil {
+		t.Error(err)
+	}
+	for _, policy := range []RestartPolicy{{MaxRestarts: -1}, {Backoff: "foo"}, {MaxBackoff: "1"}, {GiveUpAction: "panic"}} {
+		if err := policy.Validate(); !errors.Is(err, ErrInvalidRestartPolicy) {
+			t.Error(policy, err)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+import "time"
+
+// RetentionScope selects the entities of a retention policy or of the cleanup of old networks
+type RetentionScope struct {
+	NetworkIds           []string  //nil selects all networks
+	ExcludeNetworkIds    []string  //networks with their own policy
+	Before               time.Time //history: ended before; incidents: occurred before; zero selects all entities of the networks, including unfinished history
+	SkipMarkedForDelete  bool      //history only
+	SkipRunningInstances bool      //incidents only: keeps incidents of process instances which are still stored as running
+}
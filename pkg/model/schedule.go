@@ -0,0 +1,77 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+import "time"
+
+const (
+	// ScheduleMissedRunSkip ignores runs missed while no replica was able to trigger them (e.g. downtime) and waits for the next regular run
+	ScheduleMissedRunSkip = "skip"
+	// ScheduleMissedRunOnce triggers a single catch-up run for all missed runs
+	ScheduleMissedRunOnce = "run_once"
+)
+
+const (
+	ScheduleRunStatusStarted = "started"
+	ScheduleRunStatusSkipped = "skipped"
+	ScheduleRunStatusFailed  = "failed"
+)
+
+type Schedule struct {
+	Id           string `json:"id" bson:"id"`
+	NetworkId    string `json:"network_id" bson:"network_id"`
+	DeploymentId string `json:"deployment_id" bson:"deployment_id"`
+
+	// Cron expects the standard 5 fields (minute hour day-of-month month day-of-week)
+	// or one of the descriptors @yearly, @monthly, @weekly, @daily, @hourly and @every <duration>
+	Cron string `json:"cron" bson:"cron"`
+
+	// TimeZone is an IANA time zone name (e.g. Europe/Berlin); defaults to UTC
+	TimeZone string `json:"time_zone" bson:"time_zone"`
+
+	Parameter map[string]interface{} `json:"parameter" bson:"parameter"`
+
+	// BusinessKeyTemplate is a text/template with the fields .ScheduleId, .NetworkId, .DeploymentId and .Time (the planned run time);
+	// an empty template results in an empty business key
+	BusinessKeyTemplate string `json:"business_key_template" bson:"business_key_template"`
+
+	// MissedRunPolicy is ScheduleMissedRunSkip (default) or ScheduleMissedRunOnce
+	MissedRunPolicy string `json:"missed_run_policy" bson:"missed_run_policy"`
+
+	Disabled bool         `json:"disabled" bson:"disabled"`
+	NextRun  time.Time    `json:"next_run" bson:"next_run"`
+	LastRun  *ScheduleRun `json:"last_run,omitempty" bson:"last_run,omitempty"`
+
+	LockedUntil time.Time `json:"-" bson:"locked_until"`
+	LockOwner   string    `json:"-" bson:"lock_owner"`
+}
+
+type ScheduleRun struct {
+	Time        time.Time `json:"time" bson:"time"`               //time of the trigger
+	PlannedFor  time.Time `json:"planned_for" bson:"planned_for"` //time the run was planned for by the cron expression
+	Status      string    `json:"status" bson:"status"`           //one of ScheduleRunStatusStarted, ScheduleRunStatusSkipped, ScheduleRunStatusFailed
+	BusinessKey string    `json:"business_key,omitempty" bson:"business_key,omitempty"`
+	Error       string    `json:"error,omitempty" bson:"error,omitempty"`
+}
+
+type ScheduleQuery struct {
+	NetworkIds    []string
+	DeploymentIds []string
+	Sort          string
+	Limit         int64
+	Offset        int64
+}
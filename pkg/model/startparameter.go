@@ -0,0 +1,149 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/SENERGY-Platform/process-sync/pkg/model/camundamodel"
+)
+
+type StartRequest struct {
+	BusinessKey string                 `json:"business_key"`
+	Parameter   map[string]interface{} `json:"parameter"`
+
+	RestartPolicy *RestartPolicy `json:"restart_policy,omitempty"` //optional; defaults to the restart policy of the deployment
+}
+
+var ErrInvalidStartParameter = errors.New("invalid start parameter")
+
+// ValidateStartParameter checks the parameter against the ProcessParameter of the metadata and coerces the values to the expected camunda types.
+// parameters without a default value are required; an explicit null counts as missing for them. all problems are collected and returned as one error wrapping ErrInvalidStartParameter.
+func (this Metadata) ValidateStartParameter(parameter map[string]interface{}) (result map[string]interface{}, err error) {
+	result = map[string]interface{}{}
+	problems := []string{}
+	for name, value := range parameter {
+		variable, ok := this.ProcessParameter[name]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("unknown parameter '%v'", name))
+			continue
+		}
+		coerced, err := CoerceCamundaValue(variable.Type, value)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("parameter '%v': %v", name, err.Error()))
+			continue
+		}
+		result[name] = coerced
+	}
+	for name, variable := range this.ProcessParameter {
+		if value, ok := parameter[name]; (!ok || value == nil) && variable.Value == nil {
+			problems = append(problems, fmt.Sprintf("missing required parameter '%v'", name))
+		}
+	}
+	if len(problems) > 0 {
+		slices.Sort(problems)
+		return result, fmt.Errorf("%w: %v", ErrInvalidStartParameter, strings.Join(problems, "; "))
+	}
+	return result, nil
+}
+
+// CoerceCamundaValue converts value to the go representation of the camunda variable type (e.g. "Integer", "Double", "Boolean").
+// strings are parsed if the type expects a number, boolean or date; unknown types are passed through unchanged.
+func CoerceCamundaValue(camundaType string, value interface{}) (result interface{}, err error) {
+	if value == nil {
+		return nil, nil
+	}
+	switch strings.ToLower(camundaType) {
+	case "string":
+		switch v := value.(type) {
+		case string:
+			return v, nil
+		case float64, bool, json.Number:
+			return fmt.Sprint(v), nil
+		}
+	case "boolean":
+		switch v := value.(type) {
+		case bool:
+			return v, nil
+		case string:
+			b, err := strconv.ParseBool(v)
+			if err == nil {
+				return b, nil
+			}
+		}
+	case "integer":
+		return coerceInteger(camundaType, value, math.MinInt32, math.MaxInt32)
+	case "long":
+		return coerceInteger(camundaType, value, math.MinInt64, math.MaxInt64)
+	case "short":
+		return coerceInteger(camundaType, value, math.MinInt16, math.MaxInt16)
+	case "double":
+		f, ok := toFloat(value)
+		if ok {
+			return f, nil
+		}
+	case "date":
+		if v, ok := value.(string); ok {
+			t, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				t, err = camundamodel.ParseCamundaTime(v)
+			}
+			if err == nil {
+				return t.Format(camundamodel.CamundaTimeFormat), nil
+			}
+		}
+	default:
+		return value, nil
+	}
+	return nil, fmt.Errorf("expected type %v, got %T (%v)", camundaType, value, value)
+}
+
+func coerceInteger(camundaType string, value interface{}, min int64, max int64) (result int64, err error) {
+	f, ok := toFloat(value)
+	if !ok || f != math.Trunc(f) {
+		return 0, fmt.Errorf("expected type %v, got %T (%v)", camundaType, value, value)
+	}
+	if f < float64(min) || f > float64(max) {
+		return 0, fmt.Errorf("value %v out of range for type %v", value, camundaType)
+	}
+	return int64(f), nil
+}
+
+func toFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		return f, err == nil
+	}
+	return 0, false
+}
@@ -0,0 +1,102 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/SENERGY-Platform/process-sync/pkg/model/camundamodel"
+)
+
+func TestMetadata_ValidateStartParameter(t *testing.T) {
+	metadata := Metadata{
+		ProcessParameter: map[string]camundamodel.Variable{
+			"name":   {Type: "String"},
+			"count":  {Type: "Integer"},
+			"factor": {Type: "Double", Value: 1.5},
+			"flag":   {Type: "Boolean", Value: false},
+		},
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		result, err := metadata.ValidateStartParameter(map[string]interface{}{
+			"name":   "foo",
+			"count":  "42",
+			"factor": float64(2),
+			"flag":   "true",
+		})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		expected := map[string]interface{}{
+			"name":   "foo",
+			"count":  int64(42),
+			"factor": float64(2),
+			"flag":   true,
+		}
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("\n%#v\n%#v", result, expected)
+		}
+	})
+
+	t.Run("optional missing", func(t *testing.T) {
+		_, err := metadata.ValidateStartParameter(map[string]interface{}{
+			"name":  "foo",
+			"count": float64(1),
+		})
+		if err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := metadata.ValidateStartParameter(map[string]interface{}{
+			"count":   1.5,
+			"flag":    "nope",
+			"unknown": 1,
+		})
+		if !errors.Is(err, ErrInvalidStartParameter) {
+			t.Error(err)
+			return
+		}
+		for _, expected := range []string{"missing required parameter 'name'", "parameter 'count'", "parameter 'flag'", "unknown parameter 'unknown'"} {
+			if !strings.Contains(err.Error(), expected) {
+				t.Errorf("expected %v in %v", expected, err.Error())
+			}
+		}
+	})
+
+	t.Run("required null", func(t *testing.T) {
+		_, err := Metadata{ProcessParameter: map[string]camundamodel.Variable{"x": {Type: "String"}}}.ValidateStartParameter(map[string]interface{}{
+			"x": nil,
+		})
+		if !errors.Is(err, ErrInvalidStartParameter) || !strings.Contains(err.Error(), "missing required parameter 'x'") {
+			t.Error(err)
+		}
+	})
+
+	t.Run("out of range", func(t *testing.T) {
+		_, err := CoerceCamundaValue("Short", float64(40000))
+		if err == nil {
+			t.Error("expected error")
+		}
+	})
+}
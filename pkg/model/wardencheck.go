@@ -0,0 +1,28 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+import "time"
+
+// WardenCheckRequest is a pending event driven warden check, shared by all replicas;
+// it is handled by the replica holding the warden db loop lease
+type WardenCheckRequest struct {
+	NetworkId    string    `json:"network_id" bson:"network_id"`
+	BusinessKey  string    `json:"business_key" bson:"business_key"`
+	DeploymentId string    `json:"deployment_id" bson:"deployment_id"`
+	Due          time.Time `json:"due" bson:"due"`
+}
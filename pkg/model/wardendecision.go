@@ -0,0 +1,52 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+import "time"
+
+// WardenDecision documents an action the warden decided to take (or would have taken in dry-run mode)
+type WardenDecision struct {
+	Id           string     `json:"id" bson:"id"`
+	Time         time.Time  `json:"time" bson:"time"`
+	NetworkId    string     `json:"network_id" bson:"network_id"`
+	Entity       string     `json:"entity" bson:"entity"`       //warden_info, deployment_warden_info or process_instance
+	EntityId     string     `json:"entity_id" bson:"entity_id"` //business key, deployment id or process instance id
+	BusinessKey  string     `json:"business_key,omitempty" bson:"business_key,omitempty"`
+	DeploymentId string     `json:"deployment_id,omitempty" bson:"deployment_id,omitempty"`
+	Decision     string     `json:"decision" bson:"decision"`
+	Reason       string     `json:"reason" bson:"reason"`
+	DryRun       bool       `json:"dry_run" bson:"dry_run"`
+	Error        string     `json:"error,omitempty" bson:"error,omitempty"`
+	ExpiresAt    *time.Time `json:"-" bson:"expires_at,omitempty"`
+}
+
+const (
+	WardenDecisionEntityWardenInfo           = "warden_info"
+	WardenDecisionEntityDeploymentWardenInfo = "deployment_warden_info"
+	WardenDecisionEntityProcessInstance      = "process_instance"
+)
+
+type WardenDecisionQuery struct {
+	NetworkIds   []string
+	EntityIds    []string
+	BusinessKeys []string
+	Decisions    []string
+	Since        time.Time
+	Sort         string
+	Limit        int64
+	Offset       int64
+}
@@ -77,7 +77,7 @@ func TestMultiMqttClient(t *testing.T) {
 		}
 	}
 
-	client := NewClient(config.Mqtt, func(options *paho.ClientOptions) {
+	client, err := NewClient(config.Mqtt, func(options *paho.ClientOptions) {
 		options.SetCleanSession(config.MqttCleanSession)
 		options.SetResumeSubs(true)
 		options.SetConnectionLostHandler(func(c paho.Client, err error) {
@@ -90,6 +90,10 @@ func TestMultiMqttClient(t *testing.T) {
 			subscribe(c)
 		})
 	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
 
 	token := client.Connect()
 	if token.Wait() && token.Error() != nil {
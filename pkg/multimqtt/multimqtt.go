@@ -18,29 +18,44 @@ package multimqtt
 
 import (
 	"errors"
+	"fmt"
 	"sync"
 
 	"github.com/SENERGY-Platform/process-sync/pkg/configuration"
 	paho "github.com/eclipse/paho.mqtt.golang"
 )
 
-func NewClient(configs []configuration.MqttConfig, setOptions func(*paho.ClientOptions)) *MultiClient {
+func NewClient(configs []configuration.MqttConfig, setOptions func(*paho.ClientOptions)) (*MultiClient, error) {
 	result := &MultiClient{}
 	for _, config := range configs {
+		tlsConfig, err := NewTlsConfig(config)
+		if err != nil {
+			return nil, fmt.Errorf("invalid tls config of mqtt broker %v: %w", config.Broker, err)
+		}
 		options := paho.NewClientOptions().
 			SetPassword(config.Pw).
 			SetUsername(config.User).
 			SetClientID(config.ClientId).
 			AddBroker(config.Broker).
 			SetAutoReconnect(true)
+		if tlsConfig != nil {
+			options.SetTLSConfig(tlsConfig)
+		}
 		setOptions(options)
-		result.clients = append(result.clients, paho.NewClient(options))
+		client := paho.NewClient(options)
+		result.clients = append(result.clients, client)
+		if watch, ok := newTlsWatch(client, config); ok && tlsConfig != nil {
+			result.tlsWatches = append(result.tlsWatches, watch)
+		}
 	}
-	return result
+	return result, nil
 }
 
 type MultiClient struct {
-	clients []paho.Client
+	clients    []paho.Client
+	tlsWatches []tlsWatch
+	mux        sync.Mutex
+	stopWatch  chan struct{} //nil while the tls files are not watched
 }
 
 func do(clients []paho.Client, f func(client paho.Client) paho.Token) paho.Token {
@@ -93,12 +108,26 @@ func (this *MultiClient) IsConnectionOpen() bool {
 }
 
 func (this *MultiClient) Connect() paho.Token {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	if this.stopWatch == nil && len(this.tlsWatches) > 0 {
+		this.stopWatch = make(chan struct{})
+		for _, watch := range this.tlsWatches {
+			go this.watchTlsFiles(watch, this.stopWatch)
+		}
+	}
 	return do(this.clients, func(client paho.Client) paho.Token {
 		return client.Connect()
 	})
 }
 
 func (this *MultiClient) Disconnect(quiesce uint) {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	if this.stopWatch != nil {
+		close(this.stopWatch)
+		this.stopWatch = nil
+	}
 	wg := sync.WaitGroup{}
 	for _, client := range this.clients {
 		wg.Add(1)
@@ -0,0 +1,228 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package multimqtt
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/SENERGY-Platform/process-sync/pkg/configuration"
+	paho "github.com/eclipse/paho.mqtt.golang"
+)
+
+// NewTlsConfig returns nil if the broker config has no TLS options;
+// the ca bundle and the client certificate are read on every (re)connect if their files changed
+// (MultiClient reconnects open connections when the files change, see tlsWatch)
+func NewTlsConfig(config configuration.MqttConfig) (*tls.Config, error) {
+	if config.CaFile == "" && config.CertFile == "" && config.KeyFile == "" && config.ServerName == "" && !config.InsecureSkipVerify {
+		return nil, nil
+	}
+	if (config.CertFile == "") != (config.KeyFile == "") {
+		return nil, errors.New("mqtt cert_file and key_file must be set together")
+	}
+	serverName := config.ServerName
+	if serverName == "" {
+		broker, err := url.Parse(config.Broker)
+		if err != nil {
+			return nil, fmt.Errorf("invalid mqtt broker url: %w", err)
+		}
+		serverName = broker.Hostname()
+	}
+	files := &tlsFiles{caFile: config.CaFile, certFile: config.CertFile, keyFile: config.KeyFile}
+	result := &tls.Config{ServerName: serverName, MinVersion: tls.VersionTLS12}
+	if config.CertFile != "" {
+		result.GetClientCertificate = files.clientCertificate
+	}
+	if config.InsecureSkipVerify {
+		result.InsecureSkipVerify = true
+	} else if config.CaFile != "" {
+		//tls.Config.RootCAs can not be replaced after the client is created; verifyConnection replaces the default verification to use the current ca bundle
+		result.InsecureSkipVerify = true
+		result.VerifyConnection = files.verifyConnection
+	}
+	return result, nil
+}
+
+type tlsFiles struct {
+	caFile   string
+	certFile string
+	keyFile  string
+
+	mux       sync.Mutex
+	pool      *x509.CertPool
+	caState   fileState
+	cert      *tls.Certificate
+	certState fileState
+	keyState  fileState
+}
+
+type fileState struct {
+	modTime time.Time
+	size    int64
+}
+
+func stat(file string) (fileState, error) {
+	info, err := os.Stat(file)
+	if err != nil {
+		return fileState{}, err
+	}
+	return fileState{modTime: info.ModTime(), size: info.Size()}, nil
+}
+
+func (this *tlsFiles) rootCAs() (*x509.CertPool, error) {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	state, err := stat(this.caFile)
+	if err != nil {
+		return nil, err
+	}
+	if this.pool != nil && state == this.caState {
+		return this.pool, nil
+	}
+	pem, err := os.ReadFile(this.caFile)
+	if err != nil {
+		return nil, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificates found in %v", this.caFile)
+	}
+	this.pool, this.caState = pool, state
+	return pool, nil
+}
+
+func (this *tlsFiles) clientCertificate(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	certState, err := stat(this.certFile)
+	if err != nil {
+		return nil, err
+	}
+	keyState, err := stat(this.keyFile)
+	if err != nil {
+		return nil, err
+	}
+	if this.cert != nil && certState == this.certState && keyState == this.keyState {
+		return this.cert, nil
+	}
+	cert, err := tls.LoadX509KeyPair(this.certFile, this.keyFile)
+	if err != nil {
+		return nil, err
+	}
+	this.cert, this.certState, this.keyState = &cert, certState, keyState
+	return this.cert, nil
+}
+
+func (this *tlsFiles) verifyConnection(state tls.ConnectionState) error {
+	if len(state.PeerCertificates) == 0 {
+		return errors.New("mqtt broker sent no certificate")
+	}
+	pool, err := this.rootCAs()
+	if err != nil {
+		return err
+	}
+	options := x509.VerifyOptions{
+		Roots:         pool,
+		DNSName:       state.ServerName,
+		Intermediates: x509.NewCertPool(),
+	}
+	for _, cert := range state.PeerCertificates[1:] {
+		options.Intermediates.AddCert(cert)
+	}
+	_, err = state.PeerCertificates[0].Verify(options)
+	return err
+}
+
+// tlsWatchInterval is the poll interval of the tls files of connected clients
+var tlsWatchInterval = 10 * time.Second
+
+// tlsReconnectQuiesce is the time in ms to complete pending work before a connection is replaced
+const tlsReconnectQuiesce = 250
+
+// tlsWatch polls the tls files of a client; a handshake only happens on (re)connect, so the client is reconnected when the files change
+type tlsWatch struct {
+	client paho.Client
+	files  [3]string //ca, cert and key file; empty if not configured
+}
+
+func newTlsWatch(client paho.Client, config configuration.MqttConfig) (watch tlsWatch, ok bool) {
+	watch = tlsWatch{client: client, files: [3]string{config.CaFile, config.CertFile, config.KeyFile}}
+	return watch, config.CaFile != "" || config.CertFile != ""
+}
+
+// states returns the zero state for missing files, so that a file removed during a rotation counts as change
+func (this tlsWatch) states() (result [3]fileState) {
+	for i, file := range this.files {
+		if file != "" {
+			result[i], _ = stat(file)
+		}
+	}
+	return result
+}
+
+// watchTlsFiles reconnects the client of watch, if its tls files changed and stayed unchanged for one poll interval
+// (e.g. cert and key written one after another); a failed reconnect is retried on the next interval
+func (this *MultiClient) watchTlsFiles(watch tlsWatch, stop chan struct{}) {
+	ticker := time.NewTicker(tlsWatchInterval)
+	defer ticker.Stop()
+	applied := watch.states()
+	last := applied
+	failed := false
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+		current := watch.states()
+		if current != last {
+			last = current
+			continue
+		}
+		if current == applied && !failed {
+			continue
+		}
+		failed = this.reconnect(watch.client, stop, failed) != nil
+		applied = current
+	}
+}
+
+// reconnect replaces the open connection of client, so that the new connection uses the current tls files;
+// a client without open connection uses them on its next (auto) reconnect and is only connected again if retry is set
+func (this *MultiClient) reconnect(client paho.Client, stop chan struct{}, retry bool) error {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	select {
+	case <-stop:
+		return nil
+	default:
+	}
+	if client.IsConnectionOpen() {
+		client.Disconnect(tlsReconnectQuiesce)
+	} else if !retry {
+		return nil
+	}
+	token := client.Connect()
+	token.Wait()
+	return token.Error()
+}
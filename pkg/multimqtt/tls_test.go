@@ -0,0 +1,336 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package multimqtt
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SENERGY-Platform/process-sync/pkg/configuration"
+	paho "github.com/eclipse/paho.mqtt.golang"
+	"github.com/eclipse/paho.mqtt.golang/packets"
+)
+
+type testCert struct {
+	cert    *x509.Certificate
+	key     *ecdsa.PrivateKey
+	certPem []byte
+	keyPem  []byte
+}
+
+func newTestCert(t *testing.T, cn string, parent *testCert) testCert {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		DNSNames:     []string{cn},
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	signer, signerKey := template, key
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		template.KeyUsage |= x509.KeyUsageCertSign
+	} else {
+		signer, signerKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return testCert{
+		cert:    cert,
+		key:     key,
+		certPem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		keyPem:  pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}),
+	}
+}
+
+// writeTestFile sets an increasing modification time, so that changes are detected independent of the file system time resolution
+func writeTestFile(t *testing.T, file string, content []byte, version int) {
+	err := os.WriteFile(file, content, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	modTime := time.Now().Add(time.Duration(version) * time.Minute)
+	err = os.Chtimes(file, modTime, modTime)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestTlsConfigReload(t *testing.T) {
+	ca1 := newTestCert(t, "ca1", nil)
+	ca2 := newTestCert(t, "ca2", nil)
+	server := newTestCert(t, "broker.test", &ca2)
+	client1 := newTestCert(t, "client1", &ca1)
+	client2 := newTestCert(t, "client2", &ca1)
+
+	serverCert, err := tls.X509KeyPair(server.certPem, server.keyPem)
+	if err != nil {
+		t.Fatal(err)
+	}
+	clientPool := x509.NewCertPool()
+	clientPool.AddCert(ca1.cert)
+	mux := sync.Mutex{}
+	clientNames := []string{}
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{
+		Certificates: []tls.Certificate{serverCert},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    clientPool,
+		VerifyConnection: func(state tls.ConnectionState) error {
+			mux.Lock()
+			defer mux.Unlock()
+			clientNames = append(clientNames, state.PeerCertificates[0].Subject.CommonName)
+			return nil
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				if conn.(*tls.Conn).Handshake() == nil {
+					_, _ = conn.Write([]byte("x"))
+				}
+			}()
+		}
+	}()
+
+	dir := t.TempDir()
+	config := configuration.MqttConfig{
+		Broker:     "ssl://" + listener.Addr().String(),
+		CaFile:     filepath.Join(dir, "ca.pem"),
+		CertFile:   filepath.Join(dir, "client.pem"),
+		KeyFile:    filepath.Join(dir, "client-key.pem"),
+		ServerName: "broker.test",
+	}
+	writeTestFile(t, config.CaFile, ca1.certPem, 0)
+	writeTestFile(t, config.CertFile, client1.certPem, 0)
+	writeTestFile(t, config.KeyFile, client1.keyPem, 0)
+
+	tlsConfig, err := NewTlsConfig(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	connect := func() error {
+		conn, err := tls.Dial("tcp", listener.Addr().String(), tlsConfig)
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+		//the server verifies the client certificate after the client handshake; it only answers after a successful verification
+		_, err = conn.Read(make([]byte, 1))
+		return err
+	}
+
+	if err = connect(); err == nil {
+		t.Error("broker certificate of unknown ca should be rejected")
+	}
+
+	writeTestFile(t, config.CaFile, ca2.certPem, 1)
+	if err = connect(); err != nil {
+		t.Error(err)
+	}
+
+	writeTestFile(t, config.CertFile, client2.certPem, 1)
+	writeTestFile(t, config.KeyFile, client2.keyPem, 1)
+	if err = connect(); err != nil {
+		t.Error(err)
+	}
+
+	mux.Lock()
+	defer mux.Unlock()
+	if len(clientNames) != 2 || clientNames[0] != "client1" || clientNames[1] != "client2" {
+		t.Error(clientNames)
+	}
+}
+
+func TestNewTlsConfig(t *testing.T) {
+	result, err := NewTlsConfig(configuration.MqttConfig{Broker: "tcp://localhost:1883"})
+	if err != nil || result != nil {
+		t.Error(result, err)
+	}
+	_, err = NewTlsConfig(configuration.MqttConfig{Broker: "ssl://localhost:8883", CertFile: "client.pem"})
+	if err == nil {
+		t.Error("expected error for missing key_file")
+	}
+	result, err = NewTlsConfig(configuration.MqttConfig{Broker: "ssl://broker.example.com:8883", InsecureSkipVerify: true})
+	if err != nil || result == nil || !result.InsecureSkipVerify || result.ServerName != "broker.example.com" || result.VerifyConnection != nil {
+		t.Error(result, err)
+	}
+}
+
+// serveFakeBroker answers connect, subscribe and ping packets and reports the common name of the client certificate of every connection
+func serveFakeBroker(listener net.Listener, connected chan<- string) {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		go func() {
+			defer conn.Close()
+			tlsConn := conn.(*tls.Conn)
+			if tlsConn.Handshake() != nil {
+				return
+			}
+			for {
+				packet, err := packets.ReadPacket(conn)
+				if err != nil {
+					return
+				}
+				var response packets.ControlPacket
+				switch p := packet.(type) {
+				case *packets.ConnectPacket:
+					response = packets.NewControlPacket(packets.Connack)
+					connected <- tlsConn.ConnectionState().PeerCertificates[0].Subject.CommonName
+				case *packets.SubscribePacket:
+					suback := packets.NewControlPacket(packets.Suback).(*packets.SubackPacket)
+					suback.MessageID = p.MessageID
+					suback.ReturnCodes = p.Qoss
+					response = suback
+				case *packets.PingreqPacket:
+					response = packets.NewControlPacket(packets.Pingresp)
+				case *packets.DisconnectPacket:
+					return
+				default:
+					continue
+				}
+				if response.Write(conn) != nil {
+					return
+				}
+			}
+		}()
+	}
+}
+
+func TestTlsRotationReconnects(t *testing.T) {
+	interval := tlsWatchInterval
+	tlsWatchInterval = 20 * time.Millisecond
+	defer func() { tlsWatchInterval = interval }()
+
+	ca := newTestCert(t, "ca", nil)
+	server := newTestCert(t, "broker.test", &ca)
+	client1 := newTestCert(t, "client1", &ca)
+	client2 := newTestCert(t, "client2", &ca)
+
+	serverCert, err := tls.X509KeyPair(server.certPem, server.keyPem)
+	if err != nil {
+		t.Fatal(err)
+	}
+	clientPool := x509.NewCertPool()
+	clientPool.AddCert(ca.cert)
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{
+		Certificates: []tls.Certificate{serverCert},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    clientPool,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	connected := make(chan string, 10)
+	go serveFakeBroker(listener, connected)
+
+	dir := t.TempDir()
+	config := configuration.MqttConfig{
+		Broker:     "ssl://" + listener.Addr().String(),
+		ClientId:   "test",
+		CaFile:     filepath.Join(dir, "ca.pem"),
+		CertFile:   filepath.Join(dir, "client.pem"),
+		KeyFile:    filepath.Join(dir, "client-key.pem"),
+		ServerName: "broker.test",
+	}
+	writeTestFile(t, config.CaFile, ca.certPem, 0)
+	writeTestFile(t, config.CertFile, client1.certPem, 0)
+	writeTestFile(t, config.KeyFile, client1.keyPem, 0)
+
+	onConnect := make(chan struct{}, 10)
+	client, err := NewClient([]configuration.MqttConfig{config}, func(options *paho.ClientOptions) {
+		options.SetOnConnectHandler(func(client paho.Client) {
+			onConnect <- struct{}{}
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token := client.Connect(); token.Wait() && token.Error() != nil {
+		t.Fatal(token.Error())
+	}
+	defer client.Disconnect(0)
+
+	expect := func(name string) {
+		select {
+		case actual := <-connected:
+			if actual != name {
+				t.Error(actual, name)
+			}
+		case <-time.After(5 * time.Second):
+			t.Error("missing connection of", name)
+		}
+		select {
+		case <-onConnect:
+		case <-time.After(5 * time.Second):
+			t.Error("missing on connect call of", name)
+		}
+	}
+	expect("client1")
+
+	writeTestFile(t, config.CertFile, client2.certPem, 1)
+	writeTestFile(t, config.KeyFile, client2.keyPem, 1)
+	expect("client2")
+	if !client.IsConnected() {
+		t.Error("client should be connected after the rotation")
+	}
+}
@@ -0,0 +1,61 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package notifier
+
+const (
+	ChannelTypeWebhook                = "webhook"
+	ChannelTypeSmtp                   = "smtp"
+	ChannelTypeDeveloperNotifications = "developer_notifications"
+	ChannelTypeKafka                  = "kafka"
+)
+
+type Config struct {
+	Channels []ChannelConfig `json:"channels"`
+	Rules    []Rule          `json:"rules"` //without rules, every notification is sent to every channel
+
+	DedupWindow     string `json:"dedup_window"`      //equal notifications (kind, network, deployment, error message) are sent once per window; default 1h; "-" disables the deduplication
+	RateLimit       int    `json:"rate_limit"`        //max notifications per channel and network in rate_limit_window; 0 = unlimited
+	RateLimitWindow string `json:"rate_limit_window"` //default 1h
+}
+
+type ChannelConfig struct {
+	Name string `json:"name"`
+	Type string `json:"type"` //webhook, smtp, developer_notifications or kafka
+
+	TitleTemplate string `json:"title_template"` //go text/template of the Message fields; empty uses the default of the notification kind
+	BodyTemplate  string `json:"body_template"`  //go text/template of the Message fields; empty uses the default of the notification kind
+
+	Url    string `json:"url"`    //webhook and developer_notifications
+	Secret string `json:"secret"` //webhook: hmac-sha256 key of the X-Signature header; empty sends unsigned requests
+
+	SmtpHost     string   `json:"smtp_host"`
+	SmtpPort     string   `json:"smtp_port"`
+	SmtpUser     string   `json:"smtp_user"`
+	SmtpPassword string   `json:"smtp_password"`
+	From         string   `json:"from"`
+	To           []string `json:"to"`
+
+	Topic string `json:"topic"` //kafka; uses kafka_url of the service config
+}
+
+// Rule routes notifications matching all non-empty filters to the listed channels
+type Rule struct {
+	NetworkIds []string `json:"network_ids"`
+	TenantIds  []string `json:"tenant_ids"`
+	Kinds      []string `json:"kinds"` //incident or warden_give_up
+	Channels   []string `json:"channels"`
+}
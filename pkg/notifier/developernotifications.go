@@ -0,0 +1,73 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package notifier
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	developerNotifications "github.com/SENERGY-Platform/developer-notifications/pkg/client"
+)
+
+const developerNotificationSender = "github.com/SENERGY-Platform/process-sync"
+
+var developerNotificationTitles = map[string]string{
+	KindIncident:     "Fog-Process-Incident-User-Notification",
+	KindWardenGiveUp: "Fog-Process-Restart-Give-Up-User-Notification",
+}
+
+var developerNotificationTags = map[string][]string{
+	KindIncident:     {"fog", "process-incident", "user-notification"},
+	KindWardenGiveUp: {"fog", "process-incident", "warden-give-up", "user-notification"},
+}
+
+// DeveloperNotifications sends messages to the developer-notifications service, which forwards them to the user
+type DeveloperNotifications struct {
+	client developerNotifications.Client
+}
+
+func NewDeveloperNotifications(config ChannelConfig) (*DeveloperNotifications, error) {
+	if config.Url == "" {
+		return nil, errors.New("missing url")
+	}
+	return NewDeveloperNotificationsWithClient(developerNotifications.New(config.Url)), nil
+}
+
+func NewDeveloperNotificationsWithClient(client developerNotifications.Client) *DeveloperNotifications {
+	return &DeveloperNotifications{client: client}
+}
+
+func (this *DeveloperNotifications) Send(_ context.Context, message Message) error {
+	title, ok := developerNotificationTitles[message.Kind]
+	if !ok {
+		title = "Fog-Process-User-Notification"
+	}
+	tags := append([]string{}, developerNotificationTags[message.Kind]...)
+	tags = append(tags, message.TenantId, message.NetworkId)
+	return this.client.SendMessage(developerNotifications.Message{
+		Sender: developerNotificationSender,
+		Title:  title,
+		Tags:   tags,
+		Body: fmt.Sprintf("Notification For %v in network %v\nTitle: %v\nMessage: %v\n",
+			message.TenantId,
+			message.NetworkId,
+			message.Title,
+			message.Body,
+		),
+	})
+}
@@ -0,0 +1,71 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package notifier
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/SENERGY-Platform/process-sync/pkg/configuration"
+	"github.com/SENERGY-Platform/process-sync/pkg/kafka"
+)
+
+// Factory creates the notifier of a channel config
+type Factory func(ctx context.Context, config ChannelConfig) (Notifier, error)
+
+// NewFactory returns the factory of the channel types webhook, smtp, developer_notifications and kafka
+func NewFactory(config configuration.Config) Factory {
+	return func(ctx context.Context, channel ChannelConfig) (Notifier, error) {
+		switch channel.Type {
+		case ChannelTypeWebhook:
+			return NewWebhook(channel)
+		case ChannelTypeSmtp:
+			return NewSmtp(channel)
+		case ChannelTypeDeveloperNotifications:
+			return NewDeveloperNotifications(channel)
+		case ChannelTypeKafka:
+			if config.KafkaUrl == "" || config.KafkaUrl == "-" {
+				return nil, fmt.Errorf("kafka notification channel needs kafka_url")
+			}
+			if channel.Topic == "" {
+				return nil, fmt.Errorf("missing topic")
+			}
+			producer, err := kafka.NewProducer(ctx, config.KafkaUrl, channel.Topic, config.GetLogger(), config.InitTopics)
+			if err != nil {
+				return nil, err
+			}
+			return NewKafka(producer), nil
+		default:
+			return nil, fmt.Errorf("unknown channel type '%v'", channel.Type)
+		}
+	}
+}
+
+// DefaultConfig is used without notification_config_file; it sends all notifications to the developer-notifications service (if configured)
+func DefaultConfig(config configuration.Config) Config {
+	if config.DeveloperNotificationUrl == "" || config.DeveloperNotificationUrl == "-" {
+		return Config{DedupWindow: "-"}
+	}
+	return Config{
+		DedupWindow: "-",
+		Channels: []ChannelConfig{{
+			Name: "developer-notifications",
+			Type: ChannelTypeDeveloperNotifications,
+			Url:  config.DeveloperNotificationUrl,
+		}},
+	}
+}
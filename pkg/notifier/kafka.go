@@ -0,0 +1,41 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/SENERGY-Platform/process-deployment/lib/interfaces"
+)
+
+// Kafka produces messages as json with the network id as key
+type Kafka struct {
+	producer interfaces.Producer
+}
+
+func NewKafka(producer interfaces.Producer) *Kafka {
+	return &Kafka{producer: producer}
+}
+
+func (this *Kafka) Send(_ context.Context, message Message) error {
+	msg, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+	return this.producer.Produce(message.NetworkId, msg)
+}
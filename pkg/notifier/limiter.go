@@ -0,0 +1,131 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package notifier
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+const defaultDedupWindow = time.Hour
+const defaultRateLimitWindow = time.Hour
+const limiterCleanupSize = 10000
+
+// limiter keeps its state in memory; replicas deduplicate and limit independently
+type limiter struct {
+	mux             sync.Mutex
+	dedupWindow     time.Duration
+	rateLimit       int
+	rateLimitWindow time.Duration
+	sent            map[string]time.Time
+	windows         map[string]*rateWindow
+	now             func() time.Time
+}
+
+type rateWindow struct {
+	start      time.Time
+	count      int
+	suppressed int
+}
+
+func newLimiter(config Config) (result *limiter, err error) {
+	result = &limiter{
+		dedupWindow:     defaultDedupWindow,
+		rateLimit:       config.RateLimit,
+		rateLimitWindow: defaultRateLimitWindow,
+		sent:            map[string]time.Time{},
+		windows:         map[string]*rateWindow{},
+		now:             time.Now,
+	}
+	switch config.DedupWindow {
+	case "":
+	case "-":
+		result.dedupWindow = 0
+	default:
+		result.dedupWindow, err = time.ParseDuration(config.DedupWindow)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if config.RateLimitWindow != "" {
+		result.rateLimitWindow, err = time.ParseDuration(config.RateLimitWindow)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// duplicate returns true if an equal notification has been seen within the dedup window; otherwise n is recorded
+func (this *limiter) duplicate(n Notification) bool {
+	if this.dedupWindow <= 0 {
+		return false
+	}
+	key := strings.Join([]string{n.Kind, n.NetworkId, n.TenantId, n.DeploymentId, n.DeploymentName, n.ProcessDefinitionId, n.BusinessKey, n.ErrorMessage}, "\x00")
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	now := this.now()
+	if len(this.sent) > limiterCleanupSize {
+		for k, t := range this.sent {
+			if now.Sub(t) >= this.dedupWindow {
+				delete(this.sent, k)
+			}
+		}
+	}
+	if last, ok := this.sent[key]; ok && now.Sub(last) < this.dedupWindow {
+		return true
+	}
+	this.sent[key] = now
+	return false
+}
+
+// allow counts the notification in the window of channel and network;
+// suppressed is the count of rejected notifications since the last allowed one and is reset if allowed is true
+func (this *limiter) allow(channel string, networkId string) (allowed bool, suppressed int) {
+	if this.rateLimit <= 0 {
+		return true, 0
+	}
+	key := channel + "\x00" + networkId
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	now := this.now()
+	if len(this.windows) > limiterCleanupSize {
+		for k, w := range this.windows {
+			if now.Sub(w.start) >= this.rateLimitWindow && w.suppressed == 0 {
+				delete(this.windows, k)
+			}
+		}
+	}
+	w, ok := this.windows[key]
+	if !ok {
+		w = &rateWindow{start: now}
+		this.windows[key] = w
+	}
+	if now.Sub(w.start) >= this.rateLimitWindow {
+		w.start = now
+		w.count = 0
+	}
+	if w.count >= this.rateLimit {
+		w.suppressed++
+		return false, w.suppressed
+	}
+	w.count++
+	suppressed = w.suppressed
+	w.suppressed = 0
+	return true, suppressed
+}
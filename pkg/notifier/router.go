@@ -0,0 +1,239 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"slices"
+	"sync"
+	"time"
+)
+
+const (
+	KindIncident     = "incident"
+	KindWardenGiveUp = "warden_give_up"
+)
+
+// Notification describes an event the user should be informed about
+type Notification struct {
+	Kind                string    `json:"kind"`
+	NetworkId           string    `json:"network_id"`
+	TenantId            string    `json:"tenant_id"`
+	DeploymentId        string    `json:"deployment_id,omitempty"`
+	DeploymentName      string    `json:"deployment_name,omitempty"`
+	ProcessDefinitionId string    `json:"process_definition_id,omitempty"`
+	ProcessInstanceId   string    `json:"process_instance_id,omitempty"`
+	IncidentId          string    `json:"incident_id,omitempty"`
+	IncidentGroupId     string    `json:"incident_group_id,omitempty"`
+	BusinessKey         string    `json:"business_key,omitempty"`
+	ErrorMessage        string    `json:"error_message,omitempty"`
+	RestartCount        int       `json:"restart_count,omitempty"`
+	Time                time.Time `json:"time"`
+}
+
+// Message is a Notification rendered by the templates of a channel
+type Message struct {
+	Notification
+	Title      string `json:"title"`
+	Body       string `json:"body"`
+	Suppressed int    `json:"suppressed"` //count of notifications of the channel and network, suppressed by the rate limit since the last message
+}
+
+type Notifier interface {
+	Send(ctx context.Context, message Message) error
+}
+
+type Metrics interface {
+	Notification(channel string, result string)
+}
+
+const (
+	ResultSent         = "sent"
+	ResultFailed       = "failed"
+	ResultDeduplicated = "deduplicated"
+	ResultRateLimited  = "rate_limited"
+)
+
+const sendTimeout = 30 * time.Second
+
+// Router sends notifications to the channels selected by the routing rules
+type Router struct {
+	channels []*channel
+	rules    []Rule
+	limiter  *limiter
+	logger   *slog.Logger
+	metrics  Metrics
+	ctx      context.Context
+	wg       sync.WaitGroup
+}
+
+type channel struct {
+	name     string
+	notifier Notifier
+	template *messageTemplate
+}
+
+// LoadConfig reads the notification config from a json file
+func LoadConfig(location string) (config Config, err error) {
+	file, err := os.ReadFile(location)
+	if err != nil {
+		return config, err
+	}
+	err = json.Unmarshal(file, &config)
+	return config, err
+}
+
+// New creates the notifiers of config.Channels; factory creates the notifier of a channel config
+func New(ctx context.Context, config Config, factory Factory, logger *slog.Logger, metrics Metrics) (result *Router, err error) {
+	result = &Router{
+		rules:   config.Rules,
+		logger:  logger,
+		metrics: metrics,
+		ctx:     ctx,
+	}
+	result.limiter, err = newLimiter(config)
+	if err != nil {
+		return nil, err
+	}
+	known := map[string]bool{}
+	for _, channelConfig := range config.Channels {
+		if channelConfig.Name == "" {
+			return nil, errors.New("missing notification channel name")
+		}
+		if known[channelConfig.Name] {
+			return nil, fmt.Errorf("duplicate notification channel name %v", channelConfig.Name)
+		}
+		known[channelConfig.Name] = true
+		n, err := factory(ctx, channelConfig)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create notification channel %v: %w", channelConfig.Name, err)
+		}
+		t, err := newMessageTemplate(channelConfig.TitleTemplate, channelConfig.BodyTemplate)
+		if err != nil {
+			return nil, fmt.Errorf("invalid template in notification channel %v: %w", channelConfig.Name, err)
+		}
+		result.channels = append(result.channels, &channel{name: channelConfig.Name, notifier: n, template: t})
+	}
+	for _, rule := range config.Rules {
+		for _, name := range rule.Channels {
+			if !known[name] {
+				return nil, fmt.Errorf("unknown notification channel %v in rule", name)
+			}
+		}
+	}
+	return result, nil
+}
+
+// Notify sends n asynchronously to all matching channels; duplicates within the dedup window and notifications exceeding the rate limit are dropped
+func (this *Router) Notify(n Notification) {
+	if this == nil || len(this.channels) == 0 {
+		return
+	}
+	if n.Time.IsZero() {
+		n.Time = time.Now()
+	}
+	channels := this.route(n)
+	if len(channels) == 0 {
+		return
+	}
+	if this.limiter.duplicate(n) {
+		for _, c := range channels {
+			this.observe(c.name, ResultDeduplicated)
+		}
+		this.logger.Debug("drop duplicate notification", "kind", n.Kind, "network-id", n.NetworkId, "deployment-name", n.DeploymentName)
+		return
+	}
+	for _, c := range channels {
+		allowed, suppressed := this.limiter.allow(c.name, n.NetworkId)
+		if !allowed {
+			this.observe(c.name, ResultRateLimited)
+			continue
+		}
+		message, err := c.template.render(n, suppressed)
+		if err != nil {
+			this.logger.Error("unable to render notification", "error", err, "channel", c.name)
+			this.observe(c.name, ResultFailed)
+			continue
+		}
+		this.wg.Add(1)
+		go func(c *channel) {
+			defer this.wg.Done()
+			ctx, cancel := context.WithTimeout(this.ctx, sendTimeout)
+			defer cancel()
+			err := c.notifier.Send(ctx, message)
+			if err != nil {
+				this.logger.Error("unable to send notification", "snrgy-log-type", "error", "error", err.Error(), "channel", c.name, "user", n.TenantId, "network-id", n.NetworkId, "kind", n.Kind)
+				this.observe(c.name, ResultFailed)
+				return
+			}
+			this.observe(c.name, ResultSent)
+		}(c)
+	}
+}
+
+// Wait blocks until all started sends are finished
+func (this *Router) Wait() {
+	if this == nil {
+		return
+	}
+	this.wg.Wait()
+}
+
+// route returns the channels of all matching rules; without rules every channel is used
+func (this *Router) route(n Notification) (result []*channel) {
+	if len(this.rules) == 0 {
+		return this.channels
+	}
+	names := map[string]bool{}
+	for _, rule := range this.rules {
+		if rule.matches(n) {
+			for _, name := range rule.Channels {
+				names[name] = true
+			}
+		}
+	}
+	for _, c := range this.channels {
+		if names[c.name] {
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+func (this Rule) matches(n Notification) bool {
+	if len(this.NetworkIds) > 0 && !slices.Contains(this.NetworkIds, n.NetworkId) {
+		return false
+	}
+	if len(this.TenantIds) > 0 && !slices.Contains(this.TenantIds, n.TenantId) {
+		return false
+	}
+	if len(this.Kinds) > 0 && !slices.Contains(this.Kinds, n.Kind) {
+		return false
+	}
+	return true
+}
+
+func (this *Router) observe(channel string, result string) {
+	if this.metrics != nil {
+		this.metrics.Notification(channel, result)
+	}
+}
@@ -0,0 +1,162 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type recorder struct {
+	mux      sync.Mutex
+	messages []Message
+}
+
+func (this *recorder) Send(_ context.Context, message Message) error {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	this.messages = append(this.messages, message)
+	return nil
+}
+
+func (this *recorder) list() []Message {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	return append([]Message{}, this.messages...)
+}
+
+func newTestRouter(t *testing.T, config Config) (*Router, map[string]*recorder) {
+	recorders := map[string]*recorder{}
+	router, err := New(context.Background(), config, func(ctx context.Context, config ChannelConfig) (Notifier, error) {
+		recorders[config.Name] = &recorder{}
+		return recorders[config.Name], nil
+	}, slog.Default(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return router, recorders
+}
+
+func TestRouting(t *testing.T) {
+	router, recorders := newTestRouter(t, Config{
+		DedupWindow: "-",
+		Channels:    []ChannelConfig{{Name: "a"}, {Name: "b", TitleTemplate: "{{.Kind}} {{.NetworkId}}"}},
+		Rules: []Rule{
+			{NetworkIds: []string{"n1"}, Channels: []string{"a"}},
+			{TenantIds: []string{"t2"}, Kinds: []string{KindWardenGiveUp}, Channels: []string{"b"}},
+		},
+	})
+	router.Notify(Notification{Kind: KindIncident, NetworkId: "n1", TenantId: "t1", DeploymentName: "d", ErrorMessage: "err"})
+	router.Notify(Notification{Kind: KindIncident, NetworkId: "n2", TenantId: "t2"})
+	router.Notify(Notification{Kind: KindWardenGiveUp, NetworkId: "n2", TenantId: "t2", RestartCount: 3})
+	router.Wait()
+
+	a := recorders["a"].list()
+	if len(a) != 1 || a[0].Title != "Fog-Process-Incident in d" || a[0].Body != "err" {
+		t.Errorf("%#v", a)
+	}
+	b := recorders["b"].list()
+	if len(b) != 1 || b[0].Title != "warden_give_up n2" || !strings.HasPrefix(b[0].Body, "process was restarted 3 times") {
+		t.Errorf("%#v", b)
+	}
+
+	_, err := New(context.Background(), Config{Channels: []ChannelConfig{{Name: "a"}}, Rules: []Rule{{Channels: []string{"unknown"}}}}, func(ctx context.Context, config ChannelConfig) (Notifier, error) {
+		return &recorder{}, nil
+	}, slog.Default(), nil)
+	if err == nil {
+		t.Error("expected error for unknown channel")
+	}
+}
+
+func TestDedupAndRateLimit(t *testing.T) {
+	router, recorders := newTestRouter(t, Config{
+		Channels:  []ChannelConfig{{Name: "a"}},
+		RateLimit: 2,
+	})
+	now := time.Now()
+	router.limiter.now = func() time.Time { return now }
+
+	n := Notification{Kind: KindIncident, NetworkId: "n1", ErrorMessage: "err"}
+	router.Notify(n)
+	router.Notify(n)
+	for _, msg := range []string{"2", "3", "4"} {
+		router.Notify(Notification{Kind: KindIncident, NetworkId: "n1", ErrorMessage: msg})
+	}
+	router.Wait()
+	if l := recorders["a"].list(); len(l) != 2 {
+		t.Fatalf("%#v", l)
+	}
+
+	now = now.Add(2 * time.Hour)
+	router.Notify(Notification{Kind: KindIncident, NetworkId: "n1", ErrorMessage: "5"})
+	router.Wait()
+	l := recorders["a"].list()
+	if len(l) != 3 || l[2].Suppressed != 2 || !strings.Contains(l[2].Body, "2 further notifications") {
+		t.Errorf("%#v", l)
+	}
+}
+
+func TestWebhookSignature(t *testing.T) {
+	secret := "secret"
+	received := make(chan Message, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		body, _ := io.ReadAll(request.Body)
+		signature := request.Header.Get(SignatureHeader)
+		if signature != "sha256="+Sign([]byte(secret), request.Header.Get(SignatureTimestampHeader), body) {
+			http.Error(writer, "invalid signature", http.StatusUnauthorized)
+			return
+		}
+		var msg Message
+		err := json.Unmarshal(body, &msg)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		received <- msg
+	}))
+	defer server.Close()
+
+	webhook, err := NewWebhook(ChannelConfig{Url: server.URL, Secret: secret})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = webhook.Send(context.Background(), Message{Notification: Notification{Kind: KindIncident, NetworkId: "n1"}, Title: "title"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg := <-received
+	if msg.NetworkId != "n1" || msg.Title != "title" {
+		t.Errorf("%#v", msg)
+	}
+
+	webhook, err = NewWebhook(ChannelConfig{Url: server.URL, Secret: "wrong"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = webhook.Send(context.Background(), Message{})
+	if err == nil {
+		t.Error("expected error for invalid signature")
+	}
+}
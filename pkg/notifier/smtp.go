@@ -0,0 +1,91 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package notifier
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+// Smtp sends messages as plain text mails; authentication is used if smtp_user is set
+type Smtp struct {
+	addr string
+	host string
+	auth smtp.Auth
+	from string
+	to   []string
+}
+
+func NewSmtp(config ChannelConfig) (*Smtp, error) {
+	if config.SmtpHost == "" {
+		return nil, errors.New("missing smtp_host")
+	}
+	if config.From == "" {
+		return nil, errors.New("missing from")
+	}
+	if len(config.To) == 0 {
+		return nil, errors.New("missing to")
+	}
+	port := config.SmtpPort
+	if port == "" {
+		port = "587"
+	}
+	result := &Smtp{
+		addr: net.JoinHostPort(config.SmtpHost, port),
+		host: config.SmtpHost,
+		from: config.From,
+		to:   config.To,
+	}
+	if config.SmtpUser != "" {
+		result.auth = smtp.PlainAuth("", config.SmtpUser, config.SmtpPassword, config.SmtpHost)
+	}
+	return result, nil
+}
+
+func (this *Smtp) Send(ctx context.Context, message Message) error {
+	mail := this.format(message, time.Now())
+	done := make(chan error, 1)
+	go func() {
+		done <- smtp.SendMail(this.addr, this.auth, this.from, this.to, mail)
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (this *Smtp) format(message Message, now time.Time) []byte {
+	header := []string{
+		"From: " + this.from,
+		"To: " + strings.Join(this.to, ", "),
+		"Subject: " + mime.QEncoding.Encode("utf-8", message.Title),
+		"Date: " + now.Format(time.RFC1123Z),
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=utf-8",
+		"Content-Transfer-Encoding: 8bit",
+	}
+	body := strings.ReplaceAll(strings.ReplaceAll(message.Body, "\r\n", "\n"), "\n", "\r\n")
+	return []byte(fmt.Sprintf("%v\r\n\r\n%v\r\n", strings.Join(header, "\r\n"), body))
+}
@@ -0,0 +1,102 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package notifier
+
+import (
+	"bytes"
+	"text/template"
+)
+
+const suppressedSuffix = `{{if .Suppressed}}
+({{.Suppressed}} further notifications of this network were suppressed by the rate limit){{end}}`
+
+var defaultTitleTemplates = map[string]string{
+	KindIncident:     `Fog-Process-Incident in {{.DeploymentName}}`,
+	KindWardenGiveUp: `Fog-Process-Restart given up for {{.DeploymentName}}`,
+}
+
+var defaultBodyTemplates = map[string]string{
+	KindIncident: `{{.ErrorMessage}}` + suppressedSuffix,
+	KindWardenGiveUp: `process was restarted {{.RestartCount}} times after incidents and will not be restarted again
+Last Incident: {{.ErrorMessage}}` + suppressedSuffix,
+}
+
+// messageTemplate renders the title and body of a channel; nil templates use the default of the notification kind
+type messageTemplate struct {
+	title *template.Template
+	body  *template.Template
+}
+
+var defaultTemplates = map[string]*messageTemplate{}
+
+func init() {
+	for kind, title := range defaultTitleTemplates {
+		defaultTemplates[kind] = &messageTemplate{
+			title: template.Must(template.New("title").Parse(title)),
+			body:  template.Must(template.New("body").Parse(defaultBodyTemplates[kind])),
+		}
+	}
+}
+
+func newMessageTemplate(title string, body string) (result *messageTemplate, err error) {
+	result = &messageTemplate{}
+	if title != "" {
+		result.title, err = template.New("title").Parse(title)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if body != "" {
+		result.body, err = template.New("body").Parse(body)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+func (this *messageTemplate) render(n Notification, suppressed int) (result Message, err error) {
+	result = Message{Notification: n, Suppressed: suppressed}
+	title, body := this.title, this.body
+	if def, ok := defaultTemplates[n.Kind]; ok {
+		if title == nil {
+			title = def.title
+		}
+		if body == nil {
+			body = def.body
+		}
+	}
+	if title != nil {
+		result.Title, err = execute(title, result)
+		if err != nil {
+			return result, err
+		}
+	}
+	if body != nil {
+		result.Body, err = execute(body, result)
+		if err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+func execute(t *template.Template, message Message) (string, error) {
+	buf := &bytes.Buffer{}
+	err := t.Execute(buf, message)
+	return buf.String(), err
+}
@@ -0,0 +1,207 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package scheduler
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Cron is a parsed cron expression with the standard 5 fields (minute hour day-of-month month day-of-week).
+// supported field syntax: '*', 'n', 'a-b', lists separated by ',' and steps ('*/n', 'a-b/n', 'a/n').
+// months and weekdays may be given as three-letter names (JAN-DEC, SUN-SAT); 7 is accepted as sunday.
+// additionally the descriptors @yearly (@annually), @monthly, @weekly, @daily (@midnight), @hourly and @every <duration> are supported.
+type Cron struct {
+	minute, hour, dom, month, dow uint64
+	domRestricted, dowRestricted  bool
+	every                         time.Duration
+}
+
+var descriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+var monthNames = map[string]int{"JAN": 1, "FEB": 2, "MAR": 3, "APR": 4, "MAY": 5, "JUN": 6, "JUL": 7, "AUG": 8, "SEP": 9, "OCT": 10, "NOV": 11, "DEC": 12}
+var dowNames = map[string]int{"SUN": 0, "MON": 1, "TUE": 2, "WED": 3, "THU": 4, "FRI": 5, "SAT": 6}
+
+// maxSearchYears limits the search for the next matching time (e.g. for '0 0 30 2 *' which never matches)
+const maxSearchYears = 5
+
+func ParseCron(expr string) (result Cron, err error) {
+	expr = strings.TrimSpace(expr)
+	if strings.HasPrefix(expr, "@every ") {
+		result.every, err = time.ParseDuration(strings.TrimSpace(strings.TrimPrefix(expr, "@every ")))
+		if err != nil {
+			return result, err
+		}
+		if result.every < time.Minute {
+			return result, errors.New("@every duration must be at least 1m")
+		}
+		return result, nil
+	}
+	if replacement, ok := descriptors[strings.ToLower(expr)]; ok {
+		expr = replacement
+	}
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return result, fmt.Errorf("expected 5 fields in cron expression, got %v", len(fields))
+	}
+	result.minute, err = parseCronField(fields[0], 0, 59, nil)
+	if err != nil {
+		return result, fmt.Errorf("invalid minute field: %w", err)
+	}
+	result.hour, err = parseCronField(fields[1], 0, 23, nil)
+	if err != nil {
+		return result, fmt.Errorf("invalid hour field: %w", err)
+	}
+	result.dom, err = parseCronField(fields[2], 1, 31, nil)
+	if err != nil {
+		return result, fmt.Errorf("invalid day-of-month field: %w", err)
+	}
+	result.month, err = parseCronField(fields[3], 1, 12, monthNames)
+	if err != nil {
+		return result, fmt.Errorf("invalid month field: %w", err)
+	}
+	result.dow, err = parseCronField(fields[4], 0, 7, dowNames)
+	if err != nil {
+		return result, fmt.Errorf("invalid day-of-week field: %w", err)
+	}
+	if result.dow&(1<<7) != 0 {
+		result.dow = result.dow | 1
+	}
+	result.domRestricted = !strings.HasPrefix(fields[2], "*")
+	result.dowRestricted = !strings.HasPrefix(fields[4], "*")
+	return result, nil
+}
+
+func parseCronField(field string, min int, max int, names map[string]int) (result uint64, err error) {
+	for _, part := range strings.Split(field, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			step, err = strconv.Atoi(stepPart)
+			if err != nil || step < 1 {
+				return 0, fmt.Errorf("invalid step '%v'", stepPart)
+			}
+		}
+		var from, to int
+		switch {
+		case rangePart == "*":
+			from, to = min, max
+		case strings.Contains(rangePart, "-"):
+			fromStr, toStr, _ := strings.Cut(rangePart, "-")
+			from, err = parseCronValue(fromStr, min, max, names)
+			if err != nil {
+				return 0, err
+			}
+			to, err = parseCronValue(toStr, min, max, names)
+			if err != nil {
+				return 0, err
+			}
+			if from > to {
+				return 0, fmt.Errorf("invalid range '%v'", rangePart)
+			}
+		default:
+			from, err = parseCronValue(rangePart, min, max, names)
+			if err != nil {
+				return 0, err
+			}
+			to = from
+			if hasStep {
+				to = max
+			}
+		}
+		for i := from; i <= to; i += step {
+			result = result | (1 << uint(i))
+		}
+	}
+	return result, nil
+}
+
+func parseCronValue(value string, min int, max int, names map[string]int) (int, error) {
+	if names != nil {
+		if i, ok := names[strings.ToUpper(value)]; ok {
+			return i, nil
+		}
+	}
+	i, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value '%v'", value)
+	}
+	if i < min || i > max {
+		return 0, fmt.Errorf("value %v out of range [%v, %v]", i, min, max)
+	}
+	return i, nil
+}
+
+// Next returns the first matching time after the given time, evaluated in the location of after.
+// the search works on wall clock times: a time skipped by a daylight saving time change triggers at the first valid time after the gap.
+// returns the zero time if no match exists within the next years.
+func (this Cron) Next(after time.Time) time.Time {
+	if this.every > 0 {
+		return after.Truncate(time.Second).Add(this.every)
+	}
+	loc := after.Location()
+	wall := time.Date(after.Year(), after.Month(), after.Day(), after.Hour(), after.Minute()+1, 0, 0, time.UTC)
+	limit := wall.AddDate(maxSearchYears, 0, 0)
+	for wall.Before(limit) {
+		if !has(this.month, int(wall.Month())) {
+			wall = time.Date(wall.Year(), wall.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if !this.dayMatches(wall) {
+			wall = wall.AddDate(0, 0, 1).Truncate(24 * time.Hour)
+			continue
+		}
+		if !has(this.hour, wall.Hour()) {
+			wall = wall.Truncate(time.Hour).Add(time.Hour)
+			continue
+		}
+		if !has(this.minute, wall.Minute()) {
+			wall = wall.Add(time.Minute)
+			continue
+		}
+		result := time.Date(wall.Year(), wall.Month(), wall.Day(), wall.Hour(), wall.Minute(), 0, 0, loc)
+		if result.After(after) {
+			return result
+		}
+		wall = wall.Add(time.Minute)
+	}
+	return time.Time{}
+}
+
+func (this Cron) dayMatches(t time.Time) bool {
+	domMatch := has(this.dom, t.Day())
+	dowMatch := has(this.dow, int(t.Weekday()))
+	if this.domRestricted && this.dowRestricted {
+		return domMatch || dowMatch
+	}
+	return domMatch && dowMatch
+}
+
+func has(set uint64, i int) bool {
+	return set&(1<<uint(i)) != 0
+}
@@ -0,0 +1,117 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package scheduler
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/SENERGY-Platform/process-sync/pkg/model"
+)
+
+func TestCronNext(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		expr     string
+		after    time.Time
+		expected time.Time
+	}{
+		{"0 2 * * *", time.Date(2026, 1, 1, 1, 0, 0, 0, time.UTC), time.Date(2026, 1, 1, 2, 0, 0, 0, time.UTC)},
+		{"0 2 * * *", time.Date(2026, 1, 1, 2, 0, 0, 0, time.UTC), time.Date(2026, 1, 2, 2, 0, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2026, 1, 1, 10, 7, 30, 0, time.UTC), time.Date(2026, 1, 1, 10, 15, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2026, 1, 1, 10, 45, 0, 0, time.UTC), time.Date(2026, 1, 1, 11, 0, 0, 0, time.UTC)},
+		{"30 8 * * MON-FRI", time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC), time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC)},
+		{"0 0 1 * *", time.Date(2026, 1, 31, 12, 0, 0, 0, time.UTC), time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"0 0 13 * 5", time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)}, //dom or dow
+		{"0 0 * * 7", time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC), time.Date(2026, 10, 25, 0, 0, 0, 0, time.UTC)},
+		{"@daily", time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC), time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{"@every 90m", time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC), time.Date(2026, 1, 1, 13, 30, 0, 0, time.UTC)},
+		{"0 2 * * *", time.Date(2026, 3, 28, 12, 0, 0, 0, berlin), time.Date(2026, 3, 29, 3, 0, 0, 0, berlin)}, //dst gap
+		{"0 0 30 2 *", time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), time.Time{}},
+	}
+	for _, test := range tests {
+		cron, err := ParseCron(test.expr)
+		if err != nil {
+			t.Error(test.expr, err)
+			continue
+		}
+		actual := cron.Next(test.after)
+		if !actual.Equal(test.expected) {
+			t.Error(test.expr, test.after, "expected", test.expected, "got", actual)
+		}
+	}
+}
+
+func TestParseCronErrors(t *testing.T) {
+	for _, expr := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "* * * 13 *", "* * * * 8", "5-1 * * * *", "*/0 * * * *", "a * * * *", "@every 10s", "@every foo"} {
+		_, err := ParseCron(expr)
+		if err == nil {
+			t.Error("expected error for", expr)
+		}
+	}
+}
+
+func TestNextRunTimeZone(t *testing.T) {
+	next, err := NextRun(model.Schedule{Cron: "0 2 * * *", TimeZone: "Europe/Berlin"}, time.Date(2026, 7, 1, 12, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !next.Equal(time.Date(2026, 7, 2, 0, 0, 0, 0, time.UTC)) {
+		t.Error(next)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	valid := model.Schedule{DeploymentId: "d", Cron: "0 2 * * *"}
+	if err := Validate(valid); err != nil {
+		t.Error(err)
+	}
+	invalid := []model.Schedule{
+		{Cron: "0 2 * * *"},
+		{DeploymentId: "d", Cron: "0 2 * *"},
+		{DeploymentId: "d", Cron: "0 0 30 2 *"},
+		{DeploymentId: "d", Cron: "0 2 * * *", TimeZone: "Mars/Olympus"},
+		{DeploymentId: "d", Cron: "0 2 * * *", MissedRunPolicy: "always"},
+		{DeploymentId: "d", Cron: "0 2 * * *", BusinessKeyTemplate: "{{.Foo}}"},
+	}
+	for _, schedule := range invalid {
+		err := Validate(schedule)
+		if !errors.Is(err, ErrInvalidSchedule) {
+			t.Error("expected ErrInvalidSchedule", schedule, err)
+		}
+	}
+}
+
+func TestRenderBusinessKey(t *testing.T) {
+	key, err := RenderBusinessKey(model.Schedule{
+		Id:                  "sid",
+		DeploymentId:        "did",
+		TimeZone:            "Europe/Berlin",
+		BusinessKeyTemplate: `{{.DeploymentId}}_{{.Time.Format "2006-01-02T15:04"}}`,
+	}, time.Date(2026, 7, 2, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if key != "did_2026-07-02T02:00" {
+		t.Error(key)
+	}
+}
@@ -0,0 +1,239 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package scheduler
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"text/template"
+	"time"
+	_ "time/tzdata" //container images may not provide a time zone database
+
+	"github.com/SENERGY-Platform/process-sync/pkg/configuration"
+	"github.com/SENERGY-Platform/process-sync/pkg/database"
+	"github.com/SENERGY-Platform/process-sync/pkg/model"
+	"github.com/SENERGY-Platform/process-sync/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var ErrInvalidSchedule = errors.New("invalid schedule")
+
+type Controller interface {
+	ApiStartDeploymentWithValidatedParameter(ctx context.Context, networkId string, deploymentId string, request model.StartRequest) (err error, errCode int)
+}
+
+type Config struct {
+	Interval           time.Duration
+	LockDuration       time.Duration //time after which a claimed schedule may be claimed by another replica, if the run was not finished
+	MissedRunTolerance time.Duration //delay after which a planned run counts as missed
+	Logger             *slog.Logger
+}
+
+// Scheduler triggers process starts of stored model.Schedule elements.
+// every replica may run a scheduler; each due schedule is claimed by a lock in the database,
+// so that a planned run is only triggered by one replica.
+type Scheduler struct {
+	config Config
+	ctrl   Controller
+	db     database.Database
+	owner  string
+}
+
+func New(config Config, ctrl Controller, db database.Database) *Scheduler {
+	if config.Logger == nil {
+		config.Logger = slog.Default()
+	}
+	return &Scheduler{
+		config: config,
+		ctrl:   ctrl,
+		db:     db,
+		owner:  configuration.Id(),
+	}
+}
+
+func (this *Scheduler) Start(ctx context.Context) error {
+	if this.config.Interval == 0 {
+		return errors.New("invalid scheduler interval")
+	}
+	if this.config.LockDuration == 0 {
+		return errors.New("invalid scheduler lock duration")
+	}
+	ticker := time.NewTicker(this.config.Interval)
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				ticker.Stop()
+				return
+			case <-ticker.C:
+				err := this.RunDue()
+				if err != nil {
+					this.config.Logger.Error("error in scheduler loop", "error", err)
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+// RunDue triggers all schedules which are due and not claimed by other replicas
+func (this *Scheduler) RunDue() error {
+	for {
+		now := configuration.TimeNow()
+		schedule, found, err := this.db.ClaimDueSchedule(now, this.config.LockDuration, this.owner)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return nil
+		}
+		this.run(schedule, now)
+	}
+}
+
+func (this *Scheduler) run(schedule model.Schedule, now time.Time) {
+	run := model.ScheduleRun{
+		Time:       now,
+		PlannedFor: schedule.NextRun,
+	}
+	missed := now.Sub(schedule.NextRun) > this.config.MissedRunTolerance
+	if missed && schedule.MissedRunPolicy != model.ScheduleMissedRunOnce {
+		run.Status = model.ScheduleRunStatusSkipped
+		this.config.Logger.Warn("skip missed scheduled run", "schedule-id", schedule.Id, "network-id", schedule.NetworkId, "planned-for", schedule.NextRun.String())
+	} else {
+		err := this.start(schedule, &run)
+		if err != nil {
+			run.Status = model.ScheduleRunStatusFailed
+			run.Error = err.Error()
+			this.config.Logger.Error("unable to start scheduled process", "error", err, "schedule-id", schedule.Id, "network-id", schedule.NetworkId, "deployment-id", schedule.DeploymentId)
+		} else {
+			run.Status = model.ScheduleRunStatusStarted
+		}
+	}
+
+	next, err := NextRun(schedule, now)
+	if err != nil {
+		//schedules are validated on creation; retry later instead of claiming the schedule in every loop
+		this.config.Logger.Error("unable to compute next scheduled run", "error", err, "schedule-id", schedule.Id, "network-id", schedule.NetworkId)
+		next = now.Add(time.Hour)
+		if run.Error != "" {
+			run.Error = run.Error + "; "
+		}
+		run.Error = run.Error + err.Error()
+	}
+	err = this.db.FinishScheduleRun(schedule.NetworkId, schedule.Id, this.owner, next, run)
+	if err != nil {
+		this.config.Logger.Error("unable to store scheduled run", "error", err, "schedule-id", schedule.Id, "network-id", schedule.NetworkId)
+	}
+}
+
+func (this *Scheduler) start(schedule model.Schedule, run *model.ScheduleRun) error {
+	businessKey, err := RenderBusinessKey(schedule, schedule.NextRun)
+	if err != nil {
+		return err
+	}
+	run.BusinessKey = businessKey
+	ctx, span := tracing.Start(context.Background(), "Scheduler.start", attribute.String("network.id", schedule.NetworkId), attribute.String("schedule.id", schedule.Id))
+	defer func() {
+		tracing.End(span, err)
+	}()
+	err, _ = this.ctrl.ApiStartDeploymentWithValidatedParameter(ctx, schedule.NetworkId, schedule.DeploymentId, model.StartRequest{
+		BusinessKey: businessKey,
+		Parameter:   schedule.Parameter,
+	})
+	return err
+}
+
+// Validate checks the schedule fields and returns errors wrapping ErrInvalidSchedule
+func Validate(schedule model.Schedule) error {
+	if schedule.DeploymentId == "" {
+		return fmt.Errorf("%w: missing deployment_id", ErrInvalidSchedule)
+	}
+	switch schedule.MissedRunPolicy {
+	case "", model.ScheduleMissedRunSkip, model.ScheduleMissedRunOnce:
+	default:
+		return fmt.Errorf("%w: unknown missed_run_policy '%v'", ErrInvalidSchedule, schedule.MissedRunPolicy)
+	}
+	_, err := RenderBusinessKey(schedule, time.Now())
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidSchedule, err)
+	}
+	_, err = NextRun(schedule, time.Now())
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidSchedule, err)
+	}
+	return nil
+}
+
+// NextRun returns the first planned run of the schedule after the given time
+func NextRun(schedule model.Schedule, after time.Time) (time.Time, error) {
+	cron, err := ParseCron(schedule.Cron)
+	if err != nil {
+		return time.Time{}, err
+	}
+	loc := time.UTC
+	if schedule.TimeZone != "" {
+		loc, err = time.LoadLocation(schedule.TimeZone)
+		if err != nil {
+			return time.Time{}, err
+		}
+	}
+	next := cron.Next(after.In(loc))
+	if next.IsZero() {
+		return next, fmt.Errorf("cron expression '%v' has no upcoming run", schedule.Cron)
+	}
+	return next.UTC(), nil
+}
+
+type BusinessKeyTemplateData struct {
+	ScheduleId   string
+	NetworkId    string
+	DeploymentId string
+	Time         time.Time
+}
+
+// RenderBusinessKey executes the BusinessKeyTemplate of the schedule; an empty template results in an empty business key
+func RenderBusinessKey(schedule model.Schedule, plannedFor time.Time) (string, error) {
+	if schedule.BusinessKeyTemplate == "" {
+		return "", nil
+	}
+	tmpl, err := template.New("business_key").Option("missingkey=error").Parse(schedule.BusinessKeyTemplate)
+	if err != nil {
+		return "", err
+	}
+	loc := time.UTC
+	if schedule.TimeZone != "" {
+		loc, err = time.LoadLocation(schedule.TimeZone)
+		if err != nil {
+			return "", err
+		}
+	}
+	buf := bytes.Buffer{}
+	err = tmpl.Execute(&buf, BusinessKeyTemplateData{
+		ScheduleId:   schedule.Id,
+		NetworkId:    schedule.NetworkId,
+		DeploymentId: schedule.DeploymentId,
+		Time:         plannedFor.In(loc),
+	})
+	if err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
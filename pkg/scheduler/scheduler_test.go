@@ -0,0 +1,166 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SENERGY-Platform/process-sync/pkg/configuration"
+	"github.com/SENERGY-Platform/process-sync/pkg/database"
+	"github.com/SENERGY-Platform/process-sync/pkg/model"
+)
+
+// scheduleDb keeps schedules in memory and locks them like database.Database.ClaimDueSchedule
+type scheduleDb struct {
+	database.Database
+	mux       sync.Mutex
+	schedules map[string]model.Schedule
+}
+
+func (this *scheduleDb) ClaimDueSchedule(now time.Time, lockDuration time.Duration, owner string) (schedule model.Schedule, found bool, err error) {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	for id, schedule := range this.schedules {
+		if !schedule.Disabled && !schedule.NextRun.After(now) && schedule.LockedUntil.Before(now) {
+			schedule.LockedUntil = now.Add(lockDuration)
+			schedule.LockOwner = owner
+			this.schedules[id] = schedule
+			return schedule, true, nil
+		}
+	}
+	return schedule, false, nil
+}
+
+func (this *scheduleDb) FinishScheduleRun(networkId string, id string, owner string, nextRun time.Time, run model.ScheduleRun) error {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	schedule, ok := this.schedules[id]
+	if !ok || schedule.LockOwner != owner {
+		return nil
+	}
+	schedule.NextRun = nextRun
+	schedule.LastRun = &run
+	schedule.LockedUntil = time.Time{}
+	schedule.LockOwner = ""
+	this.schedules[id] = schedule
+	return nil
+}
+
+type startCtrl struct {
+	mux    sync.Mutex
+	starts []model.StartRequest
+}
+
+func (this *startCtrl) ApiStartDeploymentWithValidatedParameter(ctx context.Context, networkId string, deploymentId string, request model.StartRequest) (err error, errCode int) {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	this.starts = append(this.starts, request)
+	return nil, 200
+}
+
+func TestRunDueMissedRuns(t *testing.T) {
+	now := time.Date(2026, 10, 19, 10, 5, 0, 0, time.UTC)
+	configuration.TimeNow = func() time.Time { return now }
+	defer func() { configuration.TimeNow = time.Now }()
+
+	plannedFor := time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name           string
+		tolerance      time.Duration
+		policy         string
+		expectedStatus string
+	}{
+		{name: "in tolerance", tolerance: 10 * time.Minute, expectedStatus: model.ScheduleRunStatusStarted},
+		{name: "missed skip", tolerance: time.Minute, expectedStatus: model.ScheduleRunStatusSkipped},
+		{name: "missed run once", tolerance: time.Minute, policy: model.ScheduleMissedRunOnce, expectedStatus: model.ScheduleRunStatusStarted},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db := &scheduleDb{schedules: map[string]model.Schedule{"s1": {
+				Id:                  "s1",
+				NetworkId:           "n1",
+				DeploymentId:        "d1",
+				Cron:                "*/10 * * * *",
+				BusinessKeyTemplate: "{{.ScheduleId}}-{{.Time.Format \"1504\"}}",
+				MissedRunPolicy:     test.policy,
+				NextRun:             plannedFor,
+			}}}
+			ctrl := &startCtrl{}
+			err := New(Config{LockDuration: time.Minute, MissedRunTolerance: test.tolerance}, ctrl, db).RunDue()
+			if err != nil {
+				t.Fatal(err)
+			}
+			schedule := db.schedules["s1"]
+			if schedule.LastRun == nil || schedule.LastRun.Status != test.expectedStatus || !schedule.LastRun.PlannedFor.Equal(plannedFor) {
+				t.Fatal(schedule.LastRun)
+			}
+			if !schedule.NextRun.Equal(time.Date(2026, 10, 19, 10, 10, 0, 0, time.UTC)) {
+				t.Error("expect a single run for all missed runs and the next run after now", schedule.NextRun)
+			}
+			if schedule.LockOwner != "" {
+				t.Error("expect released lock", schedule.LockOwner)
+			}
+			expectedStarts := 0
+			if test.expectedStatus == model.ScheduleRunStatusStarted {
+				expectedStarts = 1
+			}
+			if len(ctrl.starts) != expectedStarts {
+				t.Fatal(ctrl.starts)
+			}
+			if expectedStarts == 1 && ctrl.starts[0].BusinessKey != "s1-1000" {
+				t.Error(ctrl.starts[0].BusinessKey)
+			}
+		})
+	}
+}
+
+func TestRunDueLockedByOtherReplica(t *testing.T) {
+	now := time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)
+	configuration.TimeNow = func() time.Time { return now }
+	defer func() { configuration.TimeNow = time.Now }()
+
+	db := &scheduleDb{schedules: map[string]model.Schedule{"s1": {
+		Id:           "s1",
+		NetworkId:    "n1",
+		DeploymentId: "d1",
+		Cron:         "*/10 * * * *",
+		NextRun:      now,
+		LockedUntil:  now.Add(time.Minute),
+		LockOwner:    "other",
+	}}}
+	ctrl := &startCtrl{}
+	scheduler := New(Config{LockDuration: time.Minute, MissedRunTolerance: time.Hour}, ctrl, db)
+	err := scheduler.RunDue()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ctrl.starts) != 0 {
+		t.Error("expect no start while another replica holds the lock", ctrl.starts)
+	}
+
+	now = now.Add(2 * time.Minute)
+	err = scheduler.RunDue()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ctrl.starts) != 1 {
+		t.Error("expect take over of the expired lock", ctrl.starts)
+	}
+}
@@ -0,0 +1,182 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package security
+
+import (
+	"sync"
+	"time"
+
+	"github.com/SENERGY-Platform/process-sync/pkg/configuration"
+	"github.com/SENERGY-Platform/process-sync/pkg/metrics"
+)
+
+// permissionCache caches the results of permission checks per (user, kind, id, rights);
+// concurrent checks of the same key share a single request to permissions-v2
+type permissionCache struct {
+	duration         time.Duration
+	negativeDuration time.Duration //denied checks are not cached if <= 0
+	metrics          *metrics.Metrics
+
+	mux        sync.Mutex
+	entries    map[permissionCacheKey]permissionCacheEntry
+	calls      map[permissionCacheKey]*permissionCacheCall
+	generation uint64 //incremented by every invalidation; results of calls started before an invalidation are not cached
+	lastSweep  time.Time
+}
+
+type permissionCacheKey struct {
+	user   string
+	kind   string
+	id     string
+	rights string
+}
+
+type permissionCacheEntry struct {
+	allowed bool
+	expires time.Time
+}
+
+type permissionCacheCall struct {
+	done       chan struct{}
+	generation uint64
+	allowed    bool
+	err        error
+}
+
+func newPermissionCache(duration time.Duration, negativeDuration time.Duration, m *metrics.Metrics) *permissionCache {
+	return &permissionCache{
+		duration:         duration,
+		negativeDuration: negativeDuration,
+		metrics:          m,
+		entries:          map[permissionCacheKey]permissionCacheEntry{},
+		calls:            map[permissionCacheKey]*permissionCacheCall{},
+	}
+}
+
+// get returns the permissions of user for the ids; ids without cached result or running check are passed to load in a single call
+func (this *permissionCache) get(user string, kind string, ids []string, rights string, load func(ids []string) (map[string]bool, error)) (result map[string]bool, err error) {
+	result = map[string]bool{}
+	waiting := map[string]*permissionCacheCall{}
+	loading := map[string]*permissionCacheCall{}
+	missing := []string{}
+
+	now := configuration.TimeNow()
+	this.mux.Lock()
+	for _, id := range ids {
+		key := permissionCacheKey{user: user, kind: kind, id: id, rights: rights}
+		if entry, ok := this.entries[key]; ok && now.Before(entry.expires) {
+			result[id] = entry.allowed
+			continue
+		}
+		if call, ok := this.calls[key]; ok {
+			waiting[id] = call
+			continue
+		}
+		if _, ok := loading[id]; ok {
+			continue //duplicate id
+		}
+		call := &permissionCacheCall{done: make(chan struct{}), generation: this.generation}
+		this.calls[key] = call
+		loading[id] = call
+		missing = append(missing, id)
+	}
+	this.mux.Unlock()
+	this.metrics.PermissionCache("hit", len(ids)-len(missing))
+	this.metrics.PermissionCache("miss", len(missing))
+
+	if len(missing) > 0 {
+		loaded, loadErr := load(missing)
+		now = configuration.TimeNow()
+		this.mux.Lock()
+		for id, call := range loading {
+			key := permissionCacheKey{user: user, kind: kind, id: id, rights: rights}
+			call.allowed, call.err = loaded[id], loadErr
+			if this.calls[key] == call {
+				delete(this.calls, key)
+			}
+			if loadErr == nil && call.generation == this.generation {
+				this.store(key, call.allowed, now)
+			}
+			close(call.done)
+		}
+		this.sweep(now)
+		this.mux.Unlock()
+		if loadErr != nil {
+			return nil, loadErr
+		}
+		for id, call := range loading {
+			result[id] = call.allowed
+		}
+	}
+
+	for id, call := range waiting {
+		<-call.done
+		if call.err != nil {
+			return nil, call.err
+		}
+		result[id] = call.allowed
+	}
+	return result, nil
+}
+
+// store expects a locked mux
+func (this *permissionCache) store(key permissionCacheKey, allowed bool, now time.Time) {
+	duration := this.duration
+	if !allowed {
+		duration = this.negativeDuration
+	}
+	if duration <= 0 {
+		return
+	}
+	this.entries[key] = permissionCacheEntry{allowed: allowed, expires: now.Add(duration)}
+}
+
+// sweep removes expired entries at most once per cache duration; expects a locked mux
+func (this *permissionCache) sweep(now time.Time) {
+	if now.Sub(this.lastSweep) < this.duration {
+		return
+	}
+	this.lastSweep = now
+	for key, entry := range this.entries {
+		if !now.Before(entry.expires) {
+			delete(this.entries, key)
+		}
+	}
+}
+
+// invalidate removes the cached results for id; an empty id removes all cached results;
+// running checks are detached so that later checks don't wait for possibly outdated results
+func (this *permissionCache) invalidate(id string) {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	this.generation++
+	if id == "" {
+		this.entries = map[permissionCacheKey]permissionCacheEntry{}
+		this.calls = map[permissionCacheKey]*permissionCacheCall{}
+		return
+	}
+	for key := range this.entries {
+		if key.id == id {
+			delete(this.entries, key)
+		}
+	}
+	for key := range this.calls {
+		if key.id == id {
+			delete(this.calls, key)
+		}
+	}
+}
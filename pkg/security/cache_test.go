@@ -0,0 +1,182 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package security
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/SENERGY-Platform/process-sync/pkg/configuration"
+	"github.com/golang-jwt/jwt"
+)
+
+func TestPermissionCache(t *testing.T) {
+	now := time.Now()
+	configuration.TimeNow = func() time.Time { return now }
+	defer func() { configuration.TimeNow = time.Now }()
+
+	loads := [][]string{}
+	permissions := map[string]bool{"a": true, "b": false, "c": true}
+	load := func(ids []string) (map[string]bool, error) {
+		loads = append(loads, ids)
+		result := map[string]bool{}
+		for _, id := range ids {
+			result[id] = permissions[id]
+		}
+		return result, nil
+	}
+	cache := newPermissionCache(time.Minute, time.Second, nil)
+
+	result, err := cache.get("u1", "hubs", []string{"a", "b"}, "r", load)
+	if err != nil || !result["a"] || result["b"] {
+		t.Error(result, err)
+	}
+	result, err = cache.get("u1", "hubs", []string{"a", "b", "c"}, "r", load)
+	if err != nil || !result["a"] || result["b"] || !result["c"] {
+		t.Error(result, err)
+	}
+	if len(loads) != 2 || len(loads[1]) != 1 || loads[1][0] != "c" {
+		t.Error("expected cached a and b", loads)
+	}
+
+	t.Run("keyed by user and rights", func(t *testing.T) {
+		loads = nil
+		_, _ = cache.get("u2", "hubs", []string{"a"}, "r", load)
+		_, _ = cache.get("u1", "hubs", []string{"a"}, "rx", load)
+		if len(loads) != 2 {
+			t.Error(loads)
+		}
+	})
+
+	t.Run("negative duration", func(t *testing.T) {
+		loads = nil
+		now = now.Add(2 * time.Second)
+		permissions["b"] = true
+		result, _ = cache.get("u1", "hubs", []string{"a", "b"}, "r", load)
+		if !result["a"] || !result["b"] || len(loads) != 1 || len(loads[0]) != 1 || loads[0][0] != "b" {
+			t.Error(result, loads)
+		}
+	})
+
+	t.Run("invalidate", func(t *testing.T) {
+		loads = nil
+		permissions["a"] = false
+		cache.invalidate("a")
+		result, _ = cache.get("u1", "hubs", []string{"a", "c"}, "r", load)
+		if result["a"] || !result["c"] || len(loads) != 1 || len(loads[0]) != 1 || loads[0][0] != "a" {
+			t.Error(result, loads)
+		}
+		cache.invalidate("")
+		_, _ = cache.get("u1", "hubs", []string{"a", "c"}, "r", load)
+		if len(loads) != 2 || len(loads[1]) != 2 {
+			t.Error(loads)
+		}
+	})
+
+	t.Run("expiration", func(t *testing.T) {
+		loads = nil
+		now = now.Add(time.Minute)
+		_, _ = cache.get("u1", "hubs", []string{"c"}, "r", load)
+		if len(loads) != 1 {
+			t.Error(loads)
+		}
+		if len(cache.entries) != 1 {
+			t.Error("expected sweep of expired entries", len(cache.entries))
+		}
+	})
+}
+
+func TestPermissionCacheSingleflight(t *testing.T) {
+	cache := newPermissionCache(time.Minute, 0, nil)
+	release := make(chan struct{})
+	calls := atomic.Int64{}
+	load := func(ids []string) (map[string]bool, error) {
+		calls.Add(1)
+		<-release
+		return map[string]bool{"a": false}, nil
+	}
+	wg := sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result, err := cache.get("u1", "hubs", []string{"a"}, "r", load)
+			if err != nil || result["a"] {
+				t.Error(result, err)
+			}
+		}()
+	}
+	time.Sleep(100 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	if calls.Load() != 1 {
+		t.Error(calls.Load())
+	}
+	_, _ = cache.get("u1", "hubs", []string{"a"}, "r", load)
+	if calls.Load() != 2 {
+		t.Error("denied results should not be cached without negative duration", calls.Load())
+	}
+}
+
+func TestSecurityPermissionCache(t *testing.T) {
+	requests := atomic.Int64{}
+	permissions := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		requests.Add(1)
+		access := map[string]bool{"n1": true, "n2": false}
+		if id, ok := strings.CutPrefix(request.URL.Path, "/check/hubs/"); ok {
+			_ = json.NewEncoder(writer).Encode(access[id])
+			return
+		}
+		_ = json.NewEncoder(writer).Encode(access)
+	}))
+	defer permissions.Close()
+
+	if New(configuration.Config{AuthJwksUrl: "-", PermissionsV2Url: permissions.URL, PermissionCacheDuration: "1m"}).cache != nil {
+		t.Error("permission cache must not be enabled without token validation")
+	}
+
+	jwksServer := newTestJwksServer(t)
+	key := jwksServer.rotate(t, "k1")
+	security := New(configuration.Config{AuthJwksUrl: jwksServer.URL, PermissionsV2Url: permissions.URL, PermissionCacheDuration: "1m", PermissionCacheNegativeDuration: "1m"})
+	token := signTestToken(t, key, "k1", jwt.MapClaims{"sub": "u1", "roles": []string{"user"}, "exp": time.Now().Add(time.Minute).Unix()})
+
+	for i := 0; i < 3; i++ {
+		allowed, err := security.CheckBool(context.Background(), token, "hubs", "n1", "r")
+		if err != nil || !allowed {
+			t.Error(allowed, err)
+		}
+		result, err := security.CheckMultiple(context.Background(), token, "hubs", []string{"n1", "n2"}, "r")
+		if err != nil || !result["n1"] || result["n2"] {
+			t.Error(result, err)
+		}
+	}
+	if requests.Load() != 2 {
+		t.Error(requests.Load())
+	}
+	security.InvalidatePermissions("n2")
+	_, _ = security.CheckMultiple(context.Background(), token, "hubs", []string{"n1", "n2"}, "r")
+	if requests.Load() != 3 {
+		t.Error(requests.Load())
+	}
+}
@@ -0,0 +1,74 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package security
+
+import (
+	"context"
+)
+
+// Claims are the parts of a token used by this service; they are verified if a jwks is configured
+type Claims struct {
+	Token  string
+	UserId string
+	Roles  []string
+
+	readErr error //error of valid tokens without readable roles, which is reported by each permission check
+}
+
+type claimsHolderKey struct{}
+
+// claimsHolder is added to the request context before the auth middleware runs,
+// so that middlewares wrapping the auth middleware (e.g. audit) see the verified claims
+type claimsHolder struct {
+	claims *Claims
+}
+
+// ContextWithClaimsHolder prepares ctx for SetContextClaims; contexts which already contain a holder are returned unchanged
+func ContextWithClaimsHolder(ctx context.Context) context.Context {
+	if _, ok := ctx.Value(claimsHolderKey{}).(*claimsHolder); ok {
+		return ctx
+	}
+	return context.WithValue(ctx, claimsHolderKey{}, &claimsHolder{})
+}
+
+// SetContextClaims stores the verified claims in the holder of ctx; returns false if ctx has no holder
+func SetContextClaims(ctx context.Context, claims Claims) bool {
+	holder, ok := ctx.Value(claimsHolderKey{}).(*claimsHolder)
+	if !ok {
+		return false
+	}
+	holder.claims = &claims
+	return true
+}
+
+// ContextClaims returns the claims stored by SetContextClaims, if they belong to token
+func ContextClaims(ctx context.Context, token string) (claims Claims, ok bool) {
+	holder, ok := ctx.Value(claimsHolderKey{}).(*claimsHolder)
+	if !ok || holder.claims == nil || holder.claims.Token != token {
+		return claims, false
+	}
+	return *holder.claims, true
+}
+
+// ContextUserId returns the user id of the verified claims in ctx; without claims, it falls back to the (unverified) subject of token
+func ContextUserId(ctx context.Context, token string) string {
+	if claims, ok := ContextClaims(ctx, token); ok {
+		return claims.UserId
+	}
+	userId, _ := ReadTokenUserId(token)
+	return userId
+}
@@ -0,0 +1,234 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package security
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/SENERGY-Platform/process-sync/pkg/configuration"
+	"github.com/golang-jwt/jwt"
+)
+
+var ErrInvalidToken = errors.New("invalid token")
+
+const defaultJwksCacheDuration = time.Hour
+
+// jwksMinRefreshInterval limits refetches caused by tokens with unknown key ids
+const jwksMinRefreshInterval = 10 * time.Second
+
+// tokenLeeway tolerates clock skew between the auth server and this service
+const tokenLeeway = 30 * time.Second
+
+var validTokenMethods = []string{"RS256", "RS384", "RS512", "ES256", "ES384", "ES512"}
+
+// Jwks validates token signatures with the keys of a JSON Web Key Set; keys are cached and refetched if a token references an unknown key (key rotation)
+type Jwks struct {
+	url           string
+	cacheDuration time.Duration
+	client        *http.Client
+	logger        *slog.Logger
+
+	mux       sync.Mutex //never held while fetching
+	keys      map[string]interface{}
+	fetchedAt time.Time
+	refresh   *jwksRefresh //running fetch, shared by concurrent callers
+}
+
+type jwksRefresh struct {
+	done chan struct{}
+	keys map[string]interface{}
+	err  error
+}
+
+func NewJwks(url string, cacheDuration time.Duration, logger *slog.Logger) *Jwks {
+	return &Jwks{url: url, cacheDuration: cacheDuration, client: &http.Client{Timeout: 10 * time.Second}, logger: logger}
+}
+
+// ValidateToken checks signature, expiration and not-before of the token ('Bearer <jwt>') and returns its claims
+func (this *Jwks) ValidateToken(token string) (claims jwt.MapClaims, err error) {
+	tokenType, jwtString, found := strings.Cut(token, " ")
+	if !found || !strings.EqualFold(tokenType, "bearer") {
+		return nil, fmt.Errorf("%w: expect auth string format like 'Bearer <token>'", ErrInvalidToken)
+	}
+	claims = jwt.MapClaims{}
+	parser := jwt.Parser{ValidMethods: validTokenMethods, SkipClaimsValidation: true}
+	_, err = parser.ParseWithClaims(jwtString, claims, this.key)
+	if err != nil {
+		return nil, errors.Join(ErrInvalidToken, err)
+	}
+	now := configuration.TimeNow()
+	if !claims.VerifyExpiresAt(now.Add(-tokenLeeway).Unix(), true) {
+		return nil, fmt.Errorf("%w: token is expired", ErrInvalidToken)
+	}
+	if !claims.VerifyNotBefore(now.Add(tokenLeeway).Unix(), false) {
+		return nil, fmt.Errorf("%w: token is not valid yet", ErrInvalidToken)
+	}
+	return claims, nil
+}
+
+func (this *Jwks) key(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	this.mux.Lock()
+	keys, fetchedAt := this.keys, this.fetchedAt
+	this.mux.Unlock()
+	key, found := keys[kid]
+	expired := configuration.TimeNow().Sub(fetchedAt) > this.cacheDuration
+	if (!found || expired) && configuration.TimeNow().Sub(fetchedAt) > jwksMinRefreshInterval {
+		keys, err := this.refreshKeys()
+		if err != nil {
+			this.logger.Warn("unable to fetch jwks", "error", err, "url", this.url)
+		} else {
+			key, found = keys[kid]
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("unknown key %q", kid)
+	}
+	return key, nil
+}
+
+// refreshKeys fetches the keys once for all concurrent callers and swaps the cached key map;
+// callers arriving after a refresh finished within jwksMinRefreshInterval get the new keys without fetching again
+func (this *Jwks) refreshKeys() (keys map[string]interface{}, err error) {
+	this.mux.Lock()
+	if configuration.TimeNow().Sub(this.fetchedAt) <= jwksMinRefreshInterval {
+		keys = this.keys
+		this.mux.Unlock()
+		return keys, nil
+	}
+	refresh := this.refresh
+	if refresh != nil {
+		this.mux.Unlock()
+		<-refresh.done
+		return refresh.keys, refresh.err
+	}
+	refresh = &jwksRefresh{done: make(chan struct{})}
+	this.refresh = refresh
+	this.mux.Unlock()
+
+	refresh.keys, refresh.err = this.fetch()
+
+	this.mux.Lock()
+	if refresh.err == nil {
+		this.keys = refresh.keys
+		this.fetchedAt = configuration.TimeNow()
+	}
+	this.refresh = nil
+	this.mux.Unlock()
+	close(refresh.done)
+	return refresh.keys, refresh.err
+}
+
+type jsonWebKey struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+func (this *Jwks) fetch() (keys map[string]interface{}, err error) {
+	resp, err := this.client.Get(this.url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected jwks response status %v", resp.StatusCode)
+	}
+	set := struct {
+		Keys []jsonWebKey `json:"keys"`
+	}{}
+	err = json.NewDecoder(resp.Body).Decode(&set)
+	if err != nil {
+		return nil, err
+	}
+	keys = map[string]interface{}{}
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := jwk.publicKey()
+		if err != nil {
+			this.logger.Warn("ignore unusable jwk", "error", err, "kid", jwk.Kid)
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+	return keys, nil
+}
+
+func (this jsonWebKey) publicKey() (interface{}, error) {
+	switch this.Kty {
+	case "RSA":
+		n, err := decodeBigInt(this.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(this.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch this.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", this.Crv)
+		}
+		x, err := decodeBigInt(this.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(this.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", this.Kty)
+	}
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(b), nil
+}
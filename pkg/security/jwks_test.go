@@ -0,0 +1,268 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package security
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/SENERGY-Platform/process-sync/pkg/configuration"
+	"github.com/golang-jwt/jwt"
+)
+
+type testJwksServer struct {
+	*httptest.Server
+	mux      sync.Mutex
+	keys     map[string]*rsa.PrivateKey
+	requests atomic.Int64
+}
+
+func newTestJwksServer(t *testing.T) *testJwksServer {
+	result := &testJwksServer{keys: map[string]*rsa.PrivateKey{}}
+	result.Server = httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		result.requests.Add(1)
+		result.mux.Lock()
+		defer result.mux.Unlock()
+		keys := []map[string]string{}
+		for kid, key := range result.keys {
+			keys = append(keys, map[string]string{
+				"kid": kid,
+				"kty": "RSA",
+				"alg": "RS256",
+				"use": "sig",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			})
+		}
+		_ = json.NewEncoder(writer).Encode(map[string]interface{}{"keys": keys})
+	}))
+	t.Cleanup(result.Close)
+	return result
+}
+
+func (this *testJwksServer) rotate(t *testing.T, kid string) *rsa.PrivateKey {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	this.keys = map[string]*rsa.PrivateKey{kid: key}
+	return key
+}
+
+func signTestToken(t *testing.T, key *rsa.PrivateKey, kid string, claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = kid
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return "Bearer " + signed
+}
+
+func TestJwksValidateToken(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	configuration.TimeNow = func() time.Time { return now }
+	defer func() { configuration.TimeNow = time.Now }()
+
+	server := newTestJwksServer(t)
+	key := server.rotate(t, "k1")
+	config := configuration.Config{}
+	jwks := NewJwks(server.URL, time.Hour, config.GetLogger())
+
+	claims := jwt.MapClaims{"sub": "user", "roles": []string{"user"}, "exp": now.Add(time.Minute).Unix()}
+	result, err := jwks.ValidateToken(signTestToken(t, key, "k1", claims))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result["sub"] != "user" {
+		t.Error(result)
+	}
+
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hmacToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte("secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	invalid := map[string]string{
+		"expired":         signTestToken(t, key, "k1", jwt.MapClaims{"exp": now.Add(-time.Hour).Unix()}),
+		"missing exp":     signTestToken(t, key, "k1", jwt.MapClaims{"sub": "user"}),
+		"not before":      signTestToken(t, key, "k1", jwt.MapClaims{"exp": now.Add(2 * time.Hour).Unix(), "nbf": now.Add(time.Hour).Unix()}),
+		"wrong signature": signTestToken(t, otherKey, "k1", claims),
+		"hmac":            "Bearer " + hmacToken,
+		"missing bearer":  signTestToken(t, key, "k1", claims)[len("Bearer "):],
+		"empty":           "",
+	}
+	for name, token := range invalid {
+		_, err = jwks.ValidateToken(token)
+		if !errors.Is(err, ErrInvalidToken) {
+			t.Error(name, err)
+		}
+	}
+	if requests := server.requests.Load(); requests != 1 {
+		t.Error("keys should be cached", requests)
+	}
+
+	//rotation: tokens with unknown key ids trigger a refetch, limited by jwksMinRefreshInterval
+	newKey := server.rotate(t, "k2")
+	_, err = jwks.ValidateToken(signTestToken(t, newKey, "k2", claims))
+	if !errors.Is(err, ErrInvalidToken) {
+		t.Error("refetch should wait for jwksMinRefreshInterval", err)
+	}
+	now = now.Add(jwksMinRefreshInterval + time.Second)
+	claims["exp"] = now.Add(time.Minute).Unix()
+	_, err = jwks.ValidateToken(signTestToken(t, newKey, "k2", claims))
+	if err != nil {
+		t.Error(err)
+	}
+	_, err = jwks.ValidateToken(signTestToken(t, key, "k1", claims))
+	if !errors.Is(err, ErrInvalidToken) {
+		t.Error("rotated key should be rejected", err)
+	}
+	if requests := server.requests.Load(); requests != 2 {
+		t.Error(requests)
+	}
+}
+
+func TestSecurityRejectsInvalidTokensBeforePermissionCheck(t *testing.T) {
+	now := time.Now()
+	server := newTestJwksServer(t)
+	key := server.rotate(t, "k1")
+
+	permissionCalls := atomic.Int64{}
+	permissions := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		permissionCalls.Add(1)
+		_ = json.NewEncoder(writer).Encode(map[string]bool{"n1": true})
+	}))
+	defer permissions.Close()
+
+	security := New(configuration.Config{AuthJwksUrl: server.URL, PermissionsV2Url: permissions.URL})
+
+	admin := signTestToken(t, key, "k1", jwt.MapClaims{"sub": "admin", "roles": []string{"admin"}, "exp": now.Add(time.Minute).Unix()})
+	if !security.IsAdmin(context.Background(), admin) {
+		t.Error("expected admin")
+	}
+	allowed, err := security.CheckBool(context.Background(), admin, "hubs", "n1", "r")
+	if err != nil || !allowed {
+		t.Error(allowed, err)
+	}
+
+	forged := signTestToken(t, key, "k1", jwt.MapClaims{"sub": "admin", "roles": []string{"admin"}, "exp": now.Add(-time.Hour).Unix()})
+	if security.IsAdmin(context.Background(), forged) {
+		t.Error("expired token must not be admin")
+	}
+	_, err = security.CheckBool(context.Background(), forged, "hubs", "n1", "r")
+	if !errors.Is(err, ErrInvalidToken) {
+		t.Error(err)
+	}
+	_, err = security.CheckMultiple(context.Background(), forged, "hubs", []string{"n1"}, "r")
+	if !errors.Is(err, ErrInvalidToken) {
+		t.Error(err)
+	}
+	if _, err = security.VerifyToken(forged); !errors.Is(err, ErrInvalidToken) {
+		t.Error(err)
+	}
+	if calls := permissionCalls.Load(); calls != 0 {
+		t.Error("unexpected permission calls", calls)
+	}
+}
+
+func TestJwksConcurrentRefresh(t *testing.T) {
+	server := newTestJwksServer(t)
+	key := server.rotate(t, "k1")
+	config := configuration.Config{}
+	jwks := NewJwks(server.URL, time.Hour, config.GetLogger())
+	token := signTestToken(t, key, "k1", jwt.MapClaims{"sub": "user", "exp": time.Now().Add(time.Minute).Unix()})
+
+	server.mux.Lock() //blocks the jwks response
+	wg := sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := jwks.ValidateToken(token)
+			if err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	for server.requests.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	if !jwks.mux.TryLock() {
+		t.Error("mux should not be held while fetching")
+	} else {
+		jwks.mux.Unlock()
+	}
+	time.Sleep(50 * time.Millisecond)
+	server.mux.Unlock()
+	wg.Wait()
+	if requests := server.requests.Load(); requests != 1 {
+		t.Error("concurrent refreshes should share one fetch", requests)
+	}
+}
+
+func TestSecurityUsesVerifiedContextClaims(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	configuration.TimeNow = func() time.Time { return now }
+	defer func() { configuration.TimeNow = time.Now }()
+
+	server := newTestJwksServer(t)
+	key := server.rotate(t, "k1")
+	security := New(configuration.Config{AuthJwksUrl: server.URL})
+	admin := signTestToken(t, key, "k1", jwt.MapClaims{"sub": "admin", "roles": []string{"admin"}, "exp": now.Add(time.Minute).Unix()})
+
+	claims, err := security.VerifyToken(admin)
+	if err != nil || claims.UserId != "admin" {
+		t.Fatal(claims, err)
+	}
+	ctx := ContextWithClaimsHolder(context.Background())
+	if !SetContextClaims(ctx, claims) {
+		t.Fatal("missing claims holder")
+	}
+
+	//the token expires during the request: permission checks of the request use the claims verified at its start
+	now = now.Add(time.Hour)
+	if !security.IsAdmin(ctx, admin) {
+		t.Error("expected verified admin claims of context")
+	}
+	if security.IsAdmin(context.Background(), admin) {
+		t.Error("expired token without context claims must not be admin")
+	}
+	other := signTestToken(t, key, "k1", jwt.MapClaims{"sub": "admin", "roles": []string{"admin"}, "exp": now.Add(-time.Minute).Unix()})
+	if security.IsAdmin(ctx, other) {
+		t.Error("context claims must only be used for their token")
+	}
+	if userId := ContextUserId(ctx, admin); userId != "admin" {
+		t.Error(userId)
+	}
+}
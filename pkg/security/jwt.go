@@ -36,6 +36,10 @@ func ReadTokenRoles(token string) (roles []string, err error) {
 	if err != nil {
 		return roles, err
 	}
+	return readClaimRoles(claims)
+}
+
+func readClaimRoles(claims jwt.MapClaims) (roles []string, err error) {
 	temp, ok := claims["roles"].([]interface{})
 	if !ok {
 		return roles, errors.New("missing jwt roles")
@@ -50,3 +54,22 @@ func ReadTokenRoles(token string) (roles []string, err error) {
 	}
 	return
 }
+
+// ReadTokenUserId returns the subject of the (unverified) token
+func ReadTokenUserId(token string) (userId string, err error) {
+	authParts := strings.Split(token, " ")
+	if len(authParts) != 2 {
+		return userId, errors.New("expect auth string format like '<type> <token>'")
+	}
+	claims := jwt.MapClaims{}
+	parser := jwt.Parser{}
+	_, _, err = parser.ParseUnverified(authParts[1], &claims)
+	if err != nil {
+		return userId, err
+	}
+	userId, ok := claims["sub"].(string)
+	if !ok || userId == "" {
+		return "", errors.New("missing jwt sub")
+	}
+	return userId, nil
+}
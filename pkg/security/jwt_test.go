@@ -52,3 +52,17 @@ func TestReadTokenRoles2(t *testing.T) {
 		t.Error(roles)
 	}
 }
+
+func TestReadTokenUserId(t *testing.T) {
+	userId, err := ReadTokenUserId("Bearer eyJhbGciOiJSUzI1NiIsInR5cCIgOiAiSldUIiwia2lkIiA6ICIzaUtabW9aUHpsMmRtQnBJdS1vSkY4ZVVUZHh4OUFIckVOcG5CcHM5SjYwIn0.eyJleHAiOjE2MTQ4NTY5ODUsImlhdCI6MTYxNDg1MzM4NSwiYXV0aF90aW1lIjoxNjE0ODUzMzg0LCJqdGkiOiIwZTZiYTE2MC1kZGM3LTQ3ZjQtOWYzYi02ZTYwOTU1NmFmMzEiLCJpc3MiOiJodHRwczovL2F1dGguc2VuZXJneS5pbmZhaS5vcmcvYXV0aC9yZWFsbXMvbWFzdGVyIiwiYXVkIjoiYWNjb3VudCIsInN1YiI6IjhkYjViYjlmLWMxMjItNDAzYS1hMzIwLTlmZDk0ODU4YzFhOSIsInR5cCI6IkJlYXJlciIsImF6cCI6ImZyb250ZW5kIiwibm9uY2UiOiJlMzg4OTA0Mi00N2I5LTRiZWMtYTgwMi00MmZjMjVkYzIxZWUiLCJzZXNzaW9uX3N0YXRlIjoiNTUyMGJhYzktZmRkOC00MzUwLThjODEtNTEwOWVlOThiOGFmIiwiYWNyIjoiMSIsImFsbG93ZWQtb3JpZ2lucyI6WyIqIl0sInJlYWxtX2FjY2VzcyI6eyJyb2xlcyI6WyJvZmZsaW5lX2FjY2VzcyIsImRldmVsb3BlciIsInVtYV9hdXRob3JpemF0aW9uIiwidXNlciJdfSwicmVzb3VyY2VfYWNjZXNzIjp7ImFjY291bnQiOnsicm9sZXMiOlsibWFuYWdlLWFjY291bnQiLCJtYW5hZ2UtYWNjb3VudC1saW5rcyIsInZpZXctcHJvZmlsZSJdfX0sInNjb3BlIjoib3BlbmlkIHByb2ZpbGUgZW1haWwiLCJlbWFpbF92ZXJpZmllZCI6ZmFsc2UsInJvbGVzIjpbIm9mZmxpbmVfYWNjZXNzIiwiZGV2ZWxvcGVyIiwidW1hX2F1dGhvcml6YXRpb24iLCJ1c2VyIl0sInByZWZlcnJlZF91c2VybmFtZSI6InRlc3R3b2hudW5nIiwibG9jYWxlIjoiZW4ifQ.SKJ2wtH-b6ZMBdANmjVM8MdFKyC8cZGOYupuVNq86mG_CoGNT7JF6Php8GEsKypM6-sfyI31lHFhZC4zTg_t3PqOu_62ULZTpUEvsB9g2M2pi_vxPiWgeQA3ckPwTv4HdSKj2bx4uBmEOnju3S1TJKZLHm6hTcObZEnE1b5QT-OcKWrGos5IQLB4-HS_2uLJ9yYau0AhFYTRity9R6TqISpDR_8QNpRnl5pjzy6xybRtM7ZEM9LyruxrgWuyFrrC4jZ5GiO6mQItHbNJj3S0Rv_haHmhTf174Ipo_FSl87xLaeR-S4pld7xozrlxtkb6gI4rcbjfi1s8A4nKDjLpTw")
+	if err != nil {
+		t.Error(err)
+	}
+	if userId != "8db5bb9f-c122-403a-a320-9fd94858c1a9" {
+		t.Error(userId)
+	}
+	_, err = ReadTokenUserId("")
+	if err == nil {
+		t.Error("expected error for missing token")
+	}
+}
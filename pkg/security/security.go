@@ -17,21 +17,69 @@
 package security
 
 import (
+	"context"
+	"errors"
 	"time"
 
 	permv2 "github.com/SENERGY-Platform/permissions-v2/pkg/client"
 	"github.com/SENERGY-Platform/permissions-v2/pkg/model"
 	"github.com/SENERGY-Platform/process-sync/pkg/configuration"
+	"github.com/SENERGY-Platform/process-sync/pkg/metrics"
 )
 
 func New(config configuration.Config) *Security {
-	return &Security{config: config, permv2: permv2.New(config.PermissionsV2Url)}
+	return NewWithMetrics(config, nil)
+}
+
+// NewWithMetrics records the hits and misses of the permission cache in m
+func NewWithMetrics(config configuration.Config, m *metrics.Metrics) *Security {
+	result := &Security{config: config, permv2: permv2.New(config.PermissionsV2Url)}
+	jwksUrl := config.AuthJwksUrl
+	if jwksUrl == "" && config.AuthEndpoint != "" {
+		jwksUrl = config.AuthEndpoint + "/auth/realms/master/protocol/openid-connect/certs"
+	}
+	if jwksUrl != "" && jwksUrl != "-" {
+		cacheDuration := defaultJwksCacheDuration
+		if config.AuthJwksCacheDuration != "" {
+			duration, err := time.ParseDuration(config.AuthJwksCacheDuration)
+			if err != nil {
+				config.GetLogger().Warn("invalid auth_jwks_cache_duration --> use default", "error", err, "default", cacheDuration.String())
+			} else {
+				cacheDuration = duration
+			}
+		}
+		result.jwks = NewJwks(jwksUrl, cacheDuration, config.GetLogger())
+	}
+	if config.PermissionCacheDuration != "" && config.PermissionCacheDuration != "-" {
+		if result.jwks == nil {
+			//cached results are keyed by the sub claim, which can be forged without signature validation
+			config.GetLogger().Warn("permission cache needs token validation (auth_jwks_url) --> disable permission cache")
+			return result
+		}
+		duration, err := time.ParseDuration(config.PermissionCacheDuration)
+		if err != nil {
+			config.GetLogger().Warn("invalid permission_cache_duration --> disable permission cache", "error", err)
+			return result
+		}
+		var negativeDuration time.Duration
+		if config.PermissionCacheNegativeDuration != "" && config.PermissionCacheNegativeDuration != "-" {
+			negativeDuration, err = time.ParseDuration(config.PermissionCacheNegativeDuration)
+			if err != nil {
+				config.GetLogger().Warn("invalid permission_cache_negative_duration --> disable negative permission caching", "error", err)
+				negativeDuration = 0
+			}
+		}
+		result.cache = newPermissionCache(duration, negativeDuration, m)
+	}
+	return result
 }
 
 type Security struct {
 	config configuration.Config
 	openid *OpenidToken
 	permv2 permv2.Client
+	jwks   *Jwks            //nil if the signature validation is disabled
+	cache  *permissionCache //nil if the permission cache is disabled
 }
 
 type OpenidToken struct {
@@ -47,8 +95,53 @@ type IdWrapper struct {
 	Id string `json:"id"`
 }
 
-func (this *Security) IsAdmin(token string) bool {
-	roles, err := ReadTokenRoles(token)
+// VerifyToken returns ErrInvalidToken if the signature of the token is invalid or the token is expired; without jwks, every token is accepted.
+// the returned claims may be stored with SetContextClaims, so that permission checks of the request do not validate the token again
+func (this *Security) VerifyToken(token string) (claims Claims, err error) {
+	claims.Token = token
+	claims.Roles, claims.UserId, claims.readErr = this.readToken(context.Background(), token)
+	if errors.Is(claims.readErr, ErrInvalidToken) {
+		return claims, claims.readErr
+	}
+	return claims, nil
+}
+
+// InvalidatePermissions removes the cached permission checks of id; an empty id removes all cached permission checks
+func (this *Security) InvalidatePermissions(id string) {
+	if this.cache == nil {
+		return
+	}
+	this.cache.invalidate(id)
+}
+
+// readToken returns the roles and user id of the validated token; without jwks, of the unverified token.
+// claims verified by VerifyToken and stored in ctx are used without validating the token again
+func (this *Security) readToken(ctx context.Context, token string) (roles []string, userId string, err error) {
+	if claims, ok := ContextClaims(ctx, token); ok {
+		return claims.Roles, claims.UserId, claims.readErr
+	}
+	if this.jwks == nil {
+		roles, err = ReadTokenRoles(token)
+		if err != nil {
+			return roles, userId, err
+		}
+		userId, _ = ReadTokenUserId(token)
+		return roles, userId, nil
+	}
+	claims, err := this.jwks.ValidateToken(token)
+	if err != nil {
+		return nil, userId, err
+	}
+	roles, err = readClaimRoles(claims)
+	if err != nil {
+		return roles, userId, err
+	}
+	userId, _ = claims["sub"].(string)
+	return roles, userId, nil
+}
+
+func (this *Security) IsAdmin(ctx context.Context, token string) bool {
+	roles, _, err := this.readToken(ctx, token)
 	if err != nil {
 		this.config.GetLogger().Warn("unable to parse auth token to check if user is admin", "error", err)
 		return false
@@ -56,6 +149,20 @@ func (this *Security) IsAdmin(token string) bool {
 	return contains(roles, "admin")
 }
 
+// checkToken is like IsAdmin but returns ErrInvalidToken to prevent permission checks of invalid tokens;
+// userId is empty if the token has no subject
+func (this *Security) checkToken(ctx context.Context, token string) (admin bool, userId string, err error) {
+	roles, userId, err := this.readToken(ctx, token)
+	if errors.Is(err, ErrInvalidToken) {
+		return false, "", err
+	}
+	if err != nil {
+		this.config.GetLogger().Warn("unable to parse auth token to check if user is admin", "error", err)
+		return false, "", nil
+	}
+	return contains(roles, "admin"), userId, nil
+}
+
 func contains(s []string, e string) bool {
 	for _, a := range s {
 		if a == e {
@@ -65,34 +172,34 @@ func contains(s []string, e string) bool {
 	return false
 }
 
-func (this *Security) CheckBool(token string, kind string, id string, rights string) (allowed bool, err error) {
-	if this.IsAdmin(token) {
-		return true, nil
-	}
-	permList, err := model.PermissionListFromString(rights)
+func (this *Security) CheckBool(ctx context.Context, token string, kind string, id string, rights string) (allowed bool, err error) {
+	admin, userId, err := this.checkToken(ctx, token)
 	if err != nil {
 		return false, err
 	}
-	allowed, err, _ = this.permv2.CheckPermission(token, kind, id, permList...)
+	if admin {
+		return true, nil
+	}
+	result, err := this.checkPermissions(token, userId, kind, []string{id}, rights)
 	if err != nil {
 		return false, err
 	}
-	return allowed, nil
+	return result[id], nil
 }
 
-func (this *Security) CheckMultiple(token string, kind string, ids []string, rights string) (result map[string]bool, err error) {
+func (this *Security) CheckMultiple(ctx context.Context, token string, kind string, ids []string, rights string) (result map[string]bool, err error) {
 	result = map[string]bool{}
-	if this.IsAdmin(token) {
+	admin, userId, err := this.checkToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if admin {
 		for _, id := range ids {
 			result[id] = true
 		}
 		return result, nil
 	}
-	permList, err := model.PermissionListFromString(rights)
-	if err != nil {
-		return nil, err
-	}
-	result, err, _ = this.permv2.CheckMultiplePermissions(token, kind, ids, permList...)
+	result, err = this.checkPermissions(token, userId, kind, ids, rights)
 	if err != nil {
 		return nil, err
 	}
@@ -101,3 +208,23 @@ func (this *Security) CheckMultiple(token string, kind string, ids []string, rig
 	}
 	return result, nil
 }
+
+// checkPermissions asks permissions-v2 for the ids without cached result; checks of tokens without user id are not cached
+func (this *Security) checkPermissions(token string, userId string, kind string, ids []string, rights string) (result map[string]bool, err error) {
+	permList, err := model.PermissionListFromString(rights)
+	if err != nil {
+		return nil, err
+	}
+	load := func(ids []string) (result map[string]bool, err error) {
+		if len(ids) == 1 {
+			allowed, err, _ := this.permv2.CheckPermission(token, kind, ids[0], permList...)
+			return map[string]bool{ids[0]: allowed}, err
+		}
+		result, err, _ = this.permv2.CheckMultiplePermissions(token, kind, ids, permList...)
+		return result, err
+	}
+	if this.cache == nil || userId == "" {
+		return load(ids)
+	}
+	return this.cache.get(userId, kind, ids, rights, load)
+}
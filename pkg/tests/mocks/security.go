@@ -17,7 +17,10 @@
 package mocks
 
 import (
+	"context"
+
 	"github.com/SENERGY-Platform/process-sync/pkg/controller"
+	"github.com/SENERGY-Platform/process-sync/pkg/security"
 )
 
 func Security() (result controller.Security) {
@@ -31,14 +34,25 @@ func (this *SecurityMock) GetAdminToken() (token string, err error) {
 	panic("implement me")
 }
 
-func (this *SecurityMock) CheckBool(token string, kind string, id string, rights string) (allowed bool, err error) {
+func (this *SecurityMock) VerifyToken(token string) (claims security.Claims, err error) {
+	userId, _ := security.ReadTokenUserId(token)
+	return security.Claims{Token: token, UserId: userId}, nil
+}
+
+func (this *SecurityMock) IsAdmin(ctx context.Context, token string) bool {
+	return true
+}
+
+func (this *SecurityMock) CheckBool(ctx context.Context, token string, kind string, id string, rights string) (allowed bool, err error) {
 	return true, nil
 }
 
-func (this *SecurityMock) CheckMultiple(token string, kind string, ids []string, rights string) (result map[string]bool, err error) {
+func (this *SecurityMock) CheckMultiple(ctx context.Context, token string, kind string, ids []string, rights string) (result map[string]bool, err error) {
 	result = map[string]bool{}
 	for _, id := range ids {
 		result[id] = true
 	}
 	return result, nil
 }
+
+func (this *SecurityMock) InvalidatePermissions(id string) {}
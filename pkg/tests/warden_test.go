@@ -87,6 +87,12 @@ func TestWardenWithPreexistingDatabase(t *testing.T) {
 		MongoLastNetworkContactCollection: "last_network_contact",
 		MongoWardenCollection:             "warden",
 		MongoDeploymentWardenCollection:   "deployment_warden",
+		MongoScheduleCollection:           "schedules",
+		MongoLeaseCollection:              "leases",
+		MongoWardenDecisionCollection:     "warden_decisions",
+		MongoAuditCollection:              "audit",
+		MongoIncidentGroupCollection:      "incident_groups",
+		MongoWardenCheckCollection:        "warden_checks",
 
 		LogLevel:             "debug",
 		LoggerTrimFormat:     "100:[...]:100",
@@ -128,7 +134,7 @@ func TestWardenWithPreexistingDatabase(t *testing.T) {
 
 	mqttMsgMux := sync.Mutex{}
 	mqttMessages := map[string][]string{}
-	mqttclient := multimqtt.NewClient(config.Mqtt, func(options *paho.ClientOptions) {
+	mqttclient, err := multimqtt.NewClient(config.Mqtt, func(options *paho.ClientOptions) {
 		options.SetCleanSession(true)
 		options.SetResumeSubs(true)
 		options.SetConnectionLostHandler(func(c paho.Client, err error) {
@@ -146,6 +152,10 @@ func TestWardenWithPreexistingDatabase(t *testing.T) {
 			})
 		})
 	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
 
 	token := mqttclient.Connect()
 	if token.Wait() && token.Error() != nil {
@@ -785,6 +795,12 @@ func TestWardenWithClientReset(t *testing.T) {
 		MongoLastNetworkContactCollection: "last_network_collection",
 		MongoWardenCollection:             "warden",
 		MongoDeploymentWardenCollection:   "deployment_warden",
+		MongoScheduleCollection:           "schedules",
+		MongoLeaseCollection:              "leases",
+		MongoWardenDecisionCollection:     "warden_decisions",
+		MongoAuditCollection:              "audit",
+		MongoIncidentGroupCollection:      "incident_groups",
+		MongoWardenCheckCollection:        "warden_checks",
 
 		LogLevel:             "debug",
 		LoggerTrimFormat:     "100:[...]:100",
@@ -844,7 +860,7 @@ func TestWardenWithClientReset(t *testing.T) {
 
 	mqttMsgMux := sync.Mutex{}
 	mqttMessages := map[string][]string{}
-	client := multimqtt.NewClient(config.Mqtt, func(options *paho.ClientOptions) {
+	client, err := multimqtt.NewClient(config.Mqtt, func(options *paho.ClientOptions) {
 		options.SetCleanSession(true)
 		options.SetResumeSubs(true)
 		options.SetConnectionLostHandler(func(c paho.Client, err error) {
@@ -862,6 +878,10 @@ func TestWardenWithClientReset(t *testing.T) {
 			})
 		})
 	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
 
 	token := client.Connect()
 	if token.Wait() && token.Error() != nil {
@@ -1160,6 +1180,12 @@ func TestWardenWithParameterProcess(t *testing.T) {
 		MongoLastNetworkContactCollection: "last_network_collection",
 		MongoWardenCollection:             "warden",
 		MongoDeploymentWardenCollection:   "deployment_warden",
+		MongoScheduleCollection:           "schedules",
+		MongoLeaseCollection:              "leases",
+		MongoWardenDecisionCollection:     "warden_decisions",
+		MongoAuditCollection:              "audit",
+		MongoIncidentGroupCollection:      "incident_groups",
+		MongoWardenCheckCollection:        "warden_checks",
 
 		LogLevel: "debug",
 
@@ -1233,7 +1259,7 @@ func TestWardenWithParameterProcess(t *testing.T) {
 
 	mqttMsgMux := sync.Mutex{}
 	mqttMessages := map[string][]string{}
-	client := multimqtt.NewClient(config.Mqtt, func(options *paho.ClientOptions) {
+	client, err := multimqtt.NewClient(config.Mqtt, func(options *paho.ClientOptions) {
 		options.SetCleanSession(true)
 		options.SetResumeSubs(true)
 		options.SetConnectionLostHandler(func(c paho.Client, err error) {
@@ -1251,6 +1277,10 @@ func TestWardenWithParameterProcess(t *testing.T) {
 			})
 		})
 	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
 
 	token := client.Connect()
 	if token.Wait() && token.Error() != nil {
@@ -1497,6 +1527,12 @@ func TestWardenFailingProcess(t *testing.T) {
 		MongoLastNetworkContactCollection: "last_network_collection",
 		MongoWardenCollection:             "warden",
 		MongoDeploymentWardenCollection:   "deployment_warden",
+		MongoScheduleCollection:           "schedules",
+		MongoLeaseCollection:              "leases",
+		MongoWardenDecisionCollection:     "warden_decisions",
+		MongoAuditCollection:              "audit",
+		MongoIncidentGroupCollection:      "incident_groups",
+		MongoWardenCheckCollection:        "warden_checks",
 
 		LogLevel: "debug",
 
@@ -1570,7 +1606,7 @@ func TestWardenFailingProcess(t *testing.T) {
 
 	mqttMsgMux := sync.Mutex{}
 	mqttMessages := map[string][]string{}
-	client := multimqtt.NewClient(config.Mqtt, func(options *paho.ClientOptions) {
+	client, err := multimqtt.NewClient(config.Mqtt, func(options *paho.ClientOptions) {
 		options.SetCleanSession(true)
 		options.SetResumeSubs(true)
 		options.SetConnectionLostHandler(func(c paho.Client, err error) {
@@ -1588,6 +1624,10 @@ func TestWardenFailingProcess(t *testing.T) {
 			})
 		})
 	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
 
 	token := client.Connect()
 	if token.Wait() && token.Error() != nil {
@@ -1834,6 +1874,12 @@ func TestWardenStoppingProcess(t *testing.T) {
 		MongoLastNetworkContactCollection: "last_network_collection",
 		MongoWardenCollection:             "warden",
 		MongoDeploymentWardenCollection:   "deployment_warden",
+		MongoScheduleCollection:           "schedules",
+		MongoLeaseCollection:              "leases",
+		MongoWardenDecisionCollection:     "warden_decisions",
+		MongoAuditCollection:              "audit",
+		MongoIncidentGroupCollection:      "incident_groups",
+		MongoWardenCheckCollection:        "warden_checks",
 
 		LogLevel: "debug",
 
@@ -1907,7 +1953,7 @@ func TestWardenStoppingProcess(t *testing.T) {
 
 	mqttMsgMux := sync.Mutex{}
 	mqttMessages := map[string][]string{}
-	client := multimqtt.NewClient(config.Mqtt, func(options *paho.ClientOptions) {
+	client, err := multimqtt.NewClient(config.Mqtt, func(options *paho.ClientOptions) {
 		options.SetCleanSession(true)
 		options.SetResumeSubs(true)
 		options.SetConnectionLostHandler(func(c paho.Client, err error) {
@@ -1925,6 +1971,10 @@ func TestWardenStoppingProcess(t *testing.T) {
 			})
 		})
 	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
 
 	token := client.Connect()
 	if token.Wait() && token.Error() != nil {
@@ -2171,6 +2221,12 @@ func TestWardenLongRunningProcess(t *testing.T) {
 		MongoLastNetworkContactCollection: "last_network_collection",
 		MongoWardenCollection:             "warden",
 		MongoDeploymentWardenCollection:   "deployment_warden",
+		MongoScheduleCollection:           "schedules",
+		MongoLeaseCollection:              "leases",
+		MongoWardenDecisionCollection:     "warden_decisions",
+		MongoAuditCollection:              "audit",
+		MongoIncidentGroupCollection:      "incident_groups",
+		MongoWardenCheckCollection:        "warden_checks",
 
 		LogLevel: "debug",
 
@@ -2238,7 +2294,7 @@ func TestWardenLongRunningProcess(t *testing.T) {
 
 	mqttMsgMux := sync.Mutex{}
 	mqttMessages := map[string][]string{}
-	client := multimqtt.NewClient(config.Mqtt, func(options *paho.ClientOptions) {
+	client, err := multimqtt.NewClient(config.Mqtt, func(options *paho.ClientOptions) {
 		options.SetCleanSession(true)
 		options.SetResumeSubs(true)
 		options.SetConnectionLostHandler(func(c paho.Client, err error) {
@@ -2256,6 +2312,10 @@ func TestWardenLongRunningProcess(t *testing.T) {
 			})
 		})
 	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
 
 	token := client.Connect()
 	if token.Wait() && token.Error() != nil {
@@ -0,0 +1,133 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tracing
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/SENERGY-Platform/process-sync/pkg/configuration"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/SENERGY-Platform/process-sync"
+
+// Init exports spans to config.TracingOtlpEndpoint until ctx is done.
+// without endpoint, the global no-op tracer provider is kept, so that spans cost (almost) nothing.
+// the w3c trace context propagator is set in both cases to pass incoming trace contexts on to the mgw.
+func Init(ctx context.Context, config configuration.Config) error {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	if config.TracingOtlpEndpoint == "" || config.TracingOtlpEndpoint == "-" {
+		return nil
+	}
+	exporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(config.TracingOtlpEndpoint))
+	if err != nil {
+		return err
+	}
+	serviceName := config.TracingServiceName
+	if serviceName == "" {
+		serviceName = "process-sync"
+	}
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(serviceName)))
+	if err != nil {
+		return err
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(config.TracingSampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+	config.GetLogger().Info("export traces", "endpoint", config.TracingOtlpEndpoint, "sample-ratio", config.TracingSampleRatio)
+	go func() {
+		<-ctx.Done()
+		err := provider.Shutdown(context.Background())
+		if err != nil {
+			config.GetLogger().Error("unable to shutdown tracer provider", "error", err)
+		}
+	}()
+	return nil
+}
+
+func Start(ctx context.Context, name string, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationName).Start(ctx, name, trace.WithAttributes(attributes...))
+}
+
+func StartWithKind(ctx context.Context, name string, kind trace.SpanKind, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationName).Start(ctx, name, trace.WithSpanKind(kind), trace.WithAttributes(attributes...))
+}
+
+// End records err (if not nil) and ends the span
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// Call runs f in a child span of ctx
+func Call(ctx context.Context, name string, f func(ctx context.Context) error, attributes ...attribute.KeyValue) error {
+	ctx, span := Start(ctx, name, attributes...)
+	err := f(ctx)
+	End(span, err)
+	return err
+}
+
+// Inject returns the trace context of ctx as map, to be sent in message payloads
+func Inject(ctx context.Context) map[string]string {
+	carrier := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+	if len(carrier) == 0 {
+		return nil
+	}
+	return carrier
+}
+
+// Extract continues the trace context of a message payload
+func Extract(ctx context.Context, carrier map[string]string) context.Context {
+	if len(carrier) == 0 {
+		return ctx
+	}
+	return otel.GetTextMapPropagator().Extract(ctx, propagation.MapCarrier(carrier))
+}
+
+// Middleware starts a server span per request, continuing the trace context of the request header.
+// the span is named by the pattern of the matched route, so the middleware must be placed before the router.
+func Middleware(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		ctx := otel.GetTextMapPropagator().Extract(request.Context(), propagation.HeaderCarrier(request.Header))
+		ctx, span := otel.Tracer(instrumentationName).Start(ctx, request.Method+" "+request.URL.Path,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(semconv.HTTPRequestMethodKey.String(request.Method), semconv.URLPath(request.URL.Path)))
+		defer span.End()
+		request = request.WithContext(ctx)
+		handler.ServeHTTP(writer, request)
+		if request.Pattern != "" {
+			span.SetName(request.Pattern)
+			span.SetAttributes(semconv.HTTPRoute(request.Pattern))
+		}
+	})
+}
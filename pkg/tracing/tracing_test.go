@@ -0,0 +1,51 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestInjectExtract(t *testing.T) {
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	otel.SetTracerProvider(sdktrace.NewTracerProvider())
+
+	if carrier := Inject(context.Background()); carrier != nil {
+		t.Errorf("expected nil carrier without span, got %#v", carrier)
+	}
+
+	ctx, span := Start(context.Background(), "test")
+	defer span.End()
+	carrier := Inject(ctx)
+	if carrier["traceparent"] == "" {
+		t.Fatalf("missing traceparent in %#v", carrier)
+	}
+
+	extracted := trace.SpanContextFromContext(Extract(context.Background(), carrier))
+	if extracted.TraceID() != span.SpanContext().TraceID() {
+		t.Errorf("expected trace id %v, got %v", span.SpanContext().TraceID(), extracted.TraceID())
+	}
+	if !extracted.IsRemote() {
+		t.Error("expected remote span context")
+	}
+}
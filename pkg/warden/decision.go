@@ -0,0 +1,48 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package warden
+
+import (
+	"context"
+
+	"github.com/SENERGY-Platform/process-sync/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const (
+	DecisionStart              = "start"
+	DecisionRestart            = "restart"
+	DecisionStop               = "stop"
+	DecisionRedeploy           = "redeploy"
+	DecisionReplacePlaceholder = "replace_placeholder"
+	DecisionRemoveWarden       = "remove_warden"
+	DecisionGiveUp             = "give_up"
+)
+
+// decide executes action unless the warden runs in dry-run mode; the decision is logged in both cases.
+// each decision starts a new trace, which is continued by the commands sent to the mgw.
+func (this *GenericWarden[WardenInfo, DeploymentWardenInfo, ProcessInstance, History, Incident]) decide(entity any, decision string, reason string, action func(ctx context.Context) error) (err error) {
+	ctx, span := tracing.Start(context.Background(), "Warden."+decision, attribute.String("warden.reason", reason), attribute.Bool("warden.dry_run", this.config.DryRun))
+	defer func() {
+		tracing.End(span, err)
+	}()
+	if !this.config.DryRun {
+		err = action(ctx)
+	}
+	this.wardendb.LogDecision(entity, decision, reason, this.config.DryRun, err)
+	return err
+}
@@ -0,0 +1,208 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package warden
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// CheckRequest identifies the warden infos affected by a change; BusinessKey is preferred, DeploymentId is used as fallback
+type CheckRequest struct {
+	NetworkId    string
+	BusinessKey  string
+	DeploymentId string
+}
+
+const checkQueuePollInterval = time.Second
+
+var ErrCheckQueued = errors.New("warden check queued for the replica holding the warden db loop lease")
+var ErrNotDbLoopLeaseHolder = errors.New("warden checks are only allowed on the replica holding the warden db loop lease")
+
+// CheckQueue shares pending check requests between replicas. with leader election, requests arrive at any replica
+// (e.g. by shared mqtt subscriptions), but are handled only by the holder of the db loop lease.
+type CheckQueue interface {
+	// Push stores the request; a pending request for the same target is postponed to due
+	Push(request CheckRequest, due time.Time) error
+	// PopDue removes and returns the requests with a due time before or equal to now
+	PopDue(now time.Time) ([]CheckRequest, error)
+}
+
+type checkQueue struct {
+	mux     sync.Mutex
+	pending map[CheckRequest]time.Time //due time of the request
+}
+
+// EnqueueCheck schedules a targeted check of the affected warden infos.
+// the check is debounced by AgeGate: every new request for the same target postpones the check,
+// so that it runs when the state has settled and is no longer seen as immature by the check.
+func (this *GenericWarden[WardenInfo, DeploymentWardenInfo, ProcessInstance, History, Incident]) EnqueueCheck(request CheckRequest) {
+	if !this.config.EventChecks {
+		return
+	}
+	if request.BusinessKey == "" && request.DeploymentId == "" {
+		return
+	}
+	this.pushCheck(request, time.Now().Add(this.config.AgeGate))
+}
+
+func (this *GenericWarden[WardenInfo, DeploymentWardenInfo, ProcessInstance, History, Incident]) pushCheck(request CheckRequest, due time.Time) {
+	if this.config.CheckQueue != nil {
+		err := this.config.CheckQueue.Push(request, due)
+		if err != nil {
+			this.config.Logger.Error("unable to enqueue warden check --> rely on full scan", "error", err, "request", fmt.Sprintf("%+v", request))
+		}
+		return
+	}
+	this.queue.mux.Lock()
+	defer this.queue.mux.Unlock()
+	if this.queue.pending == nil {
+		this.queue.pending = map[CheckRequest]time.Time{}
+	}
+	this.queue.pending[request] = due
+}
+
+func (this *GenericWarden[WardenInfo, DeploymentWardenInfo, ProcessInstance, History, Incident]) popDueChecks(now time.Time) (result []CheckRequest) {
+	this.queue.mux.Lock()
+	defer this.queue.mux.Unlock()
+	for request, due := range this.queue.pending {
+		if !due.After(now) {
+			result = append(result, request)
+			delete(this.queue.pending, request)
+		}
+	}
+	return result
+}
+
+func (this *GenericWarden[WardenInfo, DeploymentWardenInfo, ProcessInstance, History, Incident]) startCheckQueue(ctx context.Context) {
+	ticker := time.NewTicker(checkQueuePollInterval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				if !this.holdsLease(DbLoopLease) {
+					continue
+				}
+				err := this.withRenewedLease(ctx, DbLoopLease, func(ctx context.Context) error {
+					return this.handleDueChecks(ctx, now)
+				})
+				if err != nil {
+					this.config.Logger.Error("error in event driven warden checks", "error", err)
+				}
+			}
+		}
+	}()
+}
+
+// handleDueChecks runs the due checks exclusive to the db loop; if ctx is canceled (e.g. by a lost lease), the remaining checks are queued again
+func (this *GenericWarden[WardenInfo, DeploymentWardenInfo, ProcessInstance, History, Incident]) handleDueChecks(ctx context.Context, now time.Time) error {
+	this.dbLoopMux.Lock()
+	defer this.dbLoopMux.Unlock()
+	var requests []CheckRequest
+	if this.config.CheckQueue != nil {
+		var err error
+		requests, err = this.config.CheckQueue.PopDue(now)
+		if err != nil {
+			return err
+		}
+	} else {
+		requests = this.popDueChecks(now)
+	}
+	for i, request := range requests {
+		if err := context.Cause(ctx); err != nil {
+			for _, remaining := range requests[i:] {
+				this.pushCheck(remaining, now)
+			}
+			return err
+		}
+		err := this.observeLoop("event_check", func() error {
+			return this.HandleCheckRequest(request)
+		})
+		if err != nil {
+			this.config.Logger.Error("error in event driven warden check", "error", err, "request", fmt.Sprintf("%+v", request))
+		}
+	}
+	return nil
+}
+
+// CheckWardenInfoExclusive runs CheckWardenInfo like the db loop: only on the replica holding the DbLoopLease and never concurrently with the db loop or event driven checks.
+// on other replicas, queued is pushed to the shared CheckQueue as due and ErrCheckQueued is returned; without CheckQueue, ErrNotDbLoopLeaseHolder is returned
+func (this *GenericWarden[WardenInfo, DeploymentWardenInfo, ProcessInstance, History, Incident]) CheckWardenInfoExclusive(ctx context.Context, info WardenInfo, queued CheckRequest) error {
+	if !this.holdsLease(DbLoopLease) {
+		if this.config.CheckQueue == nil {
+			return ErrNotDbLoopLeaseHolder
+		}
+		err := this.config.CheckQueue.Push(queued, time.Now())
+		if err != nil {
+			return err
+		}
+		return ErrCheckQueued
+	}
+	this.dbLoopMux.Lock()
+	defer this.dbLoopMux.Unlock()
+	return this.withRenewedLease(ctx, DbLoopLease, func(ctx context.Context) error {
+		return this.CheckWardenInfo(info)
+	})
+}
+
+// HandleCheckRequest checks all warden infos of the request target.
+// if a business key has no warden info, the process instances with this key are checked (and stopped if they are intended to be warden handled).
+func (this *GenericWarden[WardenInfo, DeploymentWardenInfo, ProcessInstance, History, Incident]) HandleCheckRequest(request CheckRequest) error {
+	this.config.Logger.Debug("handle warden check request", "request", fmt.Sprintf("%+v", request))
+	var infos []WardenInfo
+	var err error
+	if request.BusinessKey != "" {
+		infos, err = this.wardendb.GetWardenInfoByBusinessKey(request.NetworkId, request.BusinessKey)
+		if err != nil {
+			return err
+		}
+		if len(infos) == 0 {
+			instances, err := this.processes.GetInstancesByBusinessKey(request.NetworkId, request.BusinessKey)
+			if err != nil {
+				return err
+			}
+			for _, instance := range instances {
+				err = this.CheckProcessInstance(instance)
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	} else {
+		infos, err = this.wardendb.GetWardenInfoForDeploymentId(request.DeploymentId)
+		if err != nil {
+			return err
+		}
+	}
+	for _, info := range infos {
+		if info.IsPaused() {
+			continue
+		}
+		err = this.CheckWardenInfo(info)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,190 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package warden
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SENERGY-Platform/process-sync/pkg/model"
+)
+
+func TestCheckQueueDebounce(t *testing.T) {
+	w := &GenericWarden[WardenInfo, DeploymentWardenInfo, model.ProcessInstance, model.HistoricProcessInstance, model.Incident]{
+		config: Config{EventChecks: true, AgeGate: time.Minute},
+	}
+	a := CheckRequest{NetworkId: "n", BusinessKey: "a"}
+	b := CheckRequest{NetworkId: "n", DeploymentId: "d"}
+	w.EnqueueCheck(a)
+	w.EnqueueCheck(b)
+	w.EnqueueCheck(CheckRequest{NetworkId: "n"}) //no target --> ignored
+
+	if due := w.popDueChecks(time.Now()); len(due) != 0 {
+		t.Error("checks should wait for the age gate", due)
+		return
+	}
+
+	firstDue := w.queue.pending[a]
+	time.Sleep(10 * time.Millisecond)
+	w.EnqueueCheck(a)
+	if !w.queue.pending[a].After(firstDue) {
+		t.Error("repeated request should postpone the check")
+		return
+	}
+
+	due := w.popDueChecks(time.Now().Add(2 * time.Minute))
+	if len(due) != 2 {
+		t.Error(due)
+		return
+	}
+	if len(w.queue.pending) != 0 {
+		t.Error(w.queue.pending)
+		return
+	}
+}
+
+func TestCheckQueueDisabled(t *testing.T) {
+	w := &GenericWarden[WardenInfo, DeploymentWardenInfo, model.ProcessInstance, model.HistoricProcessInstance, model.Incident]{
+		config: Config{AgeGate: time.Minute},
+	}
+	w.EnqueueCheck(CheckRequest{NetworkId: "n", BusinessKey: "a"})
+	if due := w.popDueChecks(time.Now().Add(2 * time.Minute)); len(due) != 0 {
+		t.Error(due)
+	}
+}
+
+type testCheckQueue struct {
+	mux     sync.Mutex
+	pending map[CheckRequest]time.Time
+	pops    int
+}
+
+func (this *testCheckQueue) Push(request CheckRequest, due time.Time) error {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	if this.pending == nil {
+		this.pending = map[CheckRequest]time.Time{}
+	}
+	this.pending[request] = due
+	return nil
+}
+
+func (this *testCheckQueue) PopDue(now time.Time) (result []CheckRequest, err error) {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	this.pops++
+	for request, due := range this.pending {
+		if !due.After(now) {
+			result = append(result, request)
+			delete(this.pending, request)
+		}
+	}
+	return result, nil
+}
+
+func (this *testCheckQueue) popCount() int {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	return this.pops
+}
+
+func TestSharedCheckQueue(t *testing.T) {
+	queue := &testCheckQueue{}
+	w := NewGeneric[WardenInfo, DeploymentWardenInfo, model.ProcessInstance, model.HistoricProcessInstance, model.Incident](Config{EventChecks: true, AgeGate: time.Minute, CheckQueue: queue}, nil, nil)
+	a := CheckRequest{NetworkId: "n", BusinessKey: "a"}
+	b := CheckRequest{NetworkId: "n", BusinessKey: "b"}
+	w.EnqueueCheck(a)
+	w.EnqueueCheck(b)
+	if len(queue.pending) != 2 || len(w.queue.pending) != 0 {
+		t.Fatal("expect requests in shared queue", queue.pending, w.queue.pending)
+	}
+
+	ctx, cancel := context.WithCancelCause(context.Background())
+	cancel(ErrLeaseLost)
+	err := w.handleDueChecks(ctx, time.Now().Add(2*time.Minute))
+	if !errors.Is(err, ErrLeaseLost) {
+		t.Error(err)
+	}
+	if len(queue.pending) != 2 {
+		t.Error("expect requests of lost lease to be queued again", queue.pending)
+	}
+}
+
+func TestCheckQueueNeedsLease(t *testing.T) {
+	queue := &testCheckQueue{}
+	lease := &testLease{duration: time.Minute}
+	w := NewGeneric[WardenInfo, DeploymentWardenInfo, model.ProcessInstance, model.HistoricProcessInstance, model.Incident](Config{EventChecks: true, CheckQueue: queue, Lease: lease}, nil, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	w.startCheckQueue(ctx)
+
+	time.Sleep(checkQueuePollInterval + 200*time.Millisecond)
+	if queue.popCount() != 0 {
+		t.Fatal("expect no checks without db loop lease")
+	}
+	if lease.count() == 0 {
+		t.Fatal("expect lease request")
+	}
+
+	lease.set(true)
+	time.Sleep(checkQueuePollInterval)
+	if queue.popCount() == 0 {
+		t.Error("expect checks with db loop lease")
+	}
+}
+
+func TestCheckWardenInfoExclusive(t *testing.T) {
+	queue := &testCheckQueue{}
+	lease := &testLease{duration: time.Minute}
+	w := NewGeneric[WardenInfo, DeploymentWardenInfo, model.ProcessInstance, model.HistoricProcessInstance, model.Incident](Config{EventChecks: true, AgeGate: time.Hour, CheckQueue: queue, Lease: lease, Logger: slog.Default()}, nil, nil)
+	info := WardenInfo{NetworkId: "n", BusinessKey: "a", CreationTime: time.Now().Unix()} //immature --> the check takes no action
+	request := CheckRequest{NetworkId: "n", BusinessKey: "a"}
+
+	err := w.CheckWardenInfoExclusive(context.Background(), info, request)
+	if !errors.Is(err, ErrCheckQueued) {
+		t.Error(err)
+	}
+	if _, ok := queue.pending[request]; !ok {
+		t.Error("expect check queued for the lease holder", queue.pending)
+	}
+
+	lease.set(true)
+	w.dbLoopMux.Lock()
+	done := make(chan error)
+	go func() {
+		done <- w.CheckWardenInfoExclusive(context.Background(), info, request)
+	}()
+	select {
+	case <-done:
+		t.Fatal("check should wait for the db loop")
+	case <-time.After(100 * time.Millisecond):
+	}
+	w.dbLoopMux.Unlock()
+	if err = <-done; err != nil {
+		t.Error(err)
+	}
+
+	w = NewGeneric[WardenInfo, DeploymentWardenInfo, model.ProcessInstance, model.HistoricProcessInstance, model.Incident](Config{Lease: &testLease{duration: time.Minute}, Logger: slog.Default()}, nil, nil)
+	err = w.CheckWardenInfoExclusive(context.Background(), info, request)
+	if !errors.Is(err, ErrNotDbLoopLeaseHolder) {
+		t.Error(err)
+	}
+}
@@ -22,19 +22,28 @@ import (
 	"fmt"
 	"iter"
 	"log/slog"
+	"sync"
 	"time"
 
 	"github.com/SENERGY-Platform/process-sync/pkg/database"
 )
 
-type WardenInfoInterface interface {
+type WardenInfoInterface[WardenInfo any] interface {
 	IsOlderThen(time.Duration) bool
 	Validate() error
+	IsPaused() bool
+
+	RestartOnSuccess() bool
+	HasGivenUp() bool
+	NextRestartDelay() (delay time.Duration, giveUp bool)
+	RegisterRestart(now time.Time, reason string, afterIncident bool) WardenInfo
+	RegisterGiveUp(reason string) (updated WardenInfo, stopWardening bool)
 }
 
-type ProcessesInterface[WardenInfo WardenInfoInterface, DeploymentWardenInfo, ProcessInstance any, History any, Incident any] interface {
+type ProcessesInterface[WardenInfo WardenInfoInterface[WardenInfo], DeploymentWardenInfo, ProcessInstance any, History any, Incident any] interface {
 	AllInstances() iter.Seq2[ProcessInstance, error]
 	GetInstances(WardenInfo) ([]ProcessInstance, error)
+	GetInstancesByBusinessKey(networkId string, businessKey string) ([]ProcessInstance, error)
 	GetYoungestProcessInstance(instances []ProcessInstance) (ProcessInstance, error)
 	InstanceIsOlderThen(ProcessInstance, time.Duration) (bool, error)
 	InstanceIsCreatedWithWardenHandlingIntended(instance ProcessInstance) bool
@@ -45,22 +54,25 @@ type ProcessesInterface[WardenInfo WardenInfoInterface, DeploymentWardenInfo, Pr
 	GetInstanceHistories(WardenInfo) ([]History, error)
 	GetYoungestHistory([]History) (History, error) //by start time?
 	HistoryIsOlderThen(History, time.Duration) (bool, error)
+	HistoryEndIsOlderThen(history History, youngestIncident Incident, duration time.Duration) (bool, error) //measured from the end of the history, falls back to the time of the youngest incident
 
 	GetIncidents(History) ([]Incident, error)
 	GetYoungestIncident([]Incident) (Incident, error)
 	IncidentIsOlderThen(Incident, time.Duration) bool
 
-	Start(WardenInfo) error
-	Stop(ProcessInstance) error
+	Start(ctx context.Context, info WardenInfo) error
+	Stop(ctx context.Context, instance ProcessInstance) error
+	NotifyGiveUp(WardenInfo, Incident)
 
 	DeploymentExistsForWarden(WardenInfo) (exist bool, err error)
 	DeploymentExistsForDeploymentWarden(DeploymentWardenInfo) (exist bool, err error)
-	Redeploy(DeploymentWardenInfo) error
+	Redeploy(ctx context.Context, info DeploymentWardenInfo) error
 }
 
-type DbInterface[WardenInfo WardenInfoInterface, DeploymentWardenInfo any, ProcessInstance any] interface {
+type DbInterface[WardenInfo WardenInfoInterface[WardenInfo], DeploymentWardenInfo any, ProcessInstance any] interface {
 	ListWardenInfo() iter.Seq2[WardenInfo, error]
 	GetWardenInfoForInstance(ProcessInstance) ([]WardenInfo, error)
+	GetWardenInfoByBusinessKey(networkId string, businessKey string) ([]WardenInfo, error)
 	GetWardenInfoForDeploymentId(deploymentId string) ([]WardenInfo, error)
 	GetDeploymentWardenInfo(WardenInfo) (result DeploymentWardenInfo, exist bool, err error)
 	SetWardenInfo(WardenInfo) error
@@ -70,6 +82,9 @@ type DbInterface[WardenInfo WardenInfoInterface, DeploymentWardenInfo any, Proce
 	RemoveWardenInfoByBusinessKey(networkId string, businessKey string) error
 	ListDeploymentWardenInfo() iter.Seq2[DeploymentWardenInfo, error]
 	UpdateWardenInfoDeploymentId(networkId string, oldDeploymentId string, newDeploymentId string) error
+
+	// LogDecision persists the decision; entity is the WardenInfo, DeploymentWardenInfo or ProcessInstance the decision is about
+	LogDecision(entity any, decision string, reason string, dryRun bool, err error)
 }
 
 type Config struct {
@@ -79,15 +94,46 @@ type Config struct {
 	RunProcessLoop    bool
 	RunDeploymentLoop bool
 	Logger            *slog.Logger
+
+	Lease Lease //optional; if set, each loop runs only on the replica holding the lease of the loop
+
+	DryRun            bool          //if true, decisions are logged but not executed
+	DecisionLogMaxAge time.Duration //optional; logged decisions expire after this duration
+	Metrics           Metrics       //optional
+
+	EventChecks      bool          //if true, EnqueueCheck triggers targeted checks
+	CheckQueue       CheckQueue    //optional; shares the check requests of EnqueueCheck between replicas instead of queueing them locally
+	FullScanInterval time.Duration //optional; if set, the db and process loops run only once per FullScanInterval as safety net
 }
 
-type GenericWarden[WardenInfo WardenInfoInterface, DeploymentWardenInfo any, ProcessInstance any, History any, Incident any] struct {
+type Metrics interface {
+	WardenDecision(decision string, dryRun bool, failed bool)
+	WardenLoop(loop string, duration time.Duration)
+}
+
+type Lease interface {
+	TryAcquire(name string) (acquired bool, err error)
+	Release(name string) error
+	Duration() time.Duration
+}
+
+var ErrLeaseLost = errors.New("warden lease lost")
+
+const (
+	DeploymentLoopLease = "warden_deployment_loop"
+	DbLoopLease         = "warden_db_loop"
+	ProcessLoopLease    = "warden_process_loop"
+)
+
+type GenericWarden[WardenInfo WardenInfoInterface[WardenInfo], DeploymentWardenInfo any, ProcessInstance any, History any, Incident any] struct {
 	processes ProcessesInterface[WardenInfo, DeploymentWardenInfo, ProcessInstance, History, Incident]
 	wardendb  DbInterface[WardenInfo, DeploymentWardenInfo, ProcessInstance]
 	config    Config
+	queue     checkQueue
+	dbLoopMux sync.Mutex //prevents event driven checks from running concurrently with the db loop
 }
 
-func NewGeneric[WardenInfo WardenInfoInterface, DeploymentWardenInfo any, ProcessInstance any, History any, Incident any](config Config, processes ProcessesInterface[WardenInfo, DeploymentWardenInfo, ProcessInstance, History, Incident], db DbInterface[WardenInfo, DeploymentWardenInfo, ProcessInstance]) *GenericWarden[WardenInfo, DeploymentWardenInfo, ProcessInstance, History, Incident] {
+func NewGeneric[WardenInfo WardenInfoInterface[WardenInfo], DeploymentWardenInfo any, ProcessInstance any, History any, Incident any](config Config, processes ProcessesInterface[WardenInfo, DeploymentWardenInfo, ProcessInstance, History, Incident], db DbInterface[WardenInfo, DeploymentWardenInfo, ProcessInstance]) *GenericWarden[WardenInfo, DeploymentWardenInfo, ProcessInstance, History, Incident] {
 	if config.Logger == nil {
 		config.Logger = slog.Default()
 	}
@@ -152,30 +198,42 @@ func (this *GenericWarden[WardenInfo, DeploymentWardenInfo, ProcessInstance, His
 	if this.config.Interval == 0 {
 		return errors.New("invalid warden interval")
 	}
+	if this.config.EventChecks {
+		this.startCheckQueue(ctx)
+	}
 	ticker := time.NewTicker(this.config.Interval)
 	go func() {
+		lastFullScan := time.Time{}
 		for {
 			select {
 			case <-ctx.Done():
 				ticker.Stop()
+				this.releaseLeases()
 				return
 			case <-ticker.C:
 				now := time.Now()
 				this.config.Logger.Debug("start warden loop")
-				if this.config.RunDeploymentLoop {
-					err := this.LoopDeploymentWardenDb()
+				if this.config.RunDeploymentLoop && this.holdsLease(DeploymentLoopLease) {
+					err := this.runLeased(ctx, DeploymentLoopLease, "deployment", this.LoopDeploymentWardenDb)
 					if err != nil {
 						this.config.Logger.Error("error in deployment loop", "error", err)
 					}
 				}
-				if this.config.RunDbLoop {
-					err := this.LoopWardenDb()
+				fullScan := this.config.FullScanInterval <= 0 || now.Sub(lastFullScan) >= this.config.FullScanInterval
+				if fullScan {
+					lastFullScan = now
+				}
+				//leases are renewed on every tick, even if the full scan is skipped
+				if this.config.RunDbLoop && this.holdsLease(DbLoopLease) && fullScan {
+					this.dbLoopMux.Lock()
+					err := this.runLeased(ctx, DbLoopLease, "db", this.LoopWardenDb)
+					this.dbLoopMux.Unlock()
 					if err != nil {
 						this.config.Logger.Error("error in wardendb loop", "error", err)
 					}
 				}
-				if this.config.RunProcessLoop {
-					err := this.LoopProcesses()
+				if this.config.RunProcessLoop && this.holdsLease(ProcessLoopLease) && fullScan {
+					err := this.runLeased(ctx, ProcessLoopLease, "process", this.LoopProcesses)
 					if err != nil {
 						this.config.Logger.Error("error in process loop", "error", err)
 					}
@@ -188,12 +246,107 @@ func (this *GenericWarden[WardenInfo, DeploymentWardenInfo, ProcessInstance, His
 	return nil
 }
 
-func (this *GenericWarden[WardenInfo, DeploymentWardenInfo, ProcessInstance, History, Incident]) LoopDeploymentWardenDb() error {
+func (this *GenericWarden[WardenInfo, DeploymentWardenInfo, ProcessInstance, History, Incident]) observeLoop(name string, loop func() error) error {
+	start := time.Now()
+	err := loop()
+	if this.config.Metrics != nil {
+		this.config.Metrics.WardenLoop(name, time.Since(start))
+	}
+	return err
+}
+
+// runLeased runs a loop after holdsLease; the lease is renewed every third of its duration while the loop runs,
+// so that loops taking longer than the lease duration keep it. if a renewal fails, the loop stops before its next element.
+func (this *GenericWarden[WardenInfo, DeploymentWardenInfo, ProcessInstance, History, Incident]) runLeased(ctx context.Context, lease string, name string, loop func(ctx context.Context) error) error {
+	return this.withRenewedLease(ctx, lease, func(ctx context.Context) error {
+		return this.observeLoop(name, func() error {
+			return loop(ctx)
+		})
+	})
+}
+
+func (this *GenericWarden[WardenInfo, DeploymentWardenInfo, ProcessInstance, History, Incident]) withRenewedLease(ctx context.Context, lease string, f func(ctx context.Context) error) error {
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	if this.config.Lease != nil {
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			this.renewLease(ctx, cancel, lease)
+		}()
+		defer func() {
+			cancel(nil)
+			<-done
+		}()
+	}
+	return f(ctx)
+}
+
+func (this *GenericWarden[WardenInfo, DeploymentWardenInfo, ProcessInstance, History, Incident]) renewLease(ctx context.Context, cancel context.CancelCauseFunc, lease string) {
+	ticker := time.NewTicker(this.config.Lease.Duration() / 3)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if ctx.Err() != nil {
+				return
+			}
+			acquired, err := this.config.Lease.TryAcquire(lease)
+			if err != nil {
+				err = fmt.Errorf("%w: %w", ErrLeaseLost, err)
+			} else if !acquired {
+				err = ErrLeaseLost
+			}
+			if err != nil {
+				this.config.Logger.Error("unable to renew warden lease --> stop loop", "error", err, "lease", lease)
+				cancel(err)
+				return
+			}
+		}
+	}
+}
+
+// holdsLease acquires or renews the lease; without configured Lease every replica is allowed to run the loop
+func (this *GenericWarden[WardenInfo, DeploymentWardenInfo, ProcessInstance, History, Incident]) holdsLease(name string) bool {
+	if this.config.Lease == nil {
+		return true
+	}
+	acquired, err := this.config.Lease.TryAcquire(name)
+	if err != nil {
+		this.config.Logger.Error("unable to acquire warden lease --> skip loop", "error", err, "lease", name)
+		return false
+	}
+	if !acquired {
+		this.config.Logger.Debug("warden lease is held by other replica --> skip loop", "lease", name)
+	}
+	return acquired
+}
+
+// releaseLeases allows other replicas to take over without waiting for the lease expiration
+func (this *GenericWarden[WardenInfo, DeploymentWardenInfo, ProcessInstance, History, Incident]) releaseLeases() {
+	if this.config.Lease == nil {
+		return
+	}
+	for _, name := range []string{DeploymentLoopLease, DbLoopLease, ProcessLoopLease} {
+		err := this.config.Lease.Release(name)
+		if err != nil {
+			this.config.Logger.Error("unable to release warden lease", "error", err, "lease", name)
+		}
+	}
+}
+
+// LoopDeploymentWardenDb checks all deployment warden infos; the loop stops with context.Cause(ctx) if ctx is canceled
+func (this *GenericWarden[WardenInfo, DeploymentWardenInfo, ProcessInstance, History, Incident]) LoopDeploymentWardenDb(ctx context.Context) error {
 	it := this.wardendb.ListDeploymentWardenInfo()
 	for info, err := range it {
 		if err != nil {
 			return err
 		}
+		if err = context.Cause(ctx); err != nil {
+			return err
+		}
 		err = this.CheckDeploymentWardenInfo(info)
 		if err != nil {
 			this.config.Logger.Error("error in warden info check", "error", err)
@@ -202,12 +355,20 @@ func (this *GenericWarden[WardenInfo, DeploymentWardenInfo, ProcessInstance, His
 	return nil
 }
 
-func (this *GenericWarden[WardenInfo, DeploymentWardenInfo, ProcessInstance, History, Incident]) LoopWardenDb() error {
+// LoopWardenDb checks all warden infos; the loop stops with context.Cause(ctx) if ctx is canceled
+func (this *GenericWarden[WardenInfo, DeploymentWardenInfo, ProcessInstance, History, Incident]) LoopWardenDb(ctx context.Context) error {
 	it := this.wardendb.ListWardenInfo()
 	for info, err := range it {
 		if err != nil {
 			return err
 		}
+		if err = context.Cause(ctx); err != nil {
+			return err
+		}
+		if info.IsPaused() {
+			this.config.Logger.Debug("warden info is paused --> no action", "info", fmt.Sprintf("%+v", info))
+			continue
+		}
 		err = this.CheckWardenInfo(info)
 		if err != nil {
 			this.config.Logger.Error("error in warden info check", "error", err)
@@ -216,12 +377,16 @@ func (this *GenericWarden[WardenInfo, DeploymentWardenInfo, ProcessInstance, His
 	return nil
 }
 
-func (this *GenericWarden[WardenInfo, DeploymentWardenInfo, ProcessInstance, History, Incident]) LoopProcesses() error {
+// LoopProcesses checks all process instances; the loop stops with context.Cause(ctx) if ctx is canceled
+func (this *GenericWarden[WardenInfo, DeploymentWardenInfo, ProcessInstance, History, Incident]) LoopProcesses(ctx context.Context) error {
 	it := this.processes.AllInstances()
 	for instance, err := range it {
 		if err != nil {
 			return err
 		}
+		if err = context.Cause(ctx); err != nil {
+			return err
+		}
 		err = this.CheckProcessInstance(instance)
 		if err != nil {
 			this.config.Logger.Error("error in process instance check", "error", err)
@@ -268,18 +433,22 @@ func (this *GenericWarden[WardenInfo, DeploymentWardenInfo, ProcessInstance, His
 			return nil
 		}
 		this.config.Logger.Debug("process instance without warden info --> remove", "instance", fmt.Sprintf("%+v", instance))
-		return this.processes.Stop(instance)
+		return this.decide(instance, DecisionStop, "process instance without warden info", func(ctx context.Context) error {
+			return this.processes.Stop(ctx, instance)
+		})
 	}
 	return nil
 }
 
 func (this *GenericWarden[WardenInfo, DeploymentWardenInfo, ProcessInstance, History, Incident]) oldPlaceholderInstance(info WardenInfo, instance ProcessInstance) error {
 	this.config.Logger.Debug("old placeholder instance --> start process instance", "info", fmt.Sprintf("%+v", info))
-	err := this.processes.Stop(instance)
-	if err != nil {
-		this.config.Logger.Error("unable to stop old placeholder instance", "error", err)
-	}
-	return this.processes.Start(info)
+	return this.decide(info, DecisionReplacePlaceholder, "old placeholder instance", func(ctx context.Context) error {
+		err := this.processes.Stop(ctx, instance)
+		if err != nil {
+			this.config.Logger.Error("unable to stop old placeholder instance", "error", err)
+		}
+		return this.processes.Start(ctx, info)
+	})
 }
 
 func (this *GenericWarden[WardenInfo, DeploymentWardenInfo, ProcessInstance, History, Incident]) missingInstance(info WardenInfo) error {
@@ -303,16 +472,17 @@ func (this *GenericWarden[WardenInfo, DeploymentWardenInfo, ProcessInstance, His
 					return nil
 				case errors.Is(err, ErrFinal):
 					this.config.Logger.Error("unable to redeploy missing process-deployment --> remove warden", "error", err)
-					return this.RemoveInstanceWarden(info)
+					return this.removeInstanceWarden(info, "unable to redeploy missing process-deployment: "+err.Error())
 				default:
 					this.config.Logger.Error("unable to redeploy missing process-deployment --> remove warden", "error", err)
-					return this.RemoveInstanceWarden(info)
+					return this.removeInstanceWarden(info, "unable to redeploy missing process-deployment: "+err.Error())
 				}
 			}
 		}
 		this.config.Logger.Debug("missing process instance --> start process instance", "info", fmt.Sprintf("%+v", info))
-		err = this.processes.Start(info)
-		return err
+		return this.decide(info, DecisionStart, "missing process instance", func(ctx context.Context) error {
+			return this.processes.Start(ctx, info)
+		})
 	}
 	history, err := this.processes.GetYoungestHistory(histories)
 	if err != nil {
@@ -331,8 +501,12 @@ func (this *GenericWarden[WardenInfo, DeploymentWardenInfo, ProcessInstance, His
 		return err
 	}
 	if len(incidents) == 0 {
+		if info.RestartOnSuccess() {
+			this.config.Logger.Debug("process finished without incident and restart on success is configured --> restart", "info", fmt.Sprintf("%+v", info))
+			return this.restart(info, "finished without incident", false)
+		}
 		this.config.Logger.Debug("process finished without incident --> remove info from warden", "info", fmt.Sprintf("%+v", info))
-		return this.RemoveInstanceWarden(info)
+		return this.removeInstanceWarden(info, "process finished without incident")
 	}
 	youngest, err := this.processes.GetYoungestIncident(incidents)
 	if err != nil {
@@ -342,9 +516,56 @@ func (this *GenericWarden[WardenInfo, DeploymentWardenInfo, ProcessInstance, His
 		this.config.Logger.Debug("youngest process incident change is immature --> no action", "info", fmt.Sprintf("%+v", info))
 		return nil
 	}
+	if info.HasGivenUp() {
+		this.config.Logger.Debug("process finished with incident but restart policy gave up --> no action", "info", fmt.Sprintf("%+v", info))
+		return nil
+	}
+	delay, giveUp := info.NextRestartDelay()
+	if giveUp {
+		this.config.Logger.Info("process finished with incident and max restarts reached --> give up", "info", fmt.Sprintf("%+v", info))
+		return this.giveUp(info, youngest)
+	}
+	if delay > this.config.AgeGate {
+		isOlder, err = this.processes.HistoryEndIsOlderThen(history, youngest, delay)
+		if err != nil {
+			return err
+		}
+		if !isOlder {
+			this.config.Logger.Debug("process finished with incident but restart backoff is not reached --> no action", "info", fmt.Sprintf("%+v", info), "backoff", delay.String())
+			return nil
+		}
+	}
 	this.config.Logger.Debug("process finished with incident --> restart", "info", fmt.Sprintf("%+v", info))
-	err = this.processes.Start(info)
-	return err
+	return this.restart(info, "finished with incident", true)
+}
+
+// restart starts a new instance and persists the restart statistics in the warden info
+func (this *GenericWarden[WardenInfo, DeploymentWardenInfo, ProcessInstance, History, Incident]) restart(info WardenInfo, reason string, afterIncident bool) error {
+	return this.decide(info, DecisionRestart, reason, func(ctx context.Context) error {
+		err := this.processes.Start(ctx, info)
+		if err != nil {
+			return err
+		}
+		return this.wardendb.SetWardenInfo(info.RegisterRestart(time.Now(), reason, afterIncident))
+	})
+}
+
+func (this *GenericWarden[WardenInfo, DeploymentWardenInfo, ProcessInstance, History, Incident]) giveUp(info WardenInfo, incident Incident) error {
+	return this.decide(info, DecisionGiveUp, "max restarts reached", func(ctx context.Context) error {
+		this.processes.NotifyGiveUp(info, incident)
+		updated, stopWardening := info.RegisterGiveUp("max restarts reached")
+		if stopWardening {
+			return this.RemoveInstanceWarden(info)
+		}
+		return this.wardendb.SetWardenInfo(updated)
+	})
+}
+
+// removeInstanceWarden removes the warden info as decision of the warden (in contrast to RemoveInstanceWarden which is used for explicit requests)
+func (this *GenericWarden[WardenInfo, DeploymentWardenInfo, ProcessInstance, History, Incident]) removeInstanceWarden(info WardenInfo, reason string) error {
+	return this.decide(info, DecisionRemoveWarden, reason, func(ctx context.Context) error {
+		return this.RemoveInstanceWarden(info)
+	})
 }
 
 func (this *GenericWarden[WardenInfo, DeploymentWardenInfo, ProcessInstance, History, Incident]) duplicateInstances(info WardenInfo, instances []ProcessInstance) error {
@@ -365,7 +586,9 @@ func (this *GenericWarden[WardenInfo, DeploymentWardenInfo, ProcessInstance, His
 			continue
 		}
 		this.config.Logger.Debug("duplicate process instance --> stop", "info", fmt.Sprintf("%+v", info), "instance", fmt.Sprintf("%+v", instance))
-		err := this.processes.Stop(instance)
+		err := this.decide(instance, DecisionStop, "duplicate process instance", func(ctx context.Context) error {
+			return this.processes.Stop(ctx, instance)
+		})
 		if err != nil {
 			return err
 		}
@@ -401,7 +624,9 @@ func (this *GenericWarden[WardenInfo, DeploymentWardenInfo, ProcessInstance, His
 	if !exists {
 		return fmt.Errorf("no deployment found for warden info (%w)", ErrFinal)
 	}
-	return this.processes.Redeploy(depl)
+	return this.decide(depl, DecisionRedeploy, "missing process-deployment of warden info", func(ctx context.Context) error {
+		return this.processes.Redeploy(ctx, depl)
+	})
 }
 
 func (this *GenericWarden[WardenInfo, DeploymentWardenInfo, ProcessInstance, History, Incident]) CheckDeploymentWardenInfo(info DeploymentWardenInfo) error {
@@ -410,7 +635,9 @@ func (this *GenericWarden[WardenInfo, DeploymentWardenInfo, ProcessInstance, His
 		return err
 	}
 	if !exists {
-		return this.processes.Redeploy(info)
+		return this.decide(info, DecisionRedeploy, "missing process-deployment", func(ctx context.Context) error {
+			return this.processes.Redeploy(ctx, info)
+		})
 	}
 	return nil
 }
@@ -0,0 +1,125 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package warden
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SENERGY-Platform/process-sync/pkg/model"
+)
+
+type testLease struct {
+	mux      sync.Mutex
+	duration time.Duration
+	holds    bool
+	acquires int
+}
+
+func (this *testLease) TryAcquire(name string) (acquired bool, err error) {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	this.acquires++
+	return this.holds, nil
+}
+
+func (this *testLease) Release(name string) error {
+	return nil
+}
+
+func (this *testLease) Duration() time.Duration {
+	return this.duration
+}
+
+func (this *testLease) set(holds bool) {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	this.holds = holds
+}
+
+func (this *testLease) count() int {
+	this.mux.Lock()
+	defer this.mux.Unlock()
+	return this.acquires
+}
+
+func TestRunLeasedRenewsLease(t *testing.T) {
+	lease := &testLease{duration: 30 * time.Millisecond, holds: true}
+	w := NewGeneric[WardenInfo, DeploymentWardenInfo, model.ProcessInstance, model.HistoricProcessInstance, model.Incident](Config{Lease: lease}, nil, nil)
+
+	err := w.runLeased(context.Background(), DbLoopLease, "db", func(ctx context.Context) error {
+		time.Sleep(100 * time.Millisecond)
+		return context.Cause(ctx)
+	})
+	if err != nil {
+		t.Error(err)
+	}
+	if count := lease.count(); count < 3 {
+		t.Error("expect lease renewals during the loop", count)
+	}
+
+	renewals := lease.count()
+	time.Sleep(50 * time.Millisecond)
+	if lease.count() != renewals {
+		t.Error("expect no renewals after the loop")
+	}
+}
+
+func TestRunLeasedStopsOnLostLease(t *testing.T) {
+	lease := &testLease{duration: 30 * time.Millisecond, holds: true}
+	w := NewGeneric[WardenInfo, DeploymentWardenInfo, model.ProcessInstance, model.HistoricProcessInstance, model.Incident](Config{Lease: lease}, nil, nil)
+
+	checked := 0
+	err := w.runLeased(context.Background(), DbLoopLease, "db", func(ctx context.Context) error {
+		for range 100 {
+			if err := context.Cause(ctx); err != nil {
+				return err
+			}
+			if checked == 2 {
+				lease.set(false) //other replica took over
+			}
+			checked++
+			time.Sleep(5 * time.Millisecond)
+		}
+		return nil
+	})
+	if !errors.Is(err, ErrLeaseLost) {
+		t.Error(err)
+	}
+	if checked >= 100 {
+		t.Error("expect stopped loop", checked)
+	}
+}
+
+func TestHoldsLease(t *testing.T) {
+	lease := &testLease{duration: time.Minute}
+	w := NewGeneric[WardenInfo, DeploymentWardenInfo, model.ProcessInstance, model.HistoricProcessInstance, model.Incident](Config{Lease: lease}, nil, nil)
+	if w.holdsLease(DbLoopLease) {
+		t.Error("expect lease of other replica")
+	}
+	lease.set(true)
+	if !w.holdsLease(DbLoopLease) {
+		t.Error("expect lease")
+	}
+	w = NewGeneric[WardenInfo, DeploymentWardenInfo, model.ProcessInstance, model.HistoricProcessInstance, model.Incident](Config{}, nil, nil)
+	if !w.holdsLease(DbLoopLease) {
+		t.Error("expect every replica to run loops without lease")
+	}
+}
@@ -17,6 +17,7 @@
 package warden
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"iter"
@@ -40,9 +41,10 @@ type Processes struct {
 }
 
 type Controller interface {
-	StartDeploymentWithoutWardenHandling(networkId, processDeploymentId, businessKey string, startParameters map[string]interface{}) (err error, errCode int)
-	StopProcessInstanceWithoutWardenHandling(instance model.ProcessInstance) (err error, errCode int)
-	DeployProcessWithoutWardenHandling(networkId string, deployment model.DeploymentWithEventDesc) (err error)
+	StartDeploymentWithoutWardenHandling(ctx context.Context, networkId, processDeploymentId, businessKey string, startParameters map[string]interface{}) (err error, errCode int)
+	StopProcessInstanceWithoutWardenHandling(ctx context.Context, instance model.ProcessInstance) (err error, errCode int)
+	DeployProcessWithoutWardenHandling(ctx context.Context, networkId string, deployment model.DeploymentWithEventDesc) (err error)
+	NotifyWardenGiveUp(info model.WardenInfo, incident model.Incident)
 }
 
 func (this *Processes) AllInstances() iter.Seq2[model.ProcessInstance, error] {
@@ -78,6 +80,13 @@ func (this *Processes) GetInstances(info WardenInfo) ([]model.ProcessInstance, e
 	})
 }
 
+func (this *Processes) GetInstancesByBusinessKey(networkId string, businessKey string) ([]model.ProcessInstance, error) {
+	return this.db.FindProcessInstances(model.InstanceQuery{
+		NetworkIds:   []string{networkId},
+		BusinessKeys: []string{businessKey},
+	})
+}
+
 func (this *Processes) getInstanceDate(instance model.ProcessInstance) (time.Time, error) {
 	return cache.Use(this.cache, "process-instance-age."+instance.Id, func() (time.Time, error) {
 		history, err := this.db.ReadHistoricProcessInstance(instance.NetworkId, instance.Id)
@@ -168,6 +177,25 @@ func (this *Processes) HistoryIsOlderThen(history model.HistoricProcessInstance,
 	return date.Add(duration).Before(time.Now()), nil
 }
 
+// getHistoryEndDate returns the end time of the history; if it is missing or invalid, the time of the youngest incident or, if unknown, the history.SyncDate is used
+func (this *Processes) getHistoryEndDate(history model.HistoricProcessInstance, youngestIncident model.Incident) time.Time {
+	if history.EndTime != "" {
+		result, err := camundamodel.ParseCamundaTime(history.EndTime)
+		if err == nil {
+			return result
+		}
+		this.config.Logger.Error("unable to parse historic process instance end time --> use youngest incident time or history.SyncDate", "error", err, "historyId", history.Id, "networkId", history.NetworkId)
+	}
+	if !youngestIncident.Time.IsZero() {
+		return youngestIncident.Time
+	}
+	return history.SyncDate
+}
+
+func (this *Processes) HistoryEndIsOlderThen(history model.HistoricProcessInstance, youngestIncident model.Incident, duration time.Duration) (bool, error) {
+	return this.getHistoryEndDate(history, youngestIncident).Add(duration).Before(time.Now()), nil
+}
+
 func (this *Processes) GetIncidents(history model.HistoricProcessInstance) ([]model.Incident, error) {
 	return this.db.FindIncidents(model.IncidentQuery{
 		NetworkIds:         []string{history.NetworkId},
@@ -185,17 +213,21 @@ func (this *Processes) IncidentIsOlderThen(incident model.Incident, duration tim
 	return incident.Time.Add(duration).Before(time.Now())
 }
 
-func (this *Processes) Start(info WardenInfo) (err error) {
+func (this *Processes) Start(ctx context.Context, info WardenInfo) (err error) {
 	this.config.Logger.Debug("warden start process", "deployment-id", info.ProcessDeploymentId, "business-key", info.BusinessKey)
-	err, _ = this.ctrl.StartDeploymentWithoutWardenHandling(info.NetworkId, info.ProcessDeploymentId, info.BusinessKey, info.StartParameters)
+	err, _ = this.ctrl.StartDeploymentWithoutWardenHandling(ctx, info.NetworkId, info.ProcessDeploymentId, info.BusinessKey, info.StartParameters)
 	return
 }
 
-func (this *Processes) Stop(instance model.ProcessInstance) (err error) {
-	err, _ = this.ctrl.StopProcessInstanceWithoutWardenHandling(instance)
+func (this *Processes) Stop(ctx context.Context, instance model.ProcessInstance) (err error) {
+	err, _ = this.ctrl.StopProcessInstanceWithoutWardenHandling(ctx, instance)
 	return
 }
 
+func (this *Processes) NotifyGiveUp(info WardenInfo, incident model.Incident) {
+	this.ctrl.NotifyWardenGiveUp(info, incident)
+}
+
 func (this *Processes) DeploymentExistsForWarden(info WardenInfo) (exist bool, err error) {
 	return this.deploymentExistsId(info.NetworkId, info.ProcessDeploymentId)
 }
@@ -215,7 +247,7 @@ func (this *Processes) deploymentExistsId(networkId string, deploymentId string)
 	return !depl.MarkedForDelete && !depl.MarkedAsMissing, nil
 }
 
-func (this *Processes) Redeploy(info DeploymentWardenInfo) error {
+func (this *Processes) Redeploy(ctx context.Context, info DeploymentWardenInfo) error {
 	var exists, markedForDelete, markedAsMissing bool
 	exists = true
 	depl, err := this.db.ReadDeployment(info.NetworkId, info.DeploymentId)
@@ -230,7 +262,7 @@ func (this *Processes) Redeploy(info DeploymentWardenInfo) error {
 	markedAsMissing = depl.MarkedAsMissing
 	if !exists {
 		this.config.Logger.Debug("deployment does not exist, redeploying", "deploymentId", info.DeploymentId, "networkId", info.NetworkId)
-		err = this.ctrl.DeployProcessWithoutWardenHandling(info.NetworkId, info.Deployment)
+		err = this.ctrl.DeployProcessWithoutWardenHandling(ctx, info.NetworkId, info.Deployment)
 		if err != nil {
 			return errors.Join(fmt.Errorf("unable to redeploy process %v %v: %w", info.Deployment.Id, info.Deployment.Name, err), ErrRetry)
 		}
@@ -251,7 +283,7 @@ func (this *Processes) Redeploy(info DeploymentWardenInfo) error {
 		if err != nil {
 			return errors.Join(fmt.Errorf("unable to redeploy process %v %v (unable to remove old metadata): %w", info.Deployment.Id, info.Deployment.Name, err), ErrRetry)
 		}
-		err = this.ctrl.DeployProcessWithoutWardenHandling(info.NetworkId, info.Deployment)
+		err = this.ctrl.DeployProcessWithoutWardenHandling(ctx, info.NetworkId, info.Deployment)
 		if err != nil {
 			return errors.Join(fmt.Errorf("unable to redeploy process %v %v: %w", info.Deployment.Id, info.Deployment.Name, err), ErrRetry)
 		}
@@ -19,6 +19,7 @@ package warden
 import (
 	"context"
 	"errors"
+	"log/slog"
 	"slices"
 	"strconv"
 	"sync"
@@ -57,6 +58,12 @@ func TestProcesses_AllInstances(t *testing.T) {
 		MongoLastNetworkContactCollection: "last_network_collection",
 		MongoWardenCollection:             "warden",
 		MongoDeploymentWardenCollection:   "deployment_warden",
+		MongoScheduleCollection:           "schedules",
+		MongoLeaseCollection:              "leases",
+		MongoWardenDecisionCollection:     "warden_decisions",
+		MongoAuditCollection:              "audit",
+		MongoIncidentGroupCollection:      "incident_groups",
+		MongoWardenCheckCollection:        "warden_checks",
 	}
 
 	db, err := mongo.New(config)
@@ -172,3 +179,35 @@ func TestProcesses_GetYoungestProcessInstance_Error(t *testing.T) {
 		return
 	}
 }
+
+func TestProcesses_HistoryEndIsOlderThen(t *testing.T) {
+	processes := &Processes{config: Config{Logger: slog.Default()}}
+	now := time.Now()
+	history := model.HistoricProcessInstance{
+		HistoricProcessInstance: camundamodel.HistoricProcessInstance{
+			StartTime: now.Add(-10 * time.Hour).Format(camundamodel.CamundaTimeFormat),
+			EndTime:   now.Add(-time.Hour).Format(camundamodel.CamundaTimeFormat),
+		},
+		SyncInfo: model.SyncInfo{SyncDate: now.Add(-20 * time.Hour)},
+	}
+	incident := model.Incident{Incident: camundamodel.Incident{Time: now.Add(-3 * time.Hour)}}
+	for name, test := range map[string]struct {
+		history  model.HistoricProcessInstance
+		incident model.Incident
+		expected bool
+	}{
+		"end time":      {history: history, incident: incident, expected: false},
+		"incident time": {history: withEndTime(history, ""), incident: incident, expected: true},
+		"sync date":     {history: withEndTime(history, "invalid"), expected: true},
+	} {
+		older, err := processes.HistoryEndIsOlderThen(test.history, test.incident, 2*time.Hour)
+		if err != nil || older != test.expected {
+			t.Error(name, older, err)
+		}
+	}
+}
+
+func withEndTime(history model.HistoricProcessInstance, endTime string) model.HistoricProcessInstance {
+	history.EndTime = endTime
+	return history
+}
@@ -0,0 +1,232 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package warden
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/SENERGY-Platform/process-sync/pkg/model"
+)
+
+type testHistory struct {
+	start time.Time
+	end   time.Time //zero for histories without end time
+}
+
+type testIncident struct {
+	time time.Time
+}
+
+// restartProcesses simulates a warden info without running instance, whose last instance ran from history.start to history.end
+type restartProcesses struct {
+	ProcessesInterface[WardenInfo, DeploymentWardenInfo, model.ProcessInstance, testHistory, testIncident]
+	history   testHistory
+	incidents []testIncident
+	starts    int
+	notified  int
+}
+
+func (this *restartProcesses) GetInstances(WardenInfo) ([]model.ProcessInstance, error) {
+	return nil, nil
+}
+
+func (this *restartProcesses) GetInstanceHistories(WardenInfo) ([]testHistory, error) {
+	return []testHistory{this.history}, nil
+}
+
+func (this *restartProcesses) GetYoungestHistory(histories []testHistory) (testHistory, error) {
+	return histories[0], nil
+}
+
+func (this *restartProcesses) HistoryIsOlderThen(history testHistory, duration time.Duration) (bool, error) {
+	return time.Since(history.start) > duration, nil
+}
+
+func (this *restartProcesses) HistoryEndIsOlderThen(history testHistory, youngestIncident testIncident, duration time.Duration) (bool, error) {
+	end := history.end
+	if end.IsZero() {
+		end = youngestIncident.time
+	}
+	return time.Since(end) > duration, nil
+}
+
+func (this *restartProcesses) GetIncidents(testHistory) ([]testIncident, error) {
+	return this.incidents, nil
+}
+
+func (this *restartProcesses) GetYoungestIncident(incidents []testIncident) (testIncident, error) {
+	return incidents[0], nil
+}
+
+func (this *restartProcesses) IncidentIsOlderThen(incident testIncident, duration time.Duration) bool {
+	return time.Since(incident.time) > duration
+}
+
+func (this *restartProcesses) Start(ctx context.Context, info WardenInfo) error {
+	this.starts++
+	return nil
+}
+
+func (this *restartProcesses) NotifyGiveUp(WardenInfo, testIncident) {
+	this.notified++
+}
+
+type restartDb struct {
+	DbInterface[WardenInfo, DeploymentWardenInfo, model.ProcessInstance]
+	infos     map[string]WardenInfo
+	decisions []string
+}
+
+func (this *restartDb) SetWardenInfo(info WardenInfo) error {
+	this.infos[info.BusinessKey] = info
+	return nil
+}
+
+func (this *restartDb) RemoveWardenInfo(info WardenInfo) error {
+	delete(this.infos, info.BusinessKey)
+	return nil
+}
+
+func (this *restartDb) LogDecision(entity any, decision string, reason string, dryRun bool, err error) {
+	this.decisions = append(this.decisions, decision)
+}
+
+func TestRestartPolicy(t *testing.T) {
+	newInfo := func(policy *model.RestartPolicy, consecutiveFailures int) WardenInfo {
+		return WardenInfo{
+			CreationTime:        time.Now().Add(-24 * time.Hour).Unix(),
+			NetworkId:           "n1",
+			BusinessKey:         model.WardenBusinessKeyPrefix + "bk",
+			ProcessDeploymentId: "d1",
+			RestartPolicy:       policy,
+			ConsecutiveFailures: consecutiveFailures,
+		}
+	}
+	//histories ran for 10h before they ended historyAge ago, so that backoffs measured from the start would always be reached
+	setup := func(info WardenInfo, historyAge time.Duration, withIncident bool) (*GenericWarden[WardenInfo, DeploymentWardenInfo, model.ProcessInstance, testHistory, testIncident], *restartProcesses, *restartDb) {
+		end := time.Now().Add(-historyAge)
+		processes := &restartProcesses{history: testHistory{start: end.Add(-10 * time.Hour), end: end}}
+		if withIncident {
+			processes.incidents = []testIncident{{time: time.Now().Add(-historyAge)}}
+		}
+		db := &restartDb{infos: map[string]WardenInfo{info.BusinessKey: info}}
+		w := NewGeneric[WardenInfo, DeploymentWardenInfo, model.ProcessInstance, testHistory, testIncident](Config{AgeGate: time.Minute}, processes, db)
+		return w, processes, db
+	}
+	check := func(t *testing.T, w *GenericWarden[WardenInfo, DeploymentWardenInfo, model.ProcessInstance, testHistory, testIncident], info WardenInfo) {
+		t.Helper()
+		if err := w.CheckWardenInfo(info); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Run("backoff not reached", func(t *testing.T) {
+		info := newInfo(&model.RestartPolicy{Backoff: "1h"}, 1) //2h backoff after one failure
+		w, processes, db := setup(info, 90*time.Minute, true)
+		check(t, w, info)
+		if processes.starts != 0 || len(db.decisions) != 0 {
+			t.Error(processes.starts, db.decisions)
+		}
+	})
+
+	t.Run("backoff from incident without end time", func(t *testing.T) {
+		info := newInfo(&model.RestartPolicy{Backoff: "1h"}, 1)
+		w, processes, _ := setup(info, 90*time.Minute, true)
+		processes.history.end = time.Time{}
+		check(t, w, info)
+		if processes.starts != 0 {
+			t.Error("expect backoff measured from the youngest incident", processes.starts)
+		}
+	})
+
+	t.Run("backoff reached", func(t *testing.T) {
+		info := newInfo(&model.RestartPolicy{Backoff: "1h"}, 1)
+		w, processes, db := setup(info, 3*time.Hour, true)
+		check(t, w, info)
+		updated := db.infos[info.BusinessKey]
+		if processes.starts != 1 || updated.ConsecutiveFailures != 2 || updated.RestartCount != 1 || updated.LastRestart == 0 {
+			t.Error(processes.starts, updated)
+		}
+		if len(db.decisions) != 1 || db.decisions[0] != DecisionRestart {
+			t.Error(db.decisions)
+		}
+	})
+
+	t.Run("max backoff", func(t *testing.T) {
+		info := newInfo(&model.RestartPolicy{Backoff: "1h", MaxBackoff: "90m"}, 5)
+		w, processes, _ := setup(info, 100*time.Minute, true)
+		check(t, w, info)
+		if processes.starts != 1 {
+			t.Error("expect restart after max backoff", processes.starts)
+		}
+	})
+
+	t.Run("give up with notification", func(t *testing.T) {
+		info := newInfo(&model.RestartPolicy{MaxRestarts: 2}, 2)
+		w, processes, db := setup(info, time.Hour, true)
+		check(t, w, info)
+		updated, ok := db.infos[info.BusinessKey]
+		if !ok || !updated.GaveUp || processes.notified != 1 || processes.starts != 0 {
+			t.Error(ok, updated, processes.notified, processes.starts)
+		}
+		if len(db.decisions) != 1 || db.decisions[0] != DecisionGiveUp {
+			t.Error(db.decisions)
+		}
+
+		check(t, w, updated)
+		if processes.notified != 1 || processes.starts != 0 || len(db.decisions) != 1 {
+			t.Error("expect no action after give up", processes.notified, processes.starts, db.decisions)
+		}
+	})
+
+	t.Run("give up and stop wardening", func(t *testing.T) {
+		info := newInfo(&model.RestartPolicy{MaxRestarts: 1, GiveUpAction: model.RestartGiveUpStopWardening}, 1)
+		w, processes, db := setup(info, time.Hour, true)
+		check(t, w, info)
+		if _, ok := db.infos[info.BusinessKey]; ok || processes.notified != 1 {
+			t.Error("expect removed warden info", db.infos, processes.notified)
+		}
+	})
+
+	t.Run("restart on success resets failures", func(t *testing.T) {
+		info := newInfo(&model.RestartPolicy{MaxRestarts: 3, Backoff: "1h", RestartOnSuccess: true}, 2)
+		w, processes, db := setup(info, time.Hour, false)
+		check(t, w, info)
+		updated := db.infos[info.BusinessKey]
+		if processes.starts != 1 || updated.ConsecutiveFailures != 0 || updated.RestartCount != 1 {
+			t.Error(processes.starts, updated)
+		}
+		delay, giveUp := updated.NextRestartDelay()
+		if delay != time.Hour || giveUp {
+			t.Error("expect reset backoff", delay, giveUp)
+		}
+	})
+
+	t.Run("finished without restart on success", func(t *testing.T) {
+		info := newInfo(&model.RestartPolicy{MaxRestarts: 3}, 2)
+		w, processes, db := setup(info, time.Hour, false)
+		check(t, w, info)
+		if _, ok := db.infos[info.BusinessKey]; ok || processes.starts != 0 {
+			t.Error("expect removed warden info", db.infos, processes.starts)
+		}
+		if len(db.decisions) != 1 || db.decisions[0] != DecisionRemoveWarden {
+			t.Error(db.decisions)
+		}
+	})
+}
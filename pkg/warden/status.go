@@ -0,0 +1,96 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package warden
+
+const (
+	StatusHealthy           = "healthy"
+	StatusMissingInstance   = "missing_instance"
+	StatusDuplicate         = "duplicate"
+	StatusRestarting        = "restarting"
+	StatusWaitingForAgeGate = "waiting_for_age_gate"
+	StatusFinished          = "finished"
+	StatusGaveUp            = "gave_up"
+	StatusPaused            = "paused"
+	StatusRemoved           = "removed" //the warden info was removed by the check (e.g. finished process)
+)
+
+// Status computes the state of the warden info as it would be seen by CheckWardenInfo, without taking any action
+func (this *GenericWarden[WardenInfo, DeploymentWardenInfo, ProcessInstance, History, Incident]) Status(info WardenInfo) (string, error) {
+	if info.IsPaused() {
+		return StatusPaused, nil
+	}
+	if info.IsOlderThen(this.config.AgeGate) {
+		return StatusWaitingForAgeGate, nil
+	}
+	instances, err := this.processes.GetInstances(info)
+	if err != nil {
+		return "", err
+	}
+	switch len(instances) {
+	case 0:
+		return this.missingInstanceStatus(info)
+	case 1:
+		isOldPlaceholder, err := this.processes.InstanceIsOldPlaceholder(instances[0])
+		if err != nil {
+			return "", err
+		}
+		if isOldPlaceholder {
+			return StatusRestarting, nil
+		}
+		return StatusHealthy, nil
+	default:
+		return StatusDuplicate, nil
+	}
+}
+
+func (this *GenericWarden[WardenInfo, DeploymentWardenInfo, ProcessInstance, History, Incident]) missingInstanceStatus(info WardenInfo) (string, error) {
+	histories, err := this.processes.GetInstanceHistories(info)
+	if err != nil {
+		return "", err
+	}
+	if len(histories) == 0 {
+		return StatusMissingInstance, nil
+	}
+	history, err := this.processes.GetYoungestHistory(histories)
+	if err != nil {
+		return "", err
+	}
+	isOlder, err := this.processes.HistoryIsOlderThen(history, this.config.AgeGate)
+	if err != nil {
+		return "", err
+	}
+	if !isOlder {
+		return StatusWaitingForAgeGate, nil
+	}
+	incidents, err := this.processes.GetIncidents(history)
+	if err != nil {
+		return "", err
+	}
+	if len(incidents) == 0 {
+		if info.RestartOnSuccess() {
+			return StatusRestarting, nil
+		}
+		return StatusFinished, nil
+	}
+	if info.HasGivenUp() {
+		return StatusGaveUp, nil
+	}
+	if _, giveUp := info.NextRestartDelay(); giveUp {
+		return StatusGaveUp, nil
+	}
+	return StatusRestarting, nil
+}
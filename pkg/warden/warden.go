@@ -32,6 +32,9 @@ func New(config Config, ctrl Controller, db database.Database) (Warden, error) {
 	if err != nil {
 		return nil, err
 	}
+	if config.EventChecks && config.Lease != nil && config.CheckQueue == nil {
+		config.CheckQueue = &DbCheckQueue{db: db, batchsize: 100}
+	}
 	return NewGeneric(config, &Processes{
 		db:        db,
 		batchsize: 100,
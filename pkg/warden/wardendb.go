@@ -18,7 +18,10 @@ package warden
 
 import (
 	"iter"
+	"sync"
+	"time"
 
+	"github.com/SENERGY-Platform/process-sync/pkg/configuration"
 	"github.com/SENERGY-Platform/process-sync/pkg/database"
 	"github.com/SENERGY-Platform/process-sync/pkg/model"
 )
@@ -27,6 +30,20 @@ type WardenDb struct {
 	db        database.Database
 	batchsize int64
 	config    Config
+
+	dryRunMux       sync.Mutex
+	dryRunDecisions map[string]dryRunDecision //entity key --> last logged dry-run decision
+	dryRunSweep     time.Time
+}
+
+// dryRunRepeatInterval limits how often an unchanged dry-run decision is logged again;
+// in dry-run mode the warden decides the same on every loop, because the decisions are not executed
+const dryRunRepeatInterval = time.Hour
+
+type dryRunDecision struct {
+	decision string
+	reason   string
+	time     time.Time
 }
 
 func (this *WardenDb) ListDeploymentWardenInfo() iter.Seq2[DeploymentWardenInfo, error] {
@@ -43,6 +60,9 @@ func (this *WardenDb) ListDeploymentWardenInfo() iter.Seq2[DeploymentWardenInfo,
 				return
 			}
 			for _, depl := range batch {
+				if depl.Paused {
+					continue
+				}
 				if !yield(depl, nil) {
 					return
 				}
@@ -85,6 +105,10 @@ func (this *WardenDb) GetWardenInfoForInstance(instance model.ProcessInstance) (
 	return this.db.FindWardenInfo(model.WardenInfoQuery{NetworkIds: []string{instance.NetworkId}, BusinessKeys: []string{instance.BusinessKey}})
 }
 
+func (this *WardenDb) GetWardenInfoByBusinessKey(networkId string, businessKey string) ([]WardenInfo, error) {
+	return this.db.FindWardenInfo(model.WardenInfoQuery{NetworkIds: []string{networkId}, BusinessKeys: []string{businessKey}})
+}
+
 func (this *WardenDb) GetWardenInfoForDeploymentId(deploymentId string) ([]WardenInfo, error) {
 	return this.db.FindWardenInfo(model.WardenInfoQuery{ProcessDeploymentIds: []string{deploymentId}})
 }
@@ -155,3 +179,105 @@ func (this *WardenDb) UpdateWardenInfoDeploymentId(networkId string, oldDeployme
 	}
 	return nil
 }
+
+func (this *WardenDb) LogDecision(entity any, decision string, reason string, dryRun bool, err error) {
+	element := model.WardenDecision{
+		Id:       configuration.Id(),
+		Time:     configuration.TimeNow(),
+		Decision: decision,
+		Reason:   reason,
+		DryRun:   dryRun,
+	}
+	switch e := entity.(type) {
+	case model.WardenInfo:
+		element.Entity = model.WardenDecisionEntityWardenInfo
+		element.EntityId = e.BusinessKey
+		element.NetworkId = e.NetworkId
+		element.BusinessKey = e.BusinessKey
+		element.DeploymentId = e.ProcessDeploymentId
+	case model.DeploymentWardenInfo:
+		element.Entity = model.WardenDecisionEntityDeploymentWardenInfo
+		element.EntityId = e.DeploymentId
+		element.NetworkId = e.NetworkId
+		element.DeploymentId = e.DeploymentId
+	case model.ProcessInstance:
+		element.Entity = model.WardenDecisionEntityProcessInstance
+		element.EntityId = e.Id
+		element.NetworkId = e.NetworkId
+		element.BusinessKey = e.BusinessKey
+	}
+	if err != nil {
+		element.Error = err.Error()
+	}
+	//every decision is counted; repeated dry-run decisions are only deduplicated in the decision log
+	if this.config.Metrics != nil {
+		this.config.Metrics.WardenDecision(decision, dryRun, err != nil)
+	}
+	if dryRun && err == nil && this.isRepeatedDryRunDecision(element) {
+		this.config.Logger.Debug("repeated dry-run warden decision --> not logged", "decision", decision, "entity", element.Entity, "network-id", element.NetworkId, "entity-id", element.EntityId)
+		return
+	}
+	if this.config.DecisionLogMaxAge > 0 {
+		expiresAt := element.Time.Add(this.config.DecisionLogMaxAge)
+		element.ExpiresAt = &expiresAt
+	}
+	this.config.Logger.Info("warden decision", "decision", decision, "reason", reason, "dry-run", dryRun, "entity", element.Entity, "network-id", element.NetworkId, "entity-id", element.EntityId, "error", element.Error)
+	saveErr := this.db.SaveWardenDecision(element)
+	if saveErr != nil {
+		this.config.Logger.Error("unable to save warden decision", "error", saveErr)
+	}
+}
+
+// isRepeatedDryRunDecision returns true if the same decision was logged for the entity within dryRunRepeatInterval
+func (this *WardenDb) isRepeatedDryRunDecision(element model.WardenDecision) bool {
+	this.dryRunMux.Lock()
+	defer this.dryRunMux.Unlock()
+	if this.dryRunDecisions == nil {
+		this.dryRunDecisions = map[string]dryRunDecision{}
+	}
+	if element.Time.Sub(this.dryRunSweep) > dryRunRepeatInterval {
+		this.dryRunSweep = element.Time
+		for key, last := range this.dryRunDecisions {
+			if element.Time.Sub(last.time) > dryRunRepeatInterval {
+				delete(this.dryRunDecisions, key)
+			}
+		}
+	}
+	key := element.Entity + "/" + element.NetworkId + "/" + element.EntityId
+	last, ok := this.dryRunDecisions[key]
+	if ok && last.decision == element.Decision && last.reason == element.Reason && element.Time.Sub(last.time) < dryRunRepeatInterval {
+		return true
+	}
+	this.dryRunDecisions[key] = dryRunDecision{decision: element.Decision, reason: element.Reason, time: element.Time}
+	return false
+}
+
+// DbCheckQueue shares the event driven check requests between replicas by the database
+type DbCheckQueue struct {
+	db        database.Database
+	batchsize int64
+}
+
+func (this *DbCheckQueue) Push(request CheckRequest, due time.Time) error {
+	return this.db.PushWardenCheck(model.WardenCheckRequest{
+		NetworkId:    request.NetworkId,
+		BusinessKey:  request.BusinessKey,
+		DeploymentId: request.DeploymentId,
+		Due:          due,
+	})
+}
+
+func (this *DbCheckQueue) PopDue(now time.Time) (result []CheckRequest, err error) {
+	requests, err := this.db.PopDueWardenChecks(now, this.batchsize)
+	if err != nil {
+		return nil, err
+	}
+	for _, request := range requests {
+		result = append(result, CheckRequest{
+			NetworkId:    request.NetworkId,
+			BusinessKey:  request.BusinessKey,
+			DeploymentId: request.DeploymentId,
+		})
+	}
+	return result, nil
+}
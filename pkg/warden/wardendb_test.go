@@ -0,0 +1,89 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package warden
+
+import (
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/SENERGY-Platform/process-sync/pkg/configuration"
+	"github.com/SENERGY-Platform/process-sync/pkg/database"
+	"github.com/SENERGY-Platform/process-sync/pkg/metrics"
+	"github.com/SENERGY-Platform/process-sync/pkg/model"
+)
+
+type decisionDb struct {
+	database.Database
+	decisions []model.WardenDecision
+}
+
+func (this *decisionDb) SaveWardenDecision(decision model.WardenDecision) error {
+	this.decisions = append(this.decisions, decision)
+	return nil
+}
+
+func TestLogDecisionDryRunRepeats(t *testing.T) {
+	now := time.Now()
+	configuration.TimeNow = func() time.Time { return now }
+	defer func() { configuration.TimeNow = time.Now }()
+
+	db := &decisionDb{}
+	m := metrics.New()
+	wardenDb := &WardenDb{db: db, config: Config{Logger: slog.Default(), Metrics: m}}
+	a := model.WardenInfo{NetworkId: "n1", BusinessKey: "a"}
+	b := model.WardenInfo{NetworkId: "n1", BusinessKey: "b"}
+
+	wardenDb.LogDecision(a, DecisionStart, "missing process instance", true, nil)
+	wardenDb.LogDecision(a, DecisionStart, "missing process instance", true, nil)
+	wardenDb.LogDecision(b, DecisionStart, "missing process instance", true, nil)
+	if len(db.decisions) != 2 {
+		t.Fatal("expect one decision per entity", len(db.decisions))
+	}
+
+	wardenDb.LogDecision(a, DecisionRestart, "finished with incident", true, nil)
+	if len(db.decisions) != 3 {
+		t.Fatal("expect changed decision to be logged", len(db.decisions))
+	}
+
+	now = now.Add(dryRunRepeatInterval + time.Minute)
+	wardenDb.LogDecision(a, DecisionRestart, "finished with incident", true, nil)
+	if len(db.decisions) != 4 {
+		t.Fatal("expect repeated decision after the repeat interval", len(db.decisions))
+	}
+
+	wardenDb.LogDecision(b, DecisionStart, "missing process instance", false, nil)
+	wardenDb.LogDecision(b, DecisionStart, "missing process instance", false, nil)
+	if len(db.decisions) != 6 {
+		t.Fatal("expect every executed decision to be logged", len(db.decisions))
+	}
+
+	recorder := httptest.NewRecorder()
+	m.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	for _, expected := range []string{
+		`process_sync_warden_decisions_total{decision="start",dry_run="true",failed="false"} 3`,
+		`process_sync_warden_decisions_total{decision="restart",dry_run="true",failed="false"} 2`,
+		`process_sync_warden_decisions_total{decision="start",dry_run="false",failed="false"} 2`,
+	} {
+		if !strings.Contains(recorder.Body.String(), expected) {
+			t.Error("expect repeated dry-run decisions to be counted", expected)
+		}
+	}
+}